@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text_processors/ui"
+)
+
+// Codes de sortie du mode ligne de commande
+const (
+	exitOK        = 0
+	exitStepError = 1 // une étape du pipeline a échoué
+	exitUsage     = 2 // arguments invalides, fichiers illisibles, pipeline invalide
+)
+
+// runCommand exécute un pipeline exporté sans démarrer l'interface graphique.
+//
+// Usage: text_processors run --pipeline mon_pipeline.json [--input in.txt] [--output out.txt]
+func runCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	pipelinePath := fs.String("pipeline", "", "fichier pipeline JSON (créé avec le bouton Export)")
+	inputPath := fs.String("input", "", "fichier d'entrée (entrée standard si absent ou \"-\")")
+	outputPath := fs.String("output", "", "fichier de sortie (sortie standard si absent ou \"-\")")
+	// Sans interface pour signaler les étapes ignorées, un outil inconnu est une erreur par défaut
+	lenient := fs.Bool("lenient", false, "ignorer les étapes dont l'outil est inconnu de cette version au lieu de refuser le pipeline")
+	strict := fs.Bool("strict", false, "refuser les pipelines contenant des outils inconnus (comportement par défaut, conservé pour compatibilité)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: text_processors run --pipeline <fichier.json> [--input <fichier>] [--output <fichier>] [--lenient]")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *pipelinePath == "" {
+		fmt.Fprintln(stderr, "erreur: l'option --pipeline est requise")
+		fs.Usage()
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "erreur: arguments inattendus: %v\n", fs.Args())
+		return exitUsage
+	}
+
+	if *strict && *lenient {
+		fmt.Fprintln(stderr, "erreur: les options --strict et --lenient sont incompatibles")
+		return exitUsage
+	}
+
+	pipeline := &ui.Pipeline{}
+	if err := pipeline.LoadFromFileWithOptions(*pipelinePath, ui.LoadOptions{Strict: !*lenient}); err != nil {
+		fmt.Fprintf(stderr, "erreur: chargement du pipeline %s: %v\n", *pipelinePath, err)
+		return exitUsage
+	}
+	for _, step := range pipeline.UnknownSteps() {
+		fmt.Fprintf(stderr, "attention: étape %s ignorée (type d'outil inconnu: %s)\n", step.ID, step.Type)
+	}
+
+	input, err := readInput(*inputPath, stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "erreur: lecture de l'entrée: %v\n", err)
+		return exitUsage
+	}
+
+	result, err := ui.NewPipelineExecutor().Execute(pipeline, input)
+	if err != nil {
+		var stepErr *ui.StepError
+		if errors.As(err, &stepErr) {
+			fmt.Fprintf(stderr, "erreur: étape %d (%s) en échec: %v\n", stepErr.Index, stepErr.Name, stepErr.Err)
+			return exitStepError
+		}
+		fmt.Fprintf(stderr, "erreur: %v\n", err)
+		return exitUsage
+	}
+
+	if err := writeOutput(*outputPath, stdout, result); err != nil {
+		fmt.Fprintf(stderr, "erreur: écriture du résultat: %v\n", err)
+		return exitUsage
+	}
+	return exitOK
+}
+
+// readInput lit le texte à traiter depuis un fichier ou l'entrée standard
+func readInput(path string, stdin io.Reader) (string, error) {
+	if path == "" || path == "-" {
+		data, err := io.ReadAll(stdin)
+		return string(data), err
+	}
+	data, err := os.ReadFile(path)
+	return string(data), err
+}
+
+// writeOutput écrit le résultat dans un fichier ou sur la sortie standard
+func writeOutput(path string, stdout io.Writer, result string) error {
+	if path == "" || path == "-" {
+		_, err := io.WriteString(stdout, result)
+		return err
+	}
+	return os.WriteFile(path, []byte(result), 0644)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	// cliFormatterPipeline pipeline d'une étape de formatage JSON minifié
+	cliFormatterPipeline = `{"version": 2, "name": "min", "steps": [
+  {"id": "s1", "type": "json_formatter", "config": {"IndentType": "Minifié"}, "name": ""}
+]}`
+	// cliUnknownToolPipeline pipeline dont la première étape vient d'une version plus récente
+	cliUnknownToolPipeline = `{"version": 2, "name": "futur", "steps": [
+  {"id": "s1", "type": "outil_futur", "config": {"x": 1}, "name": ""},
+  {"id": "s2", "type": "json_formatter", "config": {"IndentType": "Minifié"}, "name": ""}
+]}`
+)
+
+func TestRunCommand(t *testing.T) {
+	dir := t.TempDir()
+	writePipeline := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	formatter := writePipeline("formatter.json", cliFormatterPipeline)
+	unknown := writePipeline("unknown.json", cliUnknownToolPipeline)
+
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantCode   int
+		wantStdout string
+		wantStderr string // Extrait attendu de la sortie d'erreur ("" : pas de vérification)
+	}{
+		{"succès", []string{"--pipeline", formatter}, `{ "a" : [1, 2] }`, exitOK, `{"a":[1,2]}` + "\n", ""},
+		{"entrée standard explicite", []string{"--pipeline", formatter, "--input", "-"}, `[ ]`, exitOK, "[]\n", ""},
+		{"étape en échec", []string{"--pipeline", formatter}, `{"a": }`, exitStepError, "", "étape 1"},
+		{"--pipeline manquant", nil, "", exitUsage, "", "--pipeline est requise"},
+		{"argument inattendu", []string{"--pipeline", formatter, "reste"}, "", exitUsage, "", "arguments inattendus"},
+		{"option inconnue", []string{"--pipline", formatter}, "", exitUsage, "", ""},
+		{"pipeline absent", []string{"--pipeline", filepath.Join(dir, "absent.json")}, "", exitUsage, "", "chargement du pipeline"},
+		{"fichier d'entrée absent", []string{"--pipeline", formatter, "--input", filepath.Join(dir, "absent.txt")}, "", exitUsage, "", "lecture de l'entrée"},
+		{"outil inconnu refusé par défaut", []string{"--pipeline", unknown}, `{}`, exitUsage, "", "outil_futur"},
+		{"outil inconnu refusé avec --strict", []string{"--strict", "--pipeline", unknown}, `{}`, exitUsage, "", "outil_futur"},
+		{"outil inconnu ignoré avec --lenient", []string{"--lenient", "--pipeline", unknown}, `{ }`, exitOK, "{}\n", "étape s1 ignorée"},
+		{"--strict et --lenient incompatibles", []string{"--strict", "--lenient", "--pipeline", formatter}, "", exitUsage, "", "incompatibles"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := runCommand(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Fatalf("code de sortie %d, attendu %d (stderr: %s)", code, tt.wantCode, stderr.String())
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("sortie %q, attendu %q", stdout.String(), tt.wantStdout)
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("sortie d'erreur %q, attendu un message contenant %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRunCommandOutputFile(t *testing.T) {
+	dir := t.TempDir()
+	pipeline := filepath.Join(dir, "p.json")
+	input := filepath.Join(dir, "in.json")
+	output := filepath.Join(dir, "out.json")
+	for path, content := range map[string]string{pipeline: cliFormatterPipeline, input: `{"b": true}`} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runCommand([]string{"--pipeline", pipeline, "--input", input, "--output", output}, strings.NewReader(""), &stdout, &stderr); code != exitOK {
+		t.Fatalf("code de sortie %d, attendu %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("sortie standard %q, attendu vide", stdout.String())
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"b":true}`+"\n"; got != want {
+		t.Errorf("fichier de sortie %q, attendu %q", got, want)
+	}
+}
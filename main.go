@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"text_processors/ui"
 
 	"fyne.io/fyne/v2"
@@ -9,6 +10,11 @@ import (
 )
 
 func main() {
+	// Mode ligne de commande: exécuter un pipeline sans démarrer l'interface graphique
+	if len(os.Args) > 1 && os.Args[1] == "run" {
+		os.Exit(runCommand(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	myApp := app.New()
 
 	// Charger les processeurs personnalisés depuis conf/ au démarrage
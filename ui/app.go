@@ -128,9 +128,16 @@ func MakeUI() fyne.CanvasObject {
 				fmt.Printf("Pipeline importé avec succès depuis %s\n", filePath)
 				// Notifier que le pipeline a été mis à jour
 				NotifyPipelineUpdated()
-				// Afficher une confirmation
+				// Afficher une confirmation, en signalant les étapes inconnues désactivées
+				message := fmt.Sprintf("Pipeline importé avec succès depuis :\n%s", filePath)
+				if unknown := CurrentPipeline.UnknownSteps(); len(unknown) > 0 {
+					message += "\n\nÉtapes désactivées (outils non disponibles dans cette version) :"
+					for _, step := range unknown {
+						message += fmt.Sprintf("\n- %s", step.Type)
+					}
+				}
 				infoDialog := dialog.NewInformation("Import réussi",
-					fmt.Sprintf("Pipeline importé avec succès depuis :\n%s", filePath),
+					message,
 					fyne.CurrentApp().Driver().AllWindows()[0])
 				infoDialog.Show()
 			}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"fyne.io/fyne/v2"
@@ -17,8 +18,20 @@ import (
 
 // CustomProcessorDefinition représente la définition d'un processeur personnalisé
 type CustomProcessorDefinition struct {
-	Name   string `json:"name"`
-	Script string `json:"script"`
+	Name          string `json:"name"`
+	Script        string `json:"script"`
+	TimeoutMs     int    `json:"timeout_ms,omitempty"`      // 0: délai par défaut
+	MaxOutputSize int    `json:"max_output_size,omitempty"` // En octets, 0: taille par défaut
+}
+
+// Config retourne la configuration de pipeline correspondant à la définition
+func (d CustomProcessorDefinition) Config() CustomProcessorConfig {
+	return CustomProcessorConfig{
+		Name:          d.Name,
+		Script:        d.Script,
+		TimeoutMs:     d.TimeoutMs,
+		MaxOutputSize: d.MaxOutputSize,
+	}
 }
 
 // computeConfDir retourne le chemin du dossier conf/ à côté de l'exécutable
@@ -157,11 +170,8 @@ func (cpm *CustomProcessorManager) fireUpdate() {
 	}
 }
 
-func (cpm *CustomProcessorManager) AddProcessor(name, script string) {
-	cpm.definitions = append(cpm.definitions, CustomProcessorDefinition{
-		Name:   name,
-		Script: script,
-	})
+func (cpm *CustomProcessorManager) AddProcessor(def CustomProcessorDefinition) {
+	cpm.definitions = append(cpm.definitions, def)
 	// Sauvegarde immédiate
 	if err := cpm.SaveAll(); err != nil {
 		fmt.Printf("[WARN] échec de la sauvegarde des processeurs personnalisés: %v\n", err)
@@ -241,6 +251,10 @@ return lines.map(function(line, index) {
 		scriptEntry.SetText(script)
 	})
 
+	// Délai d'exécution maximal du script
+	timeoutEntry := widget.NewEntry()
+	timeoutEntry.SetPlaceHolder(fmt.Sprintf("%d (par défaut)", processors.DefaultScriptTimeout.Milliseconds()))
+
 	// Zone de test
 	testInput := widget.NewEntry()
 	testInput.SetPlaceHolder("Texte de test...")
@@ -255,21 +269,16 @@ return lines.map(function(line, index) {
 			return
 		}
 
-		// Créer un processeur temporaire pour tester
-		tempProcessor := processors.NewCustomProcessor(nameEntry.Text, scriptEntry.Text)
-		vm := tempProcessor.ViewModel()
-
-		// Charger la configuration
-		config := struct{ Name, Script string }{
-			Name:   nameEntry.Text,
-			Script: scriptEntry.Text,
-		}
-		err := vm.LoadConfiguration(config)
+		timeoutMs, err := parseTimeoutMs(timeoutEntry.Text)
 		if err != nil {
-			testOutput.SetText(fmt.Sprintf("Erreur de configuration: %v", err))
+			testOutput.SetText(fmt.Sprintf("Erreur: %v", err))
 			return
 		}
 
+		// Créer un processeur temporaire pour tester
+		def := CustomProcessorDefinition{Name: nameEntry.Text, Script: scriptEntry.Text, TimeoutMs: timeoutMs}
+		vm := processors.NewCustomProcessorWithOptions(def.Config().Options()).ViewModel()
+
 		// Tester avec l'entrée
 		result, err := vm.Process(testInput.Text)
 		if err != nil {
@@ -292,6 +301,11 @@ return lines.map(function(line, index) {
 		widget.NewLabel("Script JavaScript:"),
 		container.NewScroll(scriptEntry),
 
+		container.NewHBox(
+			widget.NewLabel("Délai max (ms):"),
+			timeoutEntry,
+		),
+
 		widget.NewSeparator(),
 		widget.NewLabel("Test du processeur:"),
 		container.NewHBox(
@@ -314,19 +328,15 @@ return lines.map(function(line, index) {
 			return
 		}
 
-		// Valider le script en créant un processeur temporaire
-		tempProcessor := processors.NewCustomProcessor(nameEntry.Text, scriptEntry.Text)
-		vm := tempProcessor.ViewModel()
-		config := struct{ Name, Script string }{
-			Name:   nameEntry.Text,
-			Script: scriptEntry.Text,
-		}
-		err := vm.LoadConfiguration(config)
+		timeoutMs, err := parseTimeoutMs(timeoutEntry.Text)
 		if err != nil {
-			dialog.ShowError(fmt.Errorf("erreur de configuration: %v", err), parent)
+			dialog.ShowError(err, parent)
 			return
 		}
 
+		// Valider le script en créant un processeur temporaire
+		def := CustomProcessorDefinition{Name: nameEntry.Text, Script: scriptEntry.Text, TimeoutMs: timeoutMs}
+		vm := processors.NewCustomProcessorWithOptions(def.Config().Options()).ViewModel()
 		err = vm.Validate()
 		if err != nil {
 			dialog.ShowError(fmt.Errorf("validation échouée: %v", err), parent)
@@ -334,7 +344,7 @@ return lines.map(function(line, index) {
 		}
 
 		// Ajouter le processeur
-		GlobalCustomProcessorManager.AddProcessor(nameEntry.Text, scriptEntry.Text)
+		GlobalCustomProcessorManager.AddProcessor(def)
 
 		// Afficher confirmation
 		dialog.ShowInformation("Succès",
@@ -354,3 +364,16 @@ return lines.map(function(line, index) {
 	customDialog.Resize(fyne.NewSize(600, 700))
 	customDialog.Show()
 }
+
+// parseTimeoutMs lit un délai en millisecondes saisi par l'utilisateur (vide: délai par défaut)
+func parseTimeoutMs(text string) (int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	ms, err := strconv.Atoi(text)
+	if err != nil || ms < 0 {
+		return 0, fmt.Errorf("délai invalide: %q (nombre de millisecondes attendu)", text)
+	}
+	return ms, nil
+}
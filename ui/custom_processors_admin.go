@@ -2,11 +2,13 @@ package ui
 
 import (
 	"fmt"
+	"strconv"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
+	"text_processors/ui/processors"
 )
 
 // CreateManageCustomProcessorsDialog affiche une fenêtre de gestion des processeurs personnalisés
@@ -98,12 +100,21 @@ func CreateManageCustomProcessorsDialog(parent fyne.Window) {
 		scriptEntry := widget.NewMultiLineEntry()
 		scriptEntry.SetPlaceHolder("Script JavaScript (fonction process(input) { return input; })")
 		scriptEntry.SetText(current.Script)
+		timeoutEntry := widget.NewEntry()
+		timeoutEntry.SetPlaceHolder(fmt.Sprintf("%d (par défaut)", processors.DefaultScriptTimeout.Milliseconds()))
+		if current.TimeoutMs > 0 {
+			timeoutEntry.SetText(strconv.Itoa(current.TimeoutMs))
+		}
 		// UI du dialogue
 		content := container.NewVBox(
 			widget.NewLabel("Nom:"),
 			nameEntry,
 			widget.NewLabel("Script JavaScript:"),
 			container.NewVScroll(scriptEntry),
+			container.NewHBox(
+				widget.NewLabel("Délai max (ms):"),
+				timeoutEntry,
+			),
 		)
 		d := dialog.NewCustomConfirm("Éditer le processeur", "Enregistrer", "Annuler", content, func(ok bool) {
 			if !ok {
@@ -119,10 +130,16 @@ func CreateManageCustomProcessorsDialog(parent fyne.Window) {
 				dialog.ShowError(fmt.Errorf("le script est requis"), parent)
 				return
 			}
+			timeoutMs, err := parseTimeoutMs(timeoutEntry.Text)
+			if err != nil {
+				dialog.ShowError(err, parent)
+				return
+			}
 			// Appliquer
 			procs := GlobalCustomProcessorManager.GetProcessors()
 			procs[selectedIndex].Name = newName
 			procs[selectedIndex].Script = newScript
+			procs[selectedIndex].TimeoutMs = timeoutMs
 			GlobalCustomProcessorManager.definitions = procs
 			if err := GlobalCustomProcessorManager.SaveAll(); err != nil {
 				dialog.ShowError(err, parent)
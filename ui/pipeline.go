@@ -1,120 +1,37 @@
 package ui
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"strings"
+	"path/filepath"
 	"text_processors/ui/processors"
+	"time"
 )
 
 // ToolType représente le type d'outil dans le pipeline
-type ToolType string
+type ToolType = processors.ToolType
 
+// Types des outils de base. Chaque processeur enregistre son type, sa configuration
+// et son codec dans le package processors (voir processors.Register).
 const (
-	JSONFormatterTool   ToolType = "json_formatter"
-	TextSplitterTool    ToolType = "text_splitter"
-	TextJoinerTool      ToolType = "text_joiner"
-	CustomProcessorTool ToolType = "custom_processor"
+	JSONFormatterTool   = processors.JSONFormatterTool
+	TextSplitterTool    = processors.TextSplitterTool
+	TextJoinerTool      = processors.TextJoinerTool
+	CustomProcessorTool = processors.CustomProcessorTool
 )
 
 // ToolConfig interface commune pour toutes les configurations d'outils
-type ToolConfig interface {
-	GetType() ToolType
-	Validate() error
-	GetDisplayName() string
-}
-
-// JSONFormatterConfig configuration pour le formateur JSON
-type JSONFormatterConfig struct {
-	IndentType string // "2 espaces", "4 espaces", "Tabulations"
-}
-
-func (c JSONFormatterConfig) GetType() ToolType {
-	return JSONFormatterTool
-}
-
-func (c JSONFormatterConfig) Validate() error {
-	validTypes := []string{"2 espaces", "4 espaces", "Tabulations"}
-	for _, valid := range validTypes {
-		if c.IndentType == valid {
-			return nil
-		}
-	}
-	return fmt.Errorf("type d'indentation invalide: %s", c.IndentType)
-}
-
-func (c JSONFormatterConfig) GetDisplayName() string {
-	return fmt.Sprintf("JSON Formatter (Indentation: %s)", c.IndentType)
-}
-
-// TextSplitterConfig configuration pour le diviseur de texte
-type TextSplitterConfig struct {
-	Delimiter string
-}
-
-func (c TextSplitterConfig) GetType() ToolType {
-	return TextSplitterTool
-}
-
-func (c TextSplitterConfig) Validate() error {
-	// Le délimiteur peut être vide (utilise \n par défaut)
-	return nil
-}
-
-func (c TextSplitterConfig) GetDisplayName() string {
-	delimiter := c.Delimiter
-	if delimiter == "" {
-		delimiter = "\\n (par défaut)"
-	}
-	return fmt.Sprintf("Text Splitter (Délimiteur: %s)", delimiter)
-}
-
-// TextJoinerConfig configuration pour le joigneur de texte
-type TextJoinerConfig struct {
-	Delimiter string
-}
-
-func (c TextJoinerConfig) GetType() ToolType {
-	return TextJoinerTool
-}
-
-func (c TextJoinerConfig) Validate() error {
-	// Le délimiteur peut être vide
-	return nil
-}
-
-func (c TextJoinerConfig) GetDisplayName() string {
-	delimiter := c.Delimiter
-	if delimiter == "" {
-		delimiter = "(vide)"
-	}
-	return fmt.Sprintf("Text Joiner (Délimiteur: %s)", delimiter)
-}
-
-// CustomProcessorConfig configuration pour le processeur personnalisé
-type CustomProcessorConfig struct {
-	Name   string `json:"name"`
-	Script string `json:"script"`
-}
-
-func (c CustomProcessorConfig) GetType() ToolType {
-	return CustomProcessorTool
-}
-
-func (c CustomProcessorConfig) Validate() error {
-	if c.Name == "" {
-		return fmt.Errorf("le nom du processeur ne peut pas être vide")
-	}
-	if c.Script == "" {
-		return fmt.Errorf("le script ne peut pas être vide")
-	}
-	return nil
-}
-
-func (c CustomProcessorConfig) GetDisplayName() string {
-	return fmt.Sprintf("Custom Processor (%s)", c.Name)
-}
+type ToolConfig = processors.ToolConfig
+
+// Configurations des outils de base
+type (
+	JSONFormatterConfig   = processors.JSONFormatterConfig
+	TextSplitterConfig    = processors.TextSplitterConfig
+	TextJoinerConfig      = processors.TextJoinerConfig
+	CustomProcessorConfig = processors.CustomProcessorConfig
+)
 
 // PipelineStep représente une étape dans le pipeline
 type PipelineStep struct {
@@ -122,13 +39,31 @@ type PipelineStep struct {
 	Type      ToolType             `json:"type"`
 	Config    interface{}          `json:"config"`
 	Name      string               `json:"name"`
+	Disabled  bool                 `json:"disabled,omitempty"` // Étape ignorée à l'exécution
 	Processor processors.Processor `json:"-"`
 }
 
+// DisplayName retourne le nom de l'étape, ou celui de son processeur à défaut
+func (s PipelineStep) DisplayName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Processor.Name()
+}
+
+// IsActive indique si l'étape est exécutée: ni désactivée, ni d'un type d'outil inconnu
+func (s PipelineStep) IsActive() bool {
+	if _, unknown := s.Config.(UnknownToolConfig); unknown {
+		return false
+	}
+	return !s.Disabled
+}
+
 // Variable globale du pipeline actuel
 var CurrentPipeline = &Pipeline{
-	Name:  "Mon Pipeline",
-	Steps: []PipelineStep{},
+	Version: CurrentPipelineVersion,
+	Name:    "Mon Pipeline",
+	Steps:   []PipelineStep{},
 }
 
 // Callbacks pour notifier les changements du pipeline
@@ -153,19 +88,56 @@ func NotifyPipelineUpdated() {
 
 // Pipeline représente une séquence d'outils configurés
 type Pipeline struct {
-	Steps []PipelineStep `json:"steps"`
-	Name  string         `json:"name"`
+	Version int            `json:"version"`
+	Steps   []PipelineStep `json:"steps"`
+	Name    string         `json:"name"`
 }
 
 type pipelineStepJSON struct {
-	ID     string          `json:"id"`
-	Type   ToolType        `json:"type"`
-	Config json.RawMessage `json:"config"`
-	Name   string          `json:"name"`
+	ID       string          `json:"id"`
+	Type     ToolType        `json:"type"`
+	Config   json.RawMessage `json:"config"`
+	Name     string          `json:"name"`
+	Disabled bool            `json:"disabled"`
+}
+
+// LoadOptions options de chargement d'un pipeline
+type LoadOptions struct {
+	// Strict rejette le fichier entier si une étape a un type d'outil inconnu ou si le format
+	// est plus récent que CurrentPipelineVersion. En mode non strict (par défaut), les étapes
+	// inconnues sont conservées mais ne sont pas exécutées (voir PipelineStep.IsActive).
+	Strict bool
+}
+
+// UnknownToolConfig conserve telle quelle la configuration d'une étape dont le type d'outil
+// n'est pas enregistré, pour qu'elle soit réécrite sans perte à l'export
+type UnknownToolConfig struct {
+	Type ToolType
+	Raw  json.RawMessage
+}
+
+func (c UnknownToolConfig) GetType() ToolType {
+	return c.Type
+}
+
+func (c UnknownToolConfig) Validate() error {
+	return nil
+}
+
+func (c UnknownToolConfig) GetDisplayName() string {
+	return fmt.Sprintf("Outil inconnu (%s)", c.Type)
+}
+
+func (c UnknownToolConfig) MarshalJSON() ([]byte, error) {
+	if len(c.Raw) == 0 {
+		return []byte("null"), nil
+	}
+	return c.Raw, nil
 }
 
 // SaveToFile sauvegarde le pipeline dans un fichier JSON
 func (p *Pipeline) SaveToFile(path string) error {
+	p.Version = CurrentPipelineVersion
 	data, err := json.MarshalIndent(p, "", "  ")
 	if err != nil {
 		return fmt.Errorf("échec de la sérialisation : %w", err)
@@ -177,90 +149,138 @@ func (p *Pipeline) SaveToFile(path string) error {
 	return nil
 }
 
-// LoadFromFile charge un pipeline depuis un fichier JSON
+// LoadFromFile charge un pipeline depuis un fichier JSON en mode non strict
 func (p *Pipeline) LoadFromFile(path string) error {
+	return p.LoadFromFileWithOptions(path, LoadOptions{})
+}
+
+// LoadFromFileWithOptions charge un pipeline depuis un fichier JSON, en migrant les anciens formats
+func (p *Pipeline) LoadFromFileWithOptions(path string, opts LoadOptions) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return fmt.Errorf("échec de la lecture du fichier : %w", err)
 	}
 
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("échec du décodage JSON : %w", err)
+	}
+
+	version, err := migratePipelineDocument(doc)
+	if err != nil {
+		return fmt.Errorf("format de pipeline non supporté : %w", err)
+	}
+	if version > CurrentPipelineVersion && opts.Strict {
+		return fmt.Errorf("pipeline au format v%d, cette version supporte jusqu'au format v%d", version, CurrentPipelineVersion)
+	}
+
 	var temp struct {
 		Steps []pipelineStepJSON `json:"steps"`
 		Name  string             `json:"name"`
 	}
-
-	if err := json.Unmarshal(data, &temp); err != nil {
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("échec du décodage JSON : %w", err)
+	}
+	if err := json.Unmarshal(migrated, &temp); err != nil {
 		return fmt.Errorf("échec du décodage JSON : %w", err)
 	}
 
-	p.Name = temp.Name
-	p.Steps = make([]PipelineStep, len(temp.Steps))
+	// Les chemins relatifs des configurations (fichier de schéma...) désignent des fichiers
+	// voisins du pipeline, quel que soit le répertoire courant
+	baseDir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		baseDir = filepath.Dir(path)
+	}
 
+	steps := make([]PipelineStep, len(temp.Steps))
 	for i, step := range temp.Steps {
-		var config ToolConfig
-		var processor processors.Processor
-
-		switch step.Type {
-		case JSONFormatterTool:
-			config = &JSONFormatterConfig{}
-			processor = processors.NewJSONFormatterUI()
-		case TextSplitterTool:
-			config = &TextSplitterConfig{}
-			processor = processors.NewTextSplitterUI()
-		case TextJoinerTool:
-			config = &TextJoinerConfig{}
-			processor = processors.NewTextJoinerUI()
-		case CustomProcessorTool:
-			config = &CustomProcessorConfig{}
-			processor = processors.NewCustomProcessor("", "") // Sera configuré après
-		default:
-			return fmt.Errorf("type d'outil inconnu: %s", step.Type)
+		reg, ok := processors.Lookup(step.Type)
+		if !ok {
+			if opts.Strict {
+				return fmt.Errorf("type d'outil inconnu à l'étape %d: %s", i+1, step.Type)
+			}
+			steps[i] = PipelineStep{
+				ID:        step.ID,
+				Type:      step.Type,
+				Config:    UnknownToolConfig{Type: step.Type, Raw: step.Config},
+				Name:      step.Name,
+				Disabled:  step.Disabled,
+				Processor: processors.NewPlaceholderProcessor(step.Type),
+			}
+			continue
 		}
 
-		if err := json.Unmarshal(step.Config, config); err != nil {
+		config, err := reg.Codec.Decode(step.Config)
+		if err != nil {
 			return fmt.Errorf("erreur de configuration pour l'étape %d: %w", i+1, err)
 		}
-
-		// Convertir la configuration ToolConfig vers le format attendu par le ViewModel
-		var vmConfig interface{}
-		switch cfg := config.(type) {
-		case *JSONFormatterConfig:
-			vmConfig = struct{ IndentType string }{IndentType: cfg.IndentType}
-		case *TextSplitterConfig:
-			vmConfig = struct{ Delimiter string }{Delimiter: cfg.Delimiter}
-		case *TextJoinerConfig:
-			vmConfig = struct{ Delimiter string }{Delimiter: cfg.Delimiter}
-		case *CustomProcessorConfig:
-			vmConfig = struct{ Name, Script string }{Name: cfg.Name, Script: cfg.Script}
+		if withDir, ok := config.(processors.BaseDirConfig); ok {
+			config = withDir.WithBaseDir(baseDir)
 		}
 
-		if err := processor.ViewModel().LoadConfiguration(vmConfig); err != nil {
+		processor, err := processors.NewConfiguredProcessor(config)
+		if err != nil {
 			return fmt.Errorf("chargement configuration étape %d: %w", i+1, err)
 		}
 
-		p.Steps[i] = PipelineStep{
+		steps[i] = PipelineStep{
 			ID:        step.ID,
 			Type:      step.Type,
 			Config:    config,
-			Processor: processor,
 			Name:      step.Name,
+			Disabled:  step.Disabled,
+			Processor: processor,
 		}
 	}
 
+	p.Version = CurrentPipelineVersion
+	p.Name = temp.Name
+	p.Steps = steps
 	return nil
 }
 
+// UnknownSteps retourne les étapes dont le type d'outil n'est pas connu de cette version
+func (p *Pipeline) UnknownSteps() []PipelineStep {
+	var unknown []PipelineStep
+	for _, step := range p.Steps {
+		if _, ok := step.Config.(UnknownToolConfig); ok {
+			unknown = append(unknown, step)
+		}
+	}
+	return unknown
+}
+
+// ActiveStepCount retourne le nombre d'étapes exécutées (ni désactivées, ni inconnues)
+func (p *Pipeline) ActiveStepCount() int {
+	count := 0
+	for _, step := range p.Steps {
+		if step.IsActive() {
+			count++
+		}
+	}
+	return count
+}
+
 // Validate valide la configuration complète du pipeline
 func (p *Pipeline) Validate() error {
 	if len(p.Steps) == 0 {
 		return fmt.Errorf("le pipeline doit contenir au moins une étape")
 	}
 
+	enabled := 0
 	for i, step := range p.Steps {
+		if !step.IsActive() {
+			continue
+		}
+		enabled++
 		if err := step.Processor.ViewModel().Validate(); err != nil {
 			return fmt.Errorf("erreur à l'étape %d (%s): %w", i+1, step.Name, err)
 		}
 	}
+	if enabled == 0 {
+		return fmt.Errorf("le pipeline doit contenir au moins une étape active")
+	}
 
 	return nil
 }
@@ -269,17 +289,51 @@ func (p *Pipeline) Validate() error {
 func (p *Pipeline) GetDisplaySteps() []string {
 	var steps []string
 	for i, step := range p.Steps {
-		stepName := step.Name
-		if stepName == "" {
-			stepName = step.Processor.Name()
-		}
-		steps = append(steps, fmt.Sprintf("%d. %s", i+1, stepName))
+		steps = append(steps, fmt.Sprintf("%d. %s", i+1, step.DisplayName()))
 	}
 	return steps
 }
 
+// StepError décrit l'échec d'une étape lors de l'exécution du pipeline
+type StepError struct {
+	Index int    // Position de l'étape (à partir de 1)
+	Name  string // Nom affiché de l'étape
+	Err   error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("erreur à l'étape %d (%s): %v", e.Index, e.Name, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// StepStatus indique l'état d'une étape dans un événement de progression
+type StepStatus int
+
+const (
+	StepStarted StepStatus = iota
+	StepFinished
+	StepFailed
+)
+
+// StepProgress événement émis au début et à la fin de chaque étape
+type StepProgress struct {
+	Index      int // Rang de l'étape parmi les étapes exécutées (à partir de 1)
+	Total      int // Nombre d'étapes exécutées (étapes désactivées exclues)
+	Name       string
+	Status     StepStatus
+	Duration   time.Duration // Durée de l'étape (StepFinished, StepFailed)
+	OutputSize int           // Taille du résultat en octets (StepFinished)
+	Err        error         // Erreur de l'étape (StepFailed)
+}
+
 // PipelineExecutor exécute un pipeline sur un texte d'entrée
-type PipelineExecutor struct{}
+type PipelineExecutor struct {
+	// OnProgress reçoit les événements de progression ; appelé depuis la goroutine d'exécution
+	OnProgress func(StepProgress)
+}
 
 // NewPipelineExecutor crée un nouvel exécuteur de pipeline
 func NewPipelineExecutor() *PipelineExecutor {
@@ -288,26 +342,90 @@ func NewPipelineExecutor() *PipelineExecutor {
 
 // Execute exécute le pipeline sur le texte d'entrée
 func (pe *PipelineExecutor) Execute(pipeline *Pipeline, input string) (string, error) {
+	return pe.ExecuteContext(context.Background(), pipeline, input)
+}
+
+// ExecuteContext exécute le pipeline en respectant l'annulation du contexte,
+// entre les étapes et pendant leur traitement
+func (pe *PipelineExecutor) ExecuteContext(ctx context.Context, pipeline *Pipeline, input string) (string, error) {
+	trace, err := pe.ExecuteTrace(ctx, pipeline, input)
+	if err != nil {
+		return "", err
+	}
+	return trace.Output, nil
+}
+
+// StepTrace résultat intermédiaire d'une étape du pipeline
+type StepTrace struct {
+	Index    int // Position de l'étape (à partir de 1)
+	Name     string
+	Input    string
+	Output   string
+	Err      error
+	Duration time.Duration
+}
+
+// ExecutionTrace trace d'une exécution: entrée, sortie et résultat de chaque étape exécutée
+type ExecutionTrace struct {
+	Input  string
+	Output string
+	Steps  []StepTrace
+}
+
+// ExecuteTrace exécute le pipeline comme ExecuteContext et retourne le détail de chaque étape.
+// En cas d'échec d'une étape, la trace partielle (étape en échec incluse) est retournée avec l'erreur.
+func (pe *PipelineExecutor) ExecuteTrace(ctx context.Context, pipeline *Pipeline, input string) (*ExecutionTrace, error) {
 	if err := pipeline.Validate(); err != nil {
-		return "", fmt.Errorf("pipeline invalide: %w", err)
+		return nil, fmt.Errorf("pipeline invalide: %w", err)
 	}
 
+	// Copier les étapes: le pipeline peut être modifié par l'interface pendant l'exécution
+	steps := append([]PipelineStep(nil), pipeline.Steps...)
+	trace := &ExecutionTrace{Input: input}
 	result := input
+	total := (&Pipeline{Steps: steps}).ActiveStepCount()
+	rank := 0
+
+	for i, step := range steps {
+		if !step.IsActive() {
+			continue
+		}
+		rank++
+		stepName := step.DisplayName()
+		if err := ctx.Err(); err != nil {
+			return trace, fmt.Errorf("exécution annulée avant l'étape %d (%s): %w", i+1, stepName, err)
+		}
 
-	for i, step := range pipeline.Steps {
-		vm := step.Processor.ViewModel()
-		var err error
-		result, err = vm.Process(result)
+		pe.notify(StepProgress{Index: rank, Total: total, Name: stepName, Status: StepStarted})
+		start := time.Now()
+
+		output, err := processors.ProcessWithContext(ctx, step.Processor.ViewModel(), result)
+		duration := time.Since(start)
+		trace.Steps = append(trace.Steps, StepTrace{
+			Index:    i + 1,
+			Name:     stepName,
+			Input:    result,
+			Output:   output,
+			Err:      err,
+			Duration: duration,
+		})
 		if err != nil {
-			stepName := step.Name
-			if stepName == "" {
-				stepName = step.Processor.Name()
-			}
-			return "", fmt.Errorf("erreur à l'étape %d (%s): %w", i+1, stepName, err)
+			pe.notify(StepProgress{Index: rank, Total: total, Name: stepName, Status: StepFailed, Duration: duration, Err: err})
+			return trace, &StepError{Index: i + 1, Name: stepName, Err: err}
 		}
+
+		pe.notify(StepProgress{Index: rank, Total: total, Name: stepName, Status: StepFinished, Duration: duration, OutputSize: len(output)})
+		result = output
 	}
 
-	return result, nil
+	trace.Output = result
+	return trace, nil
+}
+
+func (pe *PipelineExecutor) notify(event StepProgress) {
+	if pe.OnProgress != nil {
+		pe.OnProgress(event)
+	}
 }
 
 // Fonctions de traitement pour chaque outil
@@ -320,6 +438,10 @@ func ProcessJSONFormatter(input string, config ToolConfig) (string, error) {
 	}
 
 	formatter := processors.NewFormatter(jsonConfig.IndentType)
+	formatter.SortKeys = jsonConfig.SortKeys
+	formatter.EscapeHTML = !jsonConfig.DisableHTMLEscape
+	formatter.Lenient = jsonConfig.Lenient
+	formatter.RejectComments = jsonConfig.RejectComments
 	return formatter.FormatJSON(input)
 }
 
@@ -330,13 +452,11 @@ func ProcessTextSplitter(input string, config ToolConfig) (string, error) {
 		return "", fmt.Errorf("configuration invalide pour Text Splitter")
 	}
 
-	delimiter := splitterConfig.Delimiter
-	if delimiter == "" {
-		delimiter = "\n"
+	vm := processors.NewTextSplitterViewModel()
+	if err := vm.LoadConfiguration(splitterConfig.Options()); err != nil {
+		return "", err
 	}
-
-	parts := strings.Split(input, delimiter)
-	return strings.Join(parts, "\n"), nil
+	return vm.Process(input)
 }
 
 // ProcessTextJoiner traite le texte avec le joigneur
@@ -346,16 +466,11 @@ func ProcessTextJoiner(input string, config ToolConfig) (string, error) {
 		return "", fmt.Errorf("configuration invalide pour Text Joiner")
 	}
 
-	lines := strings.Split(input, "\n")
-	// Filtrer les lignes vides
-	var nonEmptyLines []string
-	for _, line := range lines {
-		if strings.TrimSpace(line) != "" {
-			nonEmptyLines = append(nonEmptyLines, strings.TrimSpace(line))
-		}
+	vm := processors.NewTextJoinerViewModel()
+	if err := vm.LoadConfiguration(joinerConfig.Options()); err != nil {
+		return "", err
 	}
-
-	return strings.Join(nonEmptyLines, joinerConfig.Delimiter), nil
+	return vm.Process(input)
 }
 
 // GetDefaultExecutor retourne un exécuteur de pipeline
@@ -1,8 +1,11 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -49,9 +52,26 @@ func MakePipelineBuilderUI() fyne.CanvasObject {
 				stepContainer := container.NewHBox()
 
 				// Numéro et nom de l'étape
-				stepLabel := widget.NewLabel(fmt.Sprintf("%d. %s", i+1, step.Processor.Name()))
+				stepText := fmt.Sprintf("%d. %s", i+1, step.Processor.Name())
+				if !step.IsActive() {
+					stepText += " (désactivée)"
+				}
+				stepLabel := widget.NewLabel(stepText)
 				stepContainer.Add(stepLabel)
 
+				// Bouton activer/désactiver (les étapes d'un type inconnu restent désactivées)
+				if _, unknown := step.Config.(UnknownToolConfig); !unknown {
+					toggleText := "Désactiver"
+					if step.Disabled {
+						toggleText = "Activer"
+					}
+					toggleBtn := widget.NewButton(toggleText, func() {
+						currentPipeline.Steps[stepIndex].Disabled = !currentPipeline.Steps[stepIndex].Disabled
+						updateStepsDisplay()
+					})
+					stepContainer.Add(toggleBtn)
+				}
+
 				// Bouton monter
 				if i > 0 {
 					upBtn := widget.NewButton("↑", func() {
@@ -93,7 +113,10 @@ func MakePipelineBuilderUI() fyne.CanvasObject {
 
 	// Fonction pour obtenir la liste des outils disponibles
 	getToolOptions := func() []string {
-		options := []string{"JSON Formatter", "Text Splitter", "Text Joiner"}
+		var options []string
+		for _, reg := range processors.Registered() {
+			options = append(options, reg.Name)
+		}
 		// Ajouter les processeurs personnalisés
 		for _, customProc := range GlobalCustomProcessorManager.GetProcessors() {
 			options = append(options, "Custom: "+customProc.Name)
@@ -102,91 +125,55 @@ func MakePipelineBuilderUI() fyne.CanvasObject {
 	}
 
 	// Sélecteur d'outil à ajouter
-	toolSelect := widget.NewSelect(getToolOptions(), nil)
-	toolSelect.SetSelected("JSON Formatter")
+	toolOptions := getToolOptions()
+	toolSelect := widget.NewSelect(toolOptions, nil)
 
-	// Zone de configuration pour l'outil sélectionné
+	// Zone de description de l'outil sélectionné
 	configContainer := container.NewVBox()
 
-	// Variables pour les configurations
-	var jsonIndentSelect *widget.Select
-	var splitterDelimiterEntry *widget.Entry
-	var joinerDelimiterEntry *widget.Entry
-	var customNameEntry *widget.Entry
-	var customScriptEntry *widget.Entry
-
-	// Fonction pour mettre à jour la zone de configuration
+	// Fonction pour mettre à jour la zone de description
 	updateConfigDisplay := func(toolName string) {
 		configContainer.Objects = nil
 
-		switch toolName {
-		case "JSON Formatter":
-			configContainer.Add(widget.NewLabel("Configuration JSON Formatter:"))
-			indentOptions := []string{"2 espaces", "4 espaces", "Tabulations"}
-			jsonIndentSelect = widget.NewSelect(indentOptions, nil)
-			jsonIndentSelect.SetSelected("2 espaces")
-			configContainer.Add(container.NewHBox(
-				widget.NewLabel("Indentation:"),
-				jsonIndentSelect,
-			))
-
-		case "Text Splitter":
-			configContainer.Add(widget.NewLabel("Configuration Text Splitter:"))
-			splitterDelimiterEntry = widget.NewEntry()
-			splitterDelimiterEntry.SetPlaceHolder("Délimiteur (ex: ,)")
-			splitterDelimiterEntry.SetText(",")
-			configContainer.Add(container.NewHBox(
-				widget.NewLabel("Délimiteur:"),
-				splitterDelimiterEntry,
-			))
-
-		case "Text Joiner":
-			configContainer.Add(widget.NewLabel("Configuration Text Joiner:"))
-			joinerDelimiterEntry = widget.NewEntry()
-			joinerDelimiterEntry.SetPlaceHolder("Délimiteur (ex: ,)")
-			joinerDelimiterEntry.SetText(", ")
-			configContainer.Add(container.NewHBox(
-				widget.NewLabel("Délimiteur:"),
-				joinerDelimiterEntry,
-			))
-		default:
-			// Vérifier si c'est un processeur personnalisé
-			if strings.HasPrefix(toolName, "Custom: ") {
-				customName := strings.TrimPrefix(toolName, "Custom: ")
-				// Trouver le processeur personnalisé
-				for _, customProc := range GlobalCustomProcessorManager.GetProcessors() {
-					if customProc.Name == customName {
-						configContainer.Add(widget.NewLabel("Configuration Processeur Personnalisé:"))
-
-						customNameEntry = widget.NewEntry()
-						customNameEntry.SetText(customProc.Name)
-						customNameEntry.Disable() // Nom en lecture seule
-						configContainer.Add(container.NewHBox(
-							widget.NewLabel("Nom:"),
-							customNameEntry,
-						))
-
-						customScriptEntry = widget.NewEntry()
-						customScriptEntry.SetText(customProc.Script)
-						customScriptEntry.Disable() // Script en lecture seule
-						configContainer.Add(container.NewVBox(
-							widget.NewLabel("Script:"),
-							customScriptEntry,
-						))
-						break
-					}
+		if strings.HasPrefix(toolName, "Custom: ") {
+			customName := strings.TrimPrefix(toolName, "Custom: ")
+			// Trouver le processeur personnalisé
+			for _, customProc := range GlobalCustomProcessorManager.GetProcessors() {
+				if customProc.Name == customName {
+					configContainer.Add(widget.NewLabel("Configuration Processeur Personnalisé:"))
+
+					customNameEntry := widget.NewEntry()
+					customNameEntry.SetText(customProc.Name)
+					customNameEntry.Disable() // Nom en lecture seule
+					configContainer.Add(container.NewHBox(
+						widget.NewLabel("Nom:"),
+						customNameEntry,
+					))
+
+					customScriptEntry := widget.NewEntry()
+					customScriptEntry.SetText(customProc.Script)
+					customScriptEntry.Disable() // Script en lecture seule
+					configContainer.Add(container.NewVBox(
+						widget.NewLabel("Script:"),
+						customScriptEntry,
+					))
+					break
 				}
 			}
+		} else if reg, ok := processors.LookupByName(toolName); ok {
+			description := widget.NewLabel(reg.Description)
+			description.Wrapping = fyne.TextWrapWord
+			configContainer.Add(description)
 		}
 
 		configContainer.Refresh()
 	}
 
-	// Initialiser l'affichage de configuration
-	updateConfigDisplay("JSON Formatter")
-
-	// Mettre à jour la configuration quand l'outil change
+	// Mettre à jour la description quand l'outil change
 	toolSelect.OnChanged = updateConfigDisplay
+	if len(toolOptions) > 0 {
+		toolSelect.SetSelected(toolOptions[0])
+	}
 
 	// Zone d'affichage des erreurs
 	errorLabel := widget.NewLabel("")
@@ -204,51 +191,8 @@ func MakePipelineBuilderUI() fyne.CanvasObject {
 
 	// Bouton pour ajouter l'étape au pipeline
 	addStepBtn := widget.NewButton("Ajouter l'étape", func() {
-		var config ToolConfig
-		var err error
-
-		switch toolSelect.Selected {
-		case "JSON Formatter":
-			if jsonIndentSelect.Selected == "" {
-				showError(fmt.Errorf("veuillez sélectionner un type d'indentation"))
-				return
-			}
-			config = JSONFormatterConfig{
-				IndentType: jsonIndentSelect.Selected,
-			}
-		case "Text Splitter":
-			config = TextSplitterConfig{
-				Delimiter: splitterDelimiterEntry.Text,
-			}
-		case "Text Joiner":
-			config = TextJoinerConfig{
-				Delimiter: joinerDelimiterEntry.Text,
-			}
-		default:
-			// Vérifier si c'est un processeur personnalisé
-			if strings.HasPrefix(toolSelect.Selected, "Custom: ") {
-				customName := strings.TrimPrefix(toolSelect.Selected, "Custom: ")
-				// Trouver le processeur personnalisé
-				for _, customProc := range GlobalCustomProcessorManager.GetProcessors() {
-					if customProc.Name == customName {
-						config = CustomProcessorConfig{
-							Name:   customProc.Name,
-							Script: customProc.Script,
-						}
-						break
-					}
-				}
-				if config == nil {
-					showError(fmt.Errorf("processeur personnalisé non trouvé: %s", customName))
-					return
-				}
-			} else {
-				showError(fmt.Errorf("veuillez sélectionner un outil"))
-				return
-			}
-		}
-
-		if err = config.Validate(); err != nil {
+		processor, reg, err := newProcessorForTool(toolSelect.Selected)
+		if err != nil {
 			showError(err)
 			return
 		}
@@ -256,29 +200,6 @@ func MakePipelineBuilderUI() fyne.CanvasObject {
 		// Effacer les erreurs précédentes
 		showError(nil)
 
-		// Create processor instance
-		var processor processors.Processor
-		switch toolSelect.Selected {
-		case "JSON Formatter":
-			processor = processors.NewJSONFormatterUI()
-		case "Text Splitter":
-			processor = processors.NewTextSplitterUI()
-		case "Text Joiner":
-			processor = processors.NewTextJoinerUI()
-		default:
-			// Vérifier si c'est un processeur personnalisé
-			if strings.HasPrefix(toolSelect.Selected, "Custom: ") {
-				customName := strings.TrimPrefix(toolSelect.Selected, "Custom: ")
-				// Trouver le processeur personnalisé
-				for _, customProc := range GlobalCustomProcessorManager.GetProcessors() {
-					if customProc.Name == customName {
-						processor = processors.NewCustomProcessor(customProc.Name, customProc.Script)
-						break
-					}
-				}
-			}
-		}
-
 		// Declare dialog first
 		var configDialog *dialog.CustomDialog
 
@@ -289,63 +210,24 @@ func MakePipelineBuilderUI() fyne.CanvasObject {
 				container.NewHBox(
 					widget.NewButton("Annuler", func() { configDialog.Hide() }),
 					widget.NewButton("Valider", func() {
-						// D'abord, configurer le processeur avec les valeurs de l'interface
-						var config ToolConfig
-						var toolType ToolType
-						var err error
-
-						switch toolSelect.Selected {
-						case "JSON Formatter":
-							toolType = JSONFormatterTool
-							if jsonIndentSelect.Selected == "" {
-								showError(fmt.Errorf("veuillez sélectionner un type d'indentation"))
-								return
-							}
-							config = JSONFormatterConfig{IndentType: jsonIndentSelect.Selected}
-							err = processor.ViewModel().LoadConfiguration(struct{ IndentType string }{IndentType: jsonIndentSelect.Selected})
-						case "Text Splitter":
-							toolType = TextSplitterTool
-							delimiter := splitterDelimiterEntry.Text
-							if delimiter == "" {
-								delimiter = "\n" // Valeur par défaut
-							}
-							config = TextSplitterConfig{Delimiter: delimiter}
-							err = processor.ViewModel().LoadConfiguration(struct{ Delimiter string }{Delimiter: delimiter})
-						case "Text Joiner":
-							toolType = TextJoinerTool
-							delimiter := joinerDelimiterEntry.Text
-							if delimiter == "" {
-								delimiter = " " // Valeur par défaut
-							}
-							config = TextJoinerConfig{Delimiter: delimiter}
-							err = processor.ViewModel().LoadConfiguration(struct{ Delimiter string }{Delimiter: delimiter})
-						default:
-							// Vérifier si c'est un processeur personnalisé
-							if strings.HasPrefix(toolSelect.Selected, "Custom: ") {
-								toolType = CustomProcessorTool
-								customName := strings.TrimPrefix(toolSelect.Selected, "Custom: ")
-								// Trouver le processeur personnalisé
-								for _, customProc := range GlobalCustomProcessorManager.GetProcessors() {
-									if customProc.Name == customName {
-										config = CustomProcessorConfig{Name: customProc.Name, Script: customProc.Script}
-										err = processor.ViewModel().LoadConfiguration(struct{ Name, Script string }{Name: customProc.Name, Script: customProc.Script})
-										break
-									}
-								}
-							}
-						}
-
+						// Construire la configuration de l'étape à partir de l'état du processeur
+						config, err := reg.Codec.FromViewModel(processor.ViewModel().GetConfiguration())
 						if err != nil {
 							showError(fmt.Errorf("erreur de configuration: %w", err))
 							return
 						}
 
+						if err := config.Validate(); err != nil {
+							showError(err)
+							return
+						}
+
 						if processor.ViewModel().Validate() == nil {
 							configDialog.Hide()
 
 							step := PipelineStep{
 								ID:        fmt.Sprintf("step_%d", len(currentPipeline.Steps)+1),
-								Type:      toolType,
+								Type:      reg.Type,
 								Config:    config,
 								Name:      "",
 								Processor: processor,
@@ -371,14 +253,29 @@ func MakePipelineBuilderUI() fyne.CanvasObject {
 			fyne.CurrentApp().Driver().AllWindows()[0],
 		)
 		configDialog.Show()
+	})
 
-		// Remove old configuration UI elements
-		configContainer.Objects = nil
-		configContainer.Refresh()
+	// Inspection des résultats intermédiaires de chaque étape
+	inspector, setTrace := newTraceInspector()
+
+	// Progression de l'exécution
+	progressBar := widget.NewProgressBar()
+	progressBar.Hide()
+	progressLabel := widget.NewLabel("")
+	progressLabel.Wrapping = fyne.TextWrapWord
+
+	// Annulation de l'exécution en cours
+	var cancelExecution context.CancelFunc
+	cancelBtn := widget.NewButton("Annuler", func() {
+		if cancelExecution != nil {
+			cancelExecution()
+		}
 	})
+	cancelBtn.Disable()
 
 	// Bouton pour exécuter le pipeline
-	executeBtn := widget.NewButton("Exécuter le Pipeline", func() {
+	var executeBtn *widget.Button
+	executeBtn = widget.NewButton("Exécuter le Pipeline", func() {
 		// Effacer les erreurs précédentes
 		showError(nil)
 
@@ -396,17 +293,65 @@ func MakePipelineBuilderUI() fyne.CanvasObject {
 			return
 		}
 
-		executor := GetDefaultExecutor()
-		result, err := executor.Execute(currentPipeline, inputText.Text)
+		// Travailler sur une copie: les étapes peuvent être modifiées pendant l'exécution
+		snapshot := &Pipeline{
+			Name:  currentPipeline.Name,
+			Steps: append([]PipelineStep(nil), currentPipeline.Steps...),
+		}
+		input := inputText.Text
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelExecution = cancel
+		executeBtn.Disable()
+		cancelBtn.Enable()
+		progressBar.Max = float64(snapshot.ActiveStepCount())
+		progressBar.SetValue(0)
+		progressBar.Show()
+		progressLabel.SetText("Démarrage...")
+		setTrace(nil)
 
-		if err != nil {
-			showError(err)
-			resultText = ""
-			outputText.SetText("")
-		} else {
-			resultText = result
-			outputText.SetText(resultText)
+		executor := GetDefaultExecutor()
+		executor.OnProgress = func(event StepProgress) {
+			fyne.Do(func() {
+				switch event.Status {
+				case StepStarted:
+					progressLabel.SetText(fmt.Sprintf("Étape %d/%d: %s...", event.Index, event.Total, event.Name))
+				case StepFinished:
+					progressBar.SetValue(float64(event.Index))
+					progressLabel.SetText(fmt.Sprintf("Étape %d/%d: %s terminée en %s (%d octets)",
+						event.Index, event.Total, event.Name, event.Duration.Round(time.Millisecond), event.OutputSize))
+				}
+			})
 		}
+
+		start := time.Now()
+		go func() {
+			trace, err := executor.ExecuteTrace(ctx, snapshot, input)
+			elapsed := time.Since(start)
+
+			fyne.Do(func() {
+				cancel()
+				cancelExecution = nil
+				executeBtn.Enable()
+				cancelBtn.Disable()
+				setTrace(trace)
+
+				if err != nil {
+					if errors.Is(err, context.Canceled) {
+						progressLabel.SetText("Exécution annulée")
+					} else {
+						progressLabel.SetText("")
+					}
+					showError(err)
+					resultText = ""
+					outputText.SetText("")
+				} else {
+					progressLabel.SetText(fmt.Sprintf("Pipeline exécuté en %s", elapsed.Round(time.Millisecond)))
+					resultText = trace.Output
+					outputText.SetText(resultText)
+				}
+			})
+		}()
 	})
 
 	// Bouton pour copier le résultat
@@ -442,15 +387,20 @@ func MakePipelineBuilderUI() fyne.CanvasObject {
 	)
 
 	// Section d'exécution
-	executionSection := container.NewVBox(
-		widget.NewLabel("Texte d'entrée:"),
-		inputText,
-		container.NewHBox(executeBtn),
-		container.NewHBox(
-			widget.NewLabel("Résultat:"),
-			copyBtn,
+	executionSection := container.NewVSplit(
+		container.NewVBox(
+			widget.NewLabel("Texte d'entrée:"),
+			inputText,
+			container.NewHBox(executeBtn, cancelBtn),
+			progressBar,
+			progressLabel,
+			container.NewHBox(
+				widget.NewLabel("Résultat:"),
+				copyBtn,
+			),
+			outputText,
 		),
-		outputText,
+		inspector,
 	)
 
 	// Initialiser l'affichage des étapes
@@ -488,3 +438,23 @@ func MakePipelineBuilderUI() fyne.CanvasObject {
 		executionSection,
 	)
 }
+
+// newProcessorForTool instancie le processeur correspondant à une option du sélecteur d'outils
+func newProcessorForTool(toolName string) (processors.Processor, processors.Registration, error) {
+	if strings.HasPrefix(toolName, "Custom: ") {
+		customName := strings.TrimPrefix(toolName, "Custom: ")
+		reg, _ := processors.Lookup(CustomProcessorTool)
+		for _, customProc := range GlobalCustomProcessorManager.GetProcessors() {
+			if customProc.Name == customName {
+				return processors.NewCustomProcessorWithOptions(customProc.Config().Options()), reg, nil
+			}
+		}
+		return nil, reg, fmt.Errorf("processeur personnalisé non trouvé: %s", customName)
+	}
+
+	reg, ok := processors.LookupByName(toolName)
+	if !ok {
+		return nil, reg, fmt.Errorf("veuillez sélectionner un outil")
+	}
+	return reg.New(), reg, nil
+}
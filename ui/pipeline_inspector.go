@@ -0,0 +1,261 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// newTraceInspector crée la zone d'inspection des résultats intermédiaires du pipeline.
+// La fonction retournée affiche une nouvelle trace (nil pour vider la zone).
+func newTraceInspector() (fyne.CanvasObject, func(*ExecutionTrace)) {
+	var trace *ExecutionTrace
+	selected := -1
+	generation := 0 // Incrémenté à chaque affichage: un diff calculé pour un affichage précédent est ignoré
+
+	stepsList := container.NewVBox()
+	detail := widget.NewRichText()
+	detail.Wrapping = fyne.TextWrapWord
+	detailTitle := widget.NewLabel("")
+
+	diffCheck := widget.NewCheck("Comparer avec l'étape précédente", nil)
+
+	var showStep func(index int)
+	showStep = func(index int) {
+		selected = index
+		generation++
+		if trace == nil || index < 0 || index >= len(trace.Steps) {
+			detailTitle.SetText("")
+			detail.Segments = nil
+			detail.Refresh()
+			return
+		}
+
+		step := trace.Steps[index]
+		if step.Err != nil {
+			detailTitle.SetText(fmt.Sprintf("Étape %d (%s) en échec: %v", step.Index, step.Name, step.Err))
+			detail.Segments = plainSegments(step.Input)
+		} else if diffCheck.Checked {
+			// Référence: résultat de l'étape exécutée précédente (les étapes désactivées ne sont
+			// pas tracées), ou entrée du pipeline pour la première étape
+			previous, previousName := trace.Input, "l'entrée du pipeline"
+			if index > 0 {
+				prev := trace.Steps[index-1]
+				previous, previousName = prev.Output, fmt.Sprintf("le résultat de l'étape %d (%s)", prev.Index, prev.Name)
+			}
+			detailTitle.SetText(fmt.Sprintf("Étape %d (%s): différences avec %s", step.Index, step.Name, previousName))
+			detail.Segments = plainSegments("Calcul des différences...")
+
+			// Le diff est calculé hors du thread de l'interface
+			current := generation
+			go func() {
+				segments := plainSegments(fmt.Sprintf("Plus de %d lignes ajoutées ou supprimées: différences non affichées", maxDiffEdits))
+				if lines, ok := diffLines(splitLines(previous), splitLines(step.Output)); ok {
+					segments = diffSegments(lines)
+				}
+				fyne.Do(func() {
+					if current != generation {
+						return
+					}
+					detail.Segments = segments
+					detail.Refresh()
+				})
+			}()
+		} else {
+			detailTitle.SetText(fmt.Sprintf("Étape %d (%s): résultat", step.Index, step.Name))
+			detail.Segments = plainSegments(step.Output)
+		}
+		detail.Refresh()
+	}
+
+	diffCheck.OnChanged = func(bool) { showStep(selected) }
+
+	setTrace := func(t *ExecutionTrace) {
+		trace = t
+		stepsList.Objects = nil
+		if t != nil {
+			for i, step := range t.Steps {
+				stepIndex := i // Capture pour la closure
+				status := fmt.Sprintf("%s, %d octets", step.Duration.Round(time.Microsecond), len(step.Output))
+				if step.Err != nil {
+					status = "échec"
+				}
+				stepsList.Add(widget.NewButton(fmt.Sprintf("%d. %s (%s)", step.Index, step.Name, status), func() {
+					showStep(stepIndex)
+				}))
+			}
+		}
+		stepsList.Refresh()
+
+		// Afficher la dernière étape exécutée par défaut
+		if t != nil && len(t.Steps) > 0 {
+			showStep(len(t.Steps) - 1)
+		} else {
+			showStep(-1)
+		}
+	}
+
+	content := container.NewBorder(
+		container.NewVBox(
+			container.NewHBox(widget.NewLabel("Résultats intermédiaires:"), diffCheck),
+			stepsList,
+			detailTitle,
+		),
+		nil, nil, nil,
+		container.NewScroll(detail),
+	)
+	return content, setTrace
+}
+
+// diffOp type d'une ligne de différence
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffDelete
+	diffInsert
+)
+
+// diffLine ligne d'un diff entre deux textes
+type diffLine struct {
+	Op   diffOp
+	Text string
+}
+
+// noFinalNewline ligne ajoutée par splitLines à un texte ne se terminant pas par un retour à la
+// ligne, pour que le diff signale un retour à la ligne final ajouté ou supprimé
+const noFinalNewline = `\ Pas de retour à la ligne à la fin du texte`
+
+// splitLines découpe un texte en lignes (sans ligne vide finale). Sans retour à la ligne final,
+// la dernière ligne est suivie de noFinalNewline.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	if !strings.HasSuffix(text, "\n") {
+		return append(strings.Split(text, "\n"), noFinalNewline)
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// maxDiffEdits limite le nombre de lignes ajoutées ou supprimées recherchées par diffLines, dont
+// le temps de calcul est en O((N+M)·D) et la mémoire en O(D²) pour D lignes modifiées
+const maxDiffEdits = 2000
+
+// diffLines calcule un diff ligne à ligne minimal (algorithme de Myers). Retourne false si plus
+// de maxDiffEdits lignes sont ajoutées ou supprimées.
+func diffLines(a, b []string) ([]diffLine, bool) {
+	n, m := len(a), len(b)
+	maxD := min(n+m, maxDiffEdits)
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	// trace[d] conserve les diagonales -d-1 à d+1 de v au début de l'étape d, les seules lues
+	// pour remonter le chemin
+	var trace [][]int
+
+	found := false
+	for d := 0; d <= maxD && !found; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	// Remonter les chemins pour reconstruire les opérations (dans l'ordre inverse)
+	var ops []diffLine
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		v, offset := trace[d], d+1
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			ops = append(ops, diffLine{Op: diffEqual, Text: a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			ops = append(ops, diffLine{Op: diffInsert, Text: b[y-1]})
+		} else {
+			ops = append(ops, diffLine{Op: diffDelete, Text: a[x-1]})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		ops = append(ops, diffLine{Op: diffEqual, Text: a[x-1]})
+		x--
+		y--
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops, true
+}
+
+// plainSegments affiche un texte brut en police à chasse fixe
+func plainSegments(text string) []widget.RichTextSegment {
+	return []widget.RichTextSegment{
+		&widget.TextSegment{Text: text, Style: widget.RichTextStyleCodeBlock},
+	}
+}
+
+// diffSegments affiche un diff avec les lignes supprimées et ajoutées en couleur
+func diffSegments(lines []diffLine) []widget.RichTextSegment {
+	changed := false
+	for _, line := range lines {
+		if line.Op != diffEqual {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return plainSegments("(aucune différence)")
+	}
+
+	var segments []widget.RichTextSegment
+	for _, line := range lines {
+		style := widget.RichTextStyle{TextStyle: fyne.TextStyle{Monospace: true}}
+		prefix := "  "
+		switch line.Op {
+		case diffDelete:
+			prefix = "- "
+			style.ColorName = theme.ColorNameError
+		case diffInsert:
+			prefix = "+ "
+			style.ColorName = theme.ColorNameSuccess
+		}
+		segments = append(segments, &widget.TextSegment{Text: prefix + line.Text, Style: style})
+	}
+	return segments
+}
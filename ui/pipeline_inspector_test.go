@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"text_processors/ui/processors"
+)
+
+func TestExecuteTrace(t *testing.T) {
+	disabled := newTestStep(t, "b", TextSplitterConfig{Mode: processors.TextSplitLines})
+	disabled.Disabled = true
+	failing := newTestStep(t, "d", CustomProcessorConfig{Name: "échec", Script: "throw new Error('non')"})
+
+	tests := []struct {
+		name       string
+		steps      []PipelineStep
+		input      string
+		wantErr    bool
+		wantIndex  []int    // Position de chaque étape tracée
+		wantInputs []string // Entrée de chaque étape tracée
+		wantOutput string   // Sortie finale (en l'absence d'erreur)
+	}{
+		{
+			name: "étapes enchaînées, étape désactivée ignorée",
+			steps: []PipelineStep{
+				newTestStep(t, "a", JSONFormatterConfig{IndentType: "Minifié"}),
+				disabled,
+				newTestStep(t, "c", CustomProcessorConfig{Name: "maj", Script: "return input.toUpperCase();"}),
+			},
+			input:      `{ "a" : true }`,
+			wantIndex:  []int{1, 3},
+			wantInputs: []string{`{ "a" : true }`, `{"a":true}` + "\n"},
+			wantOutput: `{"A":TRUE}` + "\n",
+		},
+		{
+			name: "trace partielle jusqu'à l'étape en échec",
+			steps: []PipelineStep{
+				newTestStep(t, "a", JSONFormatterConfig{IndentType: "Minifié"}),
+				failing,
+				newTestStep(t, "c", CustomProcessorConfig{Name: "maj", Script: "return input.toUpperCase();"}),
+			},
+			input:      `[1]`,
+			wantErr:    true,
+			wantIndex:  []int{1, 2},
+			wantInputs: []string{`[1]`, "[1]\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trace, err := NewPipelineExecutor().ExecuteTrace(context.Background(), &Pipeline{Steps: tt.steps}, tt.input)
+			if tt.wantErr {
+				var stepErr *StepError
+				if !errors.As(err, &stepErr) {
+					t.Fatalf("erreur %v, attendu une StepError", err)
+				}
+				if last := trace.Steps[len(trace.Steps)-1]; last.Err == nil {
+					t.Error("étape en échec tracée sans erreur")
+				}
+			} else if err != nil {
+				t.Fatalf("erreur inattendue: %v", err)
+			}
+
+			if trace.Input != tt.input {
+				t.Errorf("entrée tracée %q, attendu %q", trace.Input, tt.input)
+			}
+			var indexes []int
+			var inputs []string
+			for _, step := range trace.Steps {
+				indexes = append(indexes, step.Index)
+				inputs = append(inputs, step.Input)
+			}
+			if !reflect.DeepEqual(indexes, tt.wantIndex) {
+				t.Errorf("étapes tracées %v, attendu %v", indexes, tt.wantIndex)
+			}
+			if !reflect.DeepEqual(inputs, tt.wantInputs) {
+				t.Errorf("entrées des étapes %q, attendu %q", inputs, tt.wantInputs)
+			}
+			if !tt.wantErr && trace.Output != tt.wantOutput {
+				t.Errorf("sortie %q, attendu %q", trace.Output, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []diffLine
+	}{
+		{"textes identiques", "a\nb\n", "a\nb\n", []diffLine{{diffEqual, "a"}, {diffEqual, "b"}}},
+		{"ligne modifiée", "a\nb\nc\n", "a\nB\nc\n", []diffLine{
+			{diffEqual, "a"}, {diffDelete, "b"}, {diffInsert, "B"}, {diffEqual, "c"},
+		}},
+		{"ligne ajoutée", "a\n", "a\nb\n", []diffLine{{diffEqual, "a"}, {diffInsert, "b"}}},
+		{"texte vidé", "a\n", "", []diffLine{{diffDelete, "a"}}},
+		{"retour à la ligne final ajouté", "a", "a\n", []diffLine{
+			{diffEqual, "a"}, {diffDelete, noFinalNewline},
+		}},
+		{"retour à la ligne final supprimé", "a\n", "a", []diffLine{
+			{diffEqual, "a"}, {diffInsert, noFinalNewline},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := diffLines(splitLines(tt.a), splitLines(tt.b))
+			if !ok {
+				t.Fatal("diff abandonné")
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diff %v, attendu %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffLinesLimit(t *testing.T) {
+	a := make([]string, maxDiffEdits+1)
+	b := make([]string, maxDiffEdits+1)
+	for i := range a {
+		a[i] = "a"
+		b[i] = "b"
+	}
+	if _, ok := diffLines(a, b); ok {
+		t.Errorf("diff de plus de %d modifications: abandon attendu", maxDiffEdits)
+	}
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CurrentPipelineVersion version du format de fichier écrite par SaveToFile.
+//
+// Historique :
+//   - 1 : format initial, sans champ "version"
+//   - 2 : ajout des champs "version" et "disabled" (étapes désactivées)
+const CurrentPipelineVersion = 2
+
+// pipelineMigration fait passer un document pipeline de la version From à From+1
+type pipelineMigration struct {
+	From    int
+	Migrate func(doc map[string]json.RawMessage) error
+}
+
+// pipelineMigrations chaîne de migrations, appliquées dans l'ordre au chargement
+var pipelineMigrations = []pipelineMigration{
+	{From: 1, Migrate: migratePipelineV1ToV2},
+}
+
+// pipelineDocumentVersion retourne la version d'un document (1 si le champ est absent)
+func pipelineDocumentVersion(doc map[string]json.RawMessage) (int, error) {
+	raw, ok := doc["version"]
+	if !ok || string(raw) == "null" {
+		return 1, nil
+	}
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil || version < 1 {
+		return 0, fmt.Errorf("champ version invalide: %s", raw)
+	}
+	return version, nil
+}
+
+// migratePipelineDocument met à niveau un document vers CurrentPipelineVersion.
+// Retourne la version d'origine du document.
+func migratePipelineDocument(doc map[string]json.RawMessage) (int, error) {
+	version, err := pipelineDocumentVersion(doc)
+	if err != nil {
+		return 0, err
+	}
+	original := version
+
+	for _, migration := range pipelineMigrations {
+		if migration.From != version {
+			continue
+		}
+		if err := migration.Migrate(doc); err != nil {
+			return original, fmt.Errorf("migration du format v%d vers v%d: %w", version, version+1, err)
+		}
+		version++
+		doc["version"] = json.RawMessage(fmt.Sprint(version))
+	}
+
+	if version < CurrentPipelineVersion {
+		return original, fmt.Errorf("aucune migration disponible depuis le format v%d", version)
+	}
+	return original, nil
+}
+
+// migratePipelineV1ToV2 complète les identifiants d'étapes manquants ou en double
+// (les anciennes versions les numérotaient d'après la taille du pipeline, ce qui créait des doublons)
+func migratePipelineV1ToV2(doc map[string]json.RawMessage) error {
+	raw, ok := doc["steps"]
+	if !ok || string(raw) == "null" {
+		return nil
+	}
+
+	var steps []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &steps); err != nil {
+		return fmt.Errorf("étapes invalides: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, step := range steps {
+		var id string
+		if rawID, ok := step["id"]; ok {
+			_ = json.Unmarshal(rawID, &id)
+		}
+		if id == "" || seen[id] {
+			id = fmt.Sprintf("step_%d", i+1)
+			for n := i + 1; seen[id]; n++ {
+				id = fmt.Sprintf("step_%d_%d", i+1, n)
+			}
+			encoded, _ := json.Marshal(id)
+			step["id"] = encoded
+		}
+		seen[id] = true
+	}
+
+	encoded, err := json.Marshal(steps)
+	if err != nil {
+		return err
+	}
+	doc["steps"] = encoded
+	return nil
+}
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMigratePipelineDocument(t *testing.T) {
+	tests := []struct {
+		name        string
+		doc         string
+		wantVersion int      // Version d'origine retournée
+		wantIDs     []string // Identifiants des étapes après migration
+		wantErr     bool
+	}{
+		{"format v1 sans version", `{"steps": [{"id": "a"}, {"id": "b"}]}`, 1, []string{"a", "b"}, false},
+		{"version nulle", `{"version": null, "steps": []}`, 1, []string{}, false},
+		{"identifiants manquants", `{"steps": [{}, {"id": ""}]}`, 1, []string{"step_1", "step_2"}, false},
+		{"identifiants en double", `{"steps": [{"id": ""}, {"id": "step_1"}, {"id": "step_1"}]}`, 1, []string{"step_1", "step_2", "step_3"}, false},
+		{"format courant inchangé", `{"version": 2, "steps": [{"id": "x"}, {"id": "x"}]}`, 2, []string{"x", "x"}, false},
+		{"format plus récent conservé", `{"version": 3, "steps": [{"id": "x"}]}`, 3, []string{"x"}, false},
+		{"version négative", `{"version": -1, "steps": []}`, 0, nil, true},
+		{"version non numérique", `{"version": "2", "steps": []}`, 0, nil, true},
+		{"étapes invalides", `{"steps": {"id": "a"}}`, 1, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc map[string]json.RawMessage
+			if err := json.Unmarshal([]byte(tt.doc), &doc); err != nil {
+				t.Fatal(err)
+			}
+			version, err := migratePipelineDocument(doc)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("erreur attendue")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erreur inattendue: %v", err)
+			}
+			if version != tt.wantVersion {
+				t.Errorf("version d'origine %d, attendu %d", version, tt.wantVersion)
+			}
+			if got, _ := pipelineDocumentVersion(doc); got < CurrentPipelineVersion {
+				t.Errorf("document migré en v%d, attendu au moins v%d", got, CurrentPipelineVersion)
+			}
+
+			var steps []struct{ ID string }
+			if err := json.Unmarshal(doc["steps"], &steps); err != nil {
+				t.Fatal(err)
+			}
+			ids := []string{}
+			for _, step := range steps {
+				ids = append(ids, step.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("identifiants %q, attendu %q", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestLoadPipelineUnknownTool(t *testing.T) {
+	const document = `{"version": 2, "name": "futur", "steps": [
+  {"id": "s1", "type": "outil_futur", "config": {"x": [1, 2]}, "name": "nouveau"},
+  {"id": "s2", "type": "json_formatter", "config": {"IndentType": "Minifié"}, "name": ""}
+]}`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pipeline.json")
+	if err := os.WriteFile(path, []byte(document), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	strict := &Pipeline{}
+	if err := strict.LoadFromFileWithOptions(path, LoadOptions{Strict: true}); err == nil || !strings.Contains(err.Error(), "outil_futur") {
+		t.Errorf("chargement strict: erreur %v, attendu un refus de l'outil inconnu", err)
+	}
+
+	pipeline := &Pipeline{}
+	if err := pipeline.LoadFromFile(path); err != nil {
+		t.Fatalf("chargement non strict: %v", err)
+	}
+	unknown := pipeline.UnknownSteps()
+	if len(unknown) != 1 || unknown[0].ID != "s1" || unknown[0].IsActive() {
+		t.Fatalf("étapes inconnues %+v, attendu l'étape s1 inactive", unknown)
+	}
+	if got := pipeline.ActiveStepCount(); got != 1 {
+		t.Errorf("%d étape(s) active(s), attendu 1", got)
+	}
+	if output, err := NewPipelineExecutor().Execute(pipeline, `{ "a" : 1 }`); err != nil || output != `{"a":1}`+"\n" {
+		t.Errorf("exécution: %q, %v", output, err)
+	}
+
+	// La configuration de l'étape inconnue est réécrite sans perte
+	saved := filepath.Join(dir, "saved.json")
+	if err := pipeline.SaveToFile(saved); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := &Pipeline{}
+	if err := reloaded.LoadFromFile(saved); err != nil {
+		t.Fatalf("rechargement: %v", err)
+	}
+	config, ok := reloaded.Steps[0].Config.(UnknownToolConfig)
+	if !ok {
+		t.Fatalf("configuration %T, attendu UnknownToolConfig", reloaded.Steps[0].Config)
+	}
+	var got, want interface{}
+	if err := json.Unmarshal(config.Raw, &got); err != nil {
+		t.Fatal(err)
+	}
+	_ = json.Unmarshal([]byte(`{"x": [1, 2]}`), &want)
+	if !reflect.DeepEqual(got, want) || reloaded.Steps[0].Name != "nouveau" {
+		t.Errorf("étape rechargée %+v (configuration %s)", reloaded.Steps[0], config.Raw)
+	}
+}
+
+func TestLoadPipelineNewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pipeline.json")
+	document := `{"version": 99, "name": "futur", "steps": [{"id": "s1", "type": "json_formatter", "config": {}, "name": ""}]}`
+	if err := os.WriteFile(path, []byte(document), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (&Pipeline{}).LoadFromFileWithOptions(path, LoadOptions{Strict: true}); err == nil {
+		t.Error("chargement strict d'un format plus récent: erreur attendue")
+	}
+	pipeline := &Pipeline{}
+	if err := pipeline.LoadFromFile(path); err != nil {
+		t.Fatalf("chargement non strict: %v", err)
+	}
+	if pipeline.Version != CurrentPipelineVersion || len(pipeline.Steps) != 1 {
+		t.Errorf("pipeline chargé: version %d, %d étape(s)", pipeline.Version, len(pipeline.Steps))
+	}
+}
+
+func TestLoadPipelineRelativeSchemaFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"schema.json": `{"type": "object", "required": ["id"]}`,
+		"pipeline.json": `{"version": 2, "name": "schéma", "steps": [
+  {"id": "s1", "type": "json_schema", "config": {"SchemaFile": "schema.json"}, "name": ""}
+]}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Le répertoire courant (celui du paquet) ne contient pas le schéma
+	pipeline := &Pipeline{}
+	if err := pipeline.LoadFromFile(filepath.Join(dir, "pipeline.json")); err != nil {
+		t.Fatalf("chargement: %v", err)
+	}
+	executor := NewPipelineExecutor()
+	if _, err := executor.Execute(pipeline, `{"id": 1}`); err != nil {
+		t.Errorf("document conforme: %v", err)
+	}
+	if _, err := executor.Execute(pipeline, `{}`); err == nil {
+		t.Error("document non conforme: erreur attendue")
+	}
+
+	// Le chemin relatif est réécrit tel quel à l'export
+	saved := filepath.Join(dir, "saved.json")
+	if err := pipeline.SaveToFile(saved); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), dir) || !strings.Contains(string(data), `"SchemaFile": "schema.json"`) {
+		t.Errorf("pipeline exporté:\n%s", data)
+	}
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"text_processors/ui/processors"
+)
+
+// newTestStep crée une étape de pipeline configurée comme à l'import d'un fichier
+func newTestStep(t *testing.T, id string, config ToolConfig) PipelineStep {
+	t.Helper()
+	processor, err := processors.NewConfiguredProcessor(config)
+	if err != nil {
+		t.Fatalf("NewConfiguredProcessor(%#v): %v", config, err)
+	}
+	return PipelineStep{ID: id, Type: config.GetType(), Config: config, Processor: processor}
+}
+
+func TestExecuteContextCancellation(t *testing.T) {
+	minify := JSONFormatterConfig{IndentType: "Minifié"}
+	loop := CustomProcessorConfig{Name: "boucle", Script: "while (true) {}", TimeoutMs: 10000}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	short, cancelShort := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelShort()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		configs []ToolConfig
+		wantErr error
+		maxTime time.Duration // Durée maximale d'exécution attendue
+	}{
+		{"annulé avant la première étape", cancelled, []ToolConfig{minify}, context.Canceled, time.Second},
+		{"délai dépassé pendant une étape", short, []ToolConfig{loop, minify}, context.DeadlineExceeded, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline := &Pipeline{}
+			for i, config := range tt.configs {
+				pipeline.Steps = append(pipeline.Steps, newTestStep(t, string(rune('a'+i)), config))
+			}
+
+			start := time.Now()
+			_, err := NewPipelineExecutor().ExecuteContext(tt.ctx, pipeline, `{"a": 1}`)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("erreur %v, attendu %v", err, tt.wantErr)
+			}
+			if elapsed := time.Since(start); elapsed > tt.maxTime {
+				t.Errorf("exécution interrompue après %s, attendu moins de %s", elapsed, tt.maxTime)
+			}
+		})
+	}
+}
+
+func TestExecuteProgress(t *testing.T) {
+	disabled := newTestStep(t, "b", JSONFormatterConfig{IndentType: "2 espaces"})
+	disabled.Disabled = true
+	pipeline := &Pipeline{Steps: []PipelineStep{
+		newTestStep(t, "a", JSONFormatterConfig{IndentType: "Minifié"}),
+		disabled,
+		newTestStep(t, "c", CustomProcessorConfig{Name: "échec", Script: "throw new Error('non')"}),
+	}}
+
+	var events []StepProgress
+	executor := &PipelineExecutor{OnProgress: func(event StepProgress) { events = append(events, event) }}
+	_, err := executor.Execute(pipeline, `{ "a" : 1 }`)
+
+	var stepErr *StepError
+	if !errors.As(err, &stepErr) || stepErr.Index != 3 {
+		t.Fatalf("erreur %v, attendu une StepError à l'étape 3", err)
+	}
+
+	want := []struct {
+		index  int
+		status StepStatus
+	}{
+		{1, StepStarted},
+		{1, StepFinished},
+		{2, StepStarted},
+		{2, StepFailed},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("%d événement(s), attendu %d: %+v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		event := events[i]
+		if event.Index != w.index || event.Status != w.status || event.Total != 2 {
+			t.Errorf("événement %d: %+v, attendu l'étape %d/2 au statut %d", i, event, w.index, w.status)
+		}
+	}
+	if want := len(`{"a":1}` + "\n"); events[1].OutputSize != want {
+		t.Errorf("taille du résultat %d, attendu %d", events[1].OutputSize, want)
+	}
+	if events[3].Err == nil {
+		t.Error("événement d'échec sans erreur")
+	}
+}
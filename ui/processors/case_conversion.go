@@ -0,0 +1,210 @@
+package processors
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+)
+
+// Casses de sortie
+const (
+	CaseUpper    = "MAJUSCULES"
+	CaseLower    = "minuscules"
+	CaseTitle    = "Titre"
+	CaseCamel    = "camelCase"
+	CasePascal   = "PascalCase"
+	CaseSnake    = "snake_case"
+	CaseKebab    = "kebab-case"
+	CaseConstant = "CONSTANT_CASE"
+)
+
+// CaseModes liste les valeurs acceptées pour CaseConversionOptions.Mode
+var CaseModes = []string{
+	CaseUpper, CaseLower, CaseTitle, CaseCamel, CasePascal, CaseSnake, CaseKebab, CaseConstant,
+}
+
+// Portée de la conversion en identifiant
+const (
+	CaseScopeLines       = "Chaque ligne"
+	CaseScopeIdentifiers = "Chaque identifiant du texte"
+)
+
+// CaseScopes liste les valeurs acceptées pour CaseConversionOptions.Scope
+var CaseScopes = []string{CaseScopeLines, CaseScopeIdentifiers}
+
+// identifierToken identifiant dans un texte: lettres, chiffres et _, avec des tirets internes
+// (user-id), mais sans les points des chemins (user.name donne deux identifiants)
+var identifierToken = regexp.MustCompile(`[\p{L}\p{N}_]+(?:-[\p{L}\p{N}_]+)*`)
+
+// isIdentifierMode indique si la casse demande un découpage en mots
+func isIdentifierMode(mode string) bool {
+	switch mode {
+	case CaseCamel, CasePascal, CaseSnake, CaseKebab, CaseConstant:
+		return true
+	}
+	return false
+}
+
+// caseConverter convertit du texte dans une casse, avec les règles de la langue choisie
+// (i pointé du turc, ij néerlandais...)
+type caseConverter struct {
+	mode         string
+	keepAcronyms bool
+	upper        cases.Caser
+	lower        cases.Caser
+	title        cases.Caser
+}
+
+func newCaseConverter(mode, locale string, keepAcronyms bool) (*caseConverter, error) {
+	tag, err := parseLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	c := &caseConverter{
+		mode:         mode,
+		keepAcronyms: keepAcronyms,
+		upper:        cases.Upper(tag),
+		lower:        cases.Lower(tag),
+		title:        cases.Title(tag),
+	}
+	if mode == CaseTitle && keepAcronyms {
+		c.title = cases.Title(tag, cases.NoLower)
+	}
+	return c, nil
+}
+
+// convert convertit le texte. Les casses d'identifiant s'appliquent à chaque ligne ou à chaque
+// identifiant selon scope; les autres s'appliquent au texte entier. L'annulation du contexte
+// est vérifiée à chaque ligne ou identifiant.
+func (c *caseConverter) convert(ctx context.Context, text, scope string) (string, error) {
+	switch c.mode {
+	case CaseUpper:
+		return c.upper.String(text), nil
+	case CaseLower:
+		return c.lower.String(text), nil
+	case CaseTitle:
+		return c.title.String(text), nil
+	}
+
+	if scope == CaseScopeIdentifiers {
+		result := identifierToken.ReplaceAllStringFunc(text, func(token string) string {
+			if cancelled(ctx) {
+				return token
+			}
+			return c.identifier(token)
+		})
+		return result, ctx.Err()
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if cancelled(ctx) {
+			return "", ctx.Err()
+		}
+		lines[i] = c.identifier(line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// identifier convertit s en identifiant. Ce qui précède le premier mot et suit le dernier
+// (indentation, puces, _ de tête, \r) est conservé.
+func (c *caseConverter) identifier(s string) string {
+	isWordRune := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) }
+	start := strings.IndexFunc(s, isWordRune)
+	if start < 0 {
+		return s
+	}
+	end := strings.LastIndexFunc(s, isWordRune)
+	_, size := utf8.DecodeRuneInString(s[end:])
+	end += size
+
+	words := splitWords(s[start:end])
+	for i, word := range words {
+		acronym := c.keepAcronyms && isAcronym(word)
+		switch {
+		case c.mode == CaseConstant:
+			words[i] = c.upper.String(word)
+		case c.mode == CaseSnake || c.mode == CaseKebab:
+			words[i] = c.lower.String(word)
+		case c.mode == CaseCamel && i == 0:
+			words[i] = c.lower.String(word)
+		case acronym:
+			// Acronyme conservé en camelCase et PascalCase (userID, HTTPServer)
+		default:
+			words[i] = c.title.String(c.lower.String(word))
+		}
+	}
+
+	var separator string
+	switch c.mode {
+	case CaseSnake, CaseConstant:
+		separator = "_"
+	case CaseKebab:
+		separator = "-"
+	}
+	return s[:start] + strings.Join(words, separator) + s[end:]
+}
+
+// splitWords découpe un texte en mots: aux caractères qui ne sont ni lettres ni chiffres
+// (espaces, _, -, .), avant une majuscule qui suit une minuscule ou un chiffre (userId,
+// utf8Decode) et avant la dernière majuscule d'un acronyme suivi d'un mot (HTTPServer donne
+// HTTP et Server). Un s minuscule qui termine un acronyme en marque le pluriel et lui reste
+// attaché (getURLs donne get et URLs). Les chiffres restent attachés au mot qui les précède
+// (base64, v2).
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = current[:0]
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(current) > 0 {
+			previous := current[len(current)-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1]) && !isPluralS(runes, i+1)
+			if unicode.IsLower(previous) || unicode.IsNumber(previous) ||
+				(unicode.IsUpper(previous) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
+
+// isPluralS indique si runes[i] est le s final d'un acronyme au pluriel (URLs, APIs, userIDs):
+// un s précédé de deux majuscules et suivi de la fin du mot ou d'un autre mot
+func isPluralS(runes []rune, i int) bool {
+	return runes[i] == 's' && i >= 2 && unicode.IsUpper(runes[i-1]) && unicode.IsUpper(runes[i-2]) &&
+		(i+1 == len(runes) || !unicode.IsLower(runes[i+1]))
+}
+
+// isAcronym indique si le mot est un acronyme: au moins deux lettres, toutes en majuscules,
+// avec éventuellement le s du pluriel (URLs)
+func isAcronym(word string) bool {
+	if runes := []rune(word); len(runes) > 2 && isPluralS(runes, len(runes)-1) {
+		word = string(runes[:len(runes)-1])
+	}
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters >= 2
+}
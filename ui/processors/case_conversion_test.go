@@ -0,0 +1,108 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"userId", []string{"user", "Id"}},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"XMLHttpRequest", []string{"XML", "Http", "Request"}},
+		{"getURLs", []string{"get", "URLs"}},
+		{"userIDs", []string{"user", "IDs"}},
+		{"URLsList", []string{"URLs", "List"}},
+		{"utf8Decode", []string{"utf8", "Decode"}},
+		{"base64_value-x.y", []string{"base64", "value", "x", "y"}},
+		{"ÉtéChaud", []string{"Été", "Chaud"}},
+		{"  ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := splitWords(tt.input); !slices.Equal(got, tt.want) {
+				t.Errorf("splitWords(%q) = %q, attendu %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaseConversion(t *testing.T) {
+	tests := []struct {
+		name    string
+		options CaseConversionOptions
+		input   string
+		want    string
+	}{
+		{"snake_case", CaseConversionOptions{Mode: CaseSnake}, "HTTPServer error", "http_server_error"},
+		{"kebab-case avec puce et fin de ligne Windows", CaseConversionOptions{Mode: CaseKebab}, "  - Mon Titre\r\n", "  - mon-titre\r\n"},
+		{"CONSTANT_CASE", CaseConversionOptions{Mode: CaseConstant}, "été chaud", "ÉTÉ_CHAUD"},
+		{"camelCase", CaseConversionOptions{Mode: CaseCamel}, "user_id", "userId"},
+		{"camelCase chaque ligne", CaseConversionOptions{Mode: CaseCamel}, "first line\n---\nsecond line", "firstLine\n---\nsecondLine"},
+		{"camelCase avec acronymes", CaseConversionOptions{Mode: CaseCamel, KeepAcronyms: true}, "user ID", "userID"},
+		{"PascalCase", CaseConversionOptions{Mode: CasePascal}, "get URLs", "GetUrls"},
+		{"PascalCase avec acronymes", CaseConversionOptions{Mode: CasePascal, KeepAcronyms: true}, "get URLs", "GetURLs"},
+		{"titre", CaseConversionOptions{Mode: CaseTitle}, "le FBI arrive", "Le Fbi Arrive"},
+		{"titre avec acronymes", CaseConversionOptions{Mode: CaseTitle, KeepAcronyms: true}, "le FBI arrive", "Le FBI Arrive"},
+		{"majuscules en turc", CaseConversionOptions{Mode: CaseUpper, Locale: "tr"}, "istanbul", "İSTANBUL"},
+		{"minuscules en turc", CaseConversionOptions{Mode: CaseLower, Locale: "tr"}, "IŞIK", "ışık"},
+		{
+			name:    "chaque identifiant du texte",
+			options: CaseConversionOptions{Mode: CaseSnake, Scope: CaseScopeIdentifiers},
+			input:   "userName = get-user-id(user.firstName)",
+			want:    "user_name = get_user_id(user.first_name)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewCaseConversionViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			got, err := vm.Process(tt.input)
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Process = %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaseConversionErrors(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		options CaseConversionOptions
+	}{
+		{"casse inconnue", context.Background(), CaseConversionOptions{Mode: "Alternée"}},
+		{"portée inconnue", context.Background(), CaseConversionOptions{Scope: "Chaque mot"}},
+		{"locale invalide", context.Background(), CaseConversionOptions{Locale: "pas une locale"}},
+		{"lignes annulées", cancelled, CaseConversionOptions{Mode: CaseSnake}},
+		{"identifiants annulés", cancelled, CaseConversionOptions{Mode: CaseSnake, Scope: CaseScopeIdentifiers}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewCaseConversionViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			_, err := vm.ProcessContext(tt.ctx, "userName")
+			if err == nil {
+				t.Fatal("erreur attendue")
+			}
+			if tt.ctx == cancelled && !errors.Is(err, context.Canceled) {
+				t.Errorf("erreur %v, attendu %v", err, context.Canceled)
+			}
+		})
+	}
+}
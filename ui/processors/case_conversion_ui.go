@@ -0,0 +1,279 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// CaseConversionUI implémente Processor pour la conversion de casse
+type CaseConversionUI struct {
+	viewModel *CaseConversionViewModel
+}
+
+func NewCaseConversionUI() Processor {
+	return &CaseConversionUI{
+		viewModel: NewCaseConversionViewModel(),
+	}
+}
+
+func (ui *CaseConversionUI) Name() string {
+	return "Conversion de casse"
+}
+
+func (ui *CaseConversionUI) Description() string {
+	return "Convertit en MAJUSCULES, minuscules, Titre, camelCase, PascalCase, snake_case, kebab-case ou CONSTANT_CASE"
+}
+
+func (ui *CaseConversionUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *CaseConversionUI) CreateConfigurationUI() fyne.CanvasObject {
+	vm := ui.viewModel
+
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("userId\nHTTPServer\nnom du champ...")
+	input.Wrapping = fyne.TextWrapOff
+	input.SetMinRowsVisible(8)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapOff
+	output.Disable()
+
+	modeSelect := widget.NewSelect(CaseModes, nil)
+	modeSelect.SetSelected(vm.mode)
+
+	scopeSelect := widget.NewSelect(CaseScopes, nil)
+	scopeSelect.SetSelected(vm.scope)
+
+	acronymsCheck := widget.NewCheck("Conserver les acronymes (userID, HTTPServer)", nil)
+	acronymsCheck.SetChecked(vm.keepAcronyms)
+
+	localeEntry := widget.NewEntry()
+	localeEntry.SetPlaceHolder("tr, nl, el... (vide: règles par défaut)")
+	localeEntry.SetText(vm.locale)
+
+	processBtn := widget.NewButton("Convertir", func() {
+		result, err := vm.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			return
+		}
+		output.SetText(result)
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := vm.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	rerun := func() {
+		if input.Text != "" {
+			processBtn.OnTapped()
+		}
+	}
+
+	// La portée ne concerne que les casses d'identifiant
+	updateMode := func() {
+		if isIdentifierMode(vm.mode) {
+			scopeSelect.Enable()
+		} else {
+			scopeSelect.Disable()
+		}
+		if vm.mode == CaseCamel || vm.mode == CasePascal || vm.mode == CaseTitle {
+			acronymsCheck.Enable()
+		} else {
+			acronymsCheck.Disable()
+		}
+	}
+	updateMode()
+
+	input.OnChanged = func(string) {
+		rerun()
+	}
+	modeSelect.OnChanged = func(s string) {
+		vm.mode = s
+		updateMode()
+		rerun()
+	}
+	scopeSelect.OnChanged = func(s string) {
+		vm.scope = s
+		rerun()
+	}
+	acronymsCheck.OnChanged = func(checked bool) {
+		vm.keepAcronyms = checked
+		rerun()
+	}
+	localeEntry.OnChanged = func(s string) {
+		vm.locale = s
+	}
+	localeEntry.OnSubmitted = func(string) {
+		rerun()
+	}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée Texte:"),
+		input,
+		container.NewHBox(
+			widget.NewLabel("Casse:"),
+			modeSelect,
+			widget.NewLabel("Portée:"),
+			scopeSelect,
+		),
+		acronymsCheck,
+		container.NewBorder(nil, nil, widget.NewLabel("Langue:"), nil, localeEntry),
+		container.NewHBox(
+			processBtn,
+			copyBtn,
+		),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// CaseConversionOptions configuration du ViewModel de conversion de casse
+type CaseConversionOptions struct {
+	Mode         string // Une des valeurs de CaseModes
+	Scope        string // Une des valeurs de CaseScopes (casses d'identifiant uniquement)
+	KeepAcronyms bool   // camelCase, PascalCase et Titre: garder les mots en majuscules
+	Locale       string // Étiquette BCP 47 des règles de casse de la langue
+}
+
+// CaseConversionViewModel implémente ViewModel pour la conversion de casse
+type CaseConversionViewModel struct {
+	mode         string
+	scope        string
+	keepAcronyms bool
+	locale       string
+	lastResult   string
+}
+
+func NewCaseConversionViewModel() *CaseConversionViewModel {
+	return &CaseConversionViewModel{
+		mode:  CaseSnake,
+		scope: CaseScopeLines,
+	}
+}
+
+func (vm *CaseConversionViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext convertit le texte en s'interrompant si le contexte est annulé
+func (vm *CaseConversionViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	if err := vm.Validate(); err != nil {
+		return "", err
+	}
+	converter, err := newCaseConverter(vm.mode, vm.locale, vm.keepAcronyms)
+	if err != nil {
+		return "", err
+	}
+	result, err := converter.convert(ctx, input, vm.scope)
+	if err != nil {
+		return "", err
+	}
+	vm.lastResult = result
+	return result, nil
+}
+
+func (vm *CaseConversionViewModel) GetConfiguration() interface{} {
+	return CaseConversionOptions{
+		Mode:         vm.mode,
+		Scope:        vm.scope,
+		KeepAcronyms: vm.keepAcronyms,
+		Locale:       vm.locale,
+	}
+}
+
+func (vm *CaseConversionViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(CaseConversionOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.mode = cfg.Mode
+	if vm.mode == "" {
+		vm.mode = CaseSnake
+	}
+	vm.scope = cfg.Scope
+	if vm.scope == "" {
+		vm.scope = CaseScopeLines
+	}
+	vm.keepAcronyms = cfg.KeepAcronyms
+	vm.locale = cfg.Locale
+	return nil
+}
+
+func (vm *CaseConversionViewModel) Validate() error {
+	return vm.GetConfiguration().(CaseConversionOptions).Validate()
+}
+
+// Validate vérifie la casse, la portée et la langue (valeurs vides acceptées)
+func (o CaseConversionOptions) Validate() error {
+	if o.Mode != "" && !slices.Contains(CaseModes, o.Mode) {
+		return fmt.Errorf("casse invalide: %s", o.Mode)
+	}
+	if o.Scope != "" && !slices.Contains(CaseScopes, o.Scope) {
+		return fmt.Errorf("portée invalide: %s", o.Scope)
+	}
+	_, err := parseLocale(o.Locale)
+	return err
+}
+
+func (vm *CaseConversionViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// CaseConversionTool identifiant de l'outil "Conversion de casse" dans les pipelines sérialisés
+const CaseConversionTool ToolType = "case_conversion"
+
+func init() {
+	Register(Registration{
+		Type:        CaseConversionTool,
+		Name:        "Conversion de casse",
+		Description: "Convertit en MAJUSCULES, minuscules, Titre, camelCase, snake_case, kebab-case...",
+		New:         NewCaseConversionUI,
+		Codec:       NewConfigCodec(CaseConversionConfig.Options, func(o CaseConversionOptions) CaseConversionConfig { return CaseConversionConfig(o) }),
+	})
+}
+
+// CaseConversionConfig configuration pour la conversion de casse
+type CaseConversionConfig struct {
+	Mode         string `json:",omitempty"` // "snake_case" par défaut
+	Scope        string `json:",omitempty"` // "Chaque ligne" (défaut) ou "Chaque identifiant du texte"
+	KeepAcronyms bool   `json:",omitempty"`
+	Locale       string `json:",omitempty"`
+}
+
+func (c CaseConversionConfig) GetType() ToolType {
+	return CaseConversionTool
+}
+
+func (c CaseConversionConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c CaseConversionConfig) GetDisplayName() string {
+	mode := c.Mode
+	if mode == "" {
+		mode = CaseSnake
+	}
+	return fmt.Sprintf("Casse: %s", mode)
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c CaseConversionConfig) Options() CaseConversionOptions {
+	return CaseConversionOptions(c)
+}
@@ -0,0 +1,350 @@
+package processors
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Formats de sortie du processeur CSV
+const (
+	CSVOutputCSV   = "CSV"
+	CSVOutputTSV   = "TSV"
+	CSVOutputTable = "Tableau aligné"
+)
+
+// CSVOutputs liste les valeurs acceptées pour CSVOptions.Output
+var CSVOutputs = []string{CSVOutputCSV, CSVOutputTSV, CSVOutputTable}
+
+// Opérateurs de filtre des lignes
+const (
+	CSVFilterEquals      = "="
+	CSVFilterNotEquals   = "≠"
+	CSVFilterContains    = "contient"
+	CSVFilterNotContains = "ne contient pas"
+	CSVFilterLess        = "<"
+	CSVFilterGreater     = ">"
+	CSVFilterRegex       = "expression régulière"
+)
+
+// CSVFilterOps liste les valeurs acceptées pour CSVOptions.FilterOp
+var CSVFilterOps = []string{
+	CSVFilterEquals, CSVFilterNotEquals, CSVFilterContains, CSVFilterNotContains,
+	CSVFilterLess, CSVFilterGreater, CSVFilterRegex,
+}
+
+// csvTable données CSV lues, avec la ligne d'en-tête éventuelle
+type csvTable struct {
+	header []string // nil sans ligne d'en-tête
+	rows   [][]string
+}
+
+// parseCSV lit toutes les lignes, après un éventuel BOM UTF-8 en début de texte. Une ligne
+// n'ayant pas autant de cellules que la première (l'en-tête s'il y en a un) est une erreur
+// indiquant son numéro, sauf avec padRows: les lignes de longueurs différentes sont alors
+// acceptées et les cellules manquantes sont vides. L'annulation du contexte est vérifiée à
+// chaque ligne.
+func parseCSV(ctx context.Context, input string, separator rune, lazyQuotes, header, padRows bool) (*csvTable, error) {
+	input = strings.TrimPrefix(input, "\uFEFF")
+	reader := csv.NewReader(strings.NewReader(input))
+	reader.Comma = separator
+	reader.LazyQuotes = lazyQuotes
+	reader.FieldsPerRecord = -1
+
+	table := &csvTable{}
+	width := -1 // Nombre de cellules de la première ligne
+	for {
+		if cancelled(ctx) {
+			return nil, ctx.Err()
+		}
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, locateCSVError(input, err)
+		}
+		if width < 0 {
+			width = len(record)
+		} else if len(record) != width && !padRows {
+			line, _ := reader.FieldPos(0)
+			return nil, newSyntaxError("CSV", input, line, 1,
+				fmt.Errorf("%d cellule(s) au lieu de %d (option « Compléter les lignes » pour accepter les lignes de longueurs différentes)", len(record), width))
+		}
+		if header && table.header == nil {
+			table.header = record
+			continue
+		}
+		table.rows = append(table.rows, record)
+	}
+	return table, nil
+}
+
+// locateCSVError convertit une *csv.ParseError en *SyntaxError (la colonne de encoding/csv
+// est en octets, celle de SyntaxError en caractères)
+func locateCSVError(input string, err error) error {
+	var parseErr *csv.ParseError
+	if !errors.As(err, &parseErr) {
+		return &SyntaxError{Format: "CSV", Err: err}
+	}
+	column := parseErr.Column
+	if lines := strings.Split(input, "\n"); parseErr.Line >= 1 && parseErr.Line <= len(lines) {
+		lineText := lines[parseErr.Line-1]
+		if column >= 1 {
+			column = utf8.RuneCountInString(lineText[:min(column-1, len(lineText))]) + 1
+		}
+	}
+	return newSyntaxError("CSV", input, parseErr.Line, column, parseErr.Err)
+}
+
+// width nombre de colonnes de la ligne la plus longue
+func (t *csvTable) width() int {
+	width := len(t.header)
+	for _, row := range t.rows {
+		width = max(width, len(row))
+	}
+	return width
+}
+
+// column résout une référence de colonne: nom de l'en-tête, sinon numéro à partir de 1
+func (t *csvTable) column(ref string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	for i, name := range t.header {
+		if strings.TrimSpace(name) == ref {
+			return i, nil
+		}
+	}
+	if n, err := strconv.Atoi(ref); err == nil {
+		if n < 1 || n > t.width() {
+			return 0, fmt.Errorf("colonne %d hors limites (%d colonnes)", n, t.width())
+		}
+		return n - 1, nil
+	}
+	if t.header == nil {
+		return 0, fmt.Errorf("colonne %q: sans ligne d'en-tête, indiquez le numéro de la colonne", ref)
+	}
+	return 0, fmt.Errorf("colonne %q introuvable dans l'en-tête", ref)
+}
+
+// cell retourne la cellule de la ligne, vide si la ligne est trop courte
+func cell(row []string, column int) string {
+	if column < len(row) {
+		return row[column]
+	}
+	return ""
+}
+
+// parseCSVNumber lit une cellule numérique (espaces ignorés)
+func parseCSVNumber(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return f, err == nil
+}
+
+// filter conserve les lignes dont la colonne satisfait l'opérateur. Les comparaisons < et >
+// sont numériques quand les deux valeurs sont des nombres, alphabétiques sinon.
+func (t *csvTable) filter(column int, op, value string) error {
+	var match func(string) bool
+	switch op {
+	case CSVFilterEquals:
+		match = func(s string) bool { return s == value }
+	case CSVFilterNotEquals:
+		match = func(s string) bool { return s != value }
+	case CSVFilterContains:
+		match = func(s string) bool { return strings.Contains(s, value) }
+	case CSVFilterNotContains:
+		match = func(s string) bool { return !strings.Contains(s, value) }
+	case CSVFilterLess, CSVFilterGreater:
+		want := 1
+		if op == CSVFilterLess {
+			want = -1
+		}
+		match = func(s string) bool { return compareCSVValues(s, value) == want }
+	case CSVFilterRegex:
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return fmt.Errorf("expression régulière invalide: %w", err)
+		}
+		match = re.MatchString
+	default:
+		return fmt.Errorf("opérateur de filtre inconnu: %s", op)
+	}
+
+	kept := t.rows[:0]
+	for _, row := range t.rows {
+		if match(cell(row, column)) {
+			kept = append(kept, row)
+		}
+	}
+	t.rows = kept
+	return nil
+}
+
+func compareCSVValues(a, b string) int {
+	x, okA := parseCSVNumber(a)
+	y, okB := parseCSVNumber(b)
+	switch {
+	case okA && okB && x < y:
+		return -1
+	case okA && okB && x > y:
+		return 1
+	case okA && okB:
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
+// sortBy trie les lignes (tri stable) selon la colonne: numérique si toutes les cellules non
+// vides sont des nombres, alphabétique sinon; les cellules vides sont placées en premier
+func (t *csvTable) sortBy(column int, descending bool) {
+	numeric := true
+	for _, row := range t.rows {
+		if v := cell(row, column); strings.TrimSpace(v) != "" {
+			if _, ok := parseCSVNumber(v); !ok {
+				numeric = false
+				break
+			}
+		}
+	}
+
+	less := func(a, b string) bool {
+		if numeric {
+			if strings.TrimSpace(a) == "" || strings.TrimSpace(b) == "" {
+				return strings.TrimSpace(a) == "" && strings.TrimSpace(b) != ""
+			}
+			x, _ := parseCSVNumber(a)
+			y, _ := parseCSVNumber(b)
+			return x < y
+		}
+		return a < b
+	}
+	sort.SliceStable(t.rows, func(i, j int) bool {
+		a, b := cell(t.rows[i], column), cell(t.rows[j], column)
+		if descending {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+}
+
+// selectColumns ne garde que les colonnes indiquées, dans cet ordre
+func (t *csvTable) selectColumns(columns []int) {
+	project := func(row []string) []string {
+		projected := make([]string, len(columns))
+		for i, c := range columns {
+			projected[i] = cell(row, c)
+		}
+		return projected
+	}
+	if t.header != nil {
+		t.header = project(t.header)
+	}
+	for i, row := range t.rows {
+		t.rows[i] = project(row)
+	}
+}
+
+// dedupe supprime les lignes identiques à une ligne précédente
+func (t *csvTable) dedupe() {
+	seen := make(map[string]bool)
+	kept := t.rows[:0]
+	for _, row := range t.rows {
+		key := strings.Join(row, "\x00")
+		if !seen[key] {
+			seen[key] = true
+			kept = append(kept, row)
+		}
+	}
+	t.rows = kept
+}
+
+// records retourne l'en-tête éventuel suivi des lignes, toutes complétées à la même largeur
+func (t *csvTable) records() [][]string {
+	width := t.width()
+	pad := func(row []string) []string {
+		if len(row) == width {
+			return row
+		}
+		padded := make([]string, width)
+		copy(padded, row)
+		return padded
+	}
+	var records [][]string
+	if t.header != nil {
+		records = append(records, pad(t.header))
+	}
+	for _, row := range t.rows {
+		records = append(records, pad(row))
+	}
+	return records
+}
+
+// writeDelimited écrit les lignes en CSV ou TSV (guillemets ajoutés si nécessaire)
+func (t *csvTable) writeDelimited(separator rune) (string, error) {
+	var out strings.Builder
+	writer := csv.NewWriter(&out)
+	writer.Comma = separator
+	if err := writer.WriteAll(t.records()); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// writeAligned écrit un tableau texte: colonnes alignées, nombres à droite, en-tête souligné
+func (t *csvTable) writeAligned() string {
+	records := t.records()
+	width := t.width()
+	widths := make([]int, width)
+	numeric := make([]bool, width)
+	for c := range width {
+		numeric[c] = t.numericColumn(c)
+		for _, record := range records {
+			widths[c] = max(widths[c], utf8.RuneCountInString(record[c]))
+		}
+	}
+
+	var out strings.Builder
+	writeRow := func(record []string) {
+		cells := make([]string, width)
+		for c, v := range record {
+			padding := strings.Repeat(" ", widths[c]-utf8.RuneCountInString(v))
+			if numeric[c] {
+				cells[c] = padding + v
+			} else {
+				cells[c] = v + padding
+			}
+		}
+		out.WriteString(strings.TrimRight(strings.Join(cells, "  "), " "))
+		out.WriteString("\n")
+	}
+
+	for i, record := range records {
+		writeRow(record)
+		if i == 0 && t.header != nil {
+			rules := make([]string, width)
+			for c := range width {
+				rules[c] = strings.Repeat("-", widths[c])
+			}
+			out.WriteString(strings.Join(rules, "  "))
+			out.WriteString("\n")
+		}
+	}
+	return out.String()
+}
+
+// splitColumnList découpe une liste de colonnes séparées par des virgules
+func splitColumnList(spec string) []string {
+	var refs []string
+	for _, ref := range strings.Split(spec, ",") {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
@@ -0,0 +1,170 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		separator  rune
+		header     bool
+		padRows    bool
+		wantHeader []string
+		wantRows   [][]string
+	}{
+		{"avec en-tête", "a,b\n1,2\n3,4\n", ',', true, false, []string{"a", "b"}, [][]string{{"1", "2"}, {"3", "4"}}},
+		{"sans en-tête", "1,2\n3,4", ',', false, false, nil, [][]string{{"1", "2"}, {"3", "4"}}},
+		{"BOM UTF-8 supprimé", "\uFEFFnom;âge\nA;3\n", ';', true, false, []string{"nom", "âge"}, [][]string{{"A", "3"}}},
+		{"guillemets et retours à la ligne", "a,b\n\"x,y\",\"l1\nl2\"\n", ',', true, false, []string{"a", "b"}, [][]string{{"x,y", "l1\nl2"}}},
+		{"fins de ligne CRLF", "a\tb\r\n1\t2\r\n", '\t', true, false, []string{"a", "b"}, [][]string{{"1", "2"}}},
+		{"lignes irrégulières acceptées", "a,b\n1\n2,3,4\n", ',', true, true, []string{"a", "b"}, [][]string{{"1"}, {"2", "3", "4"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := parseCSV(context.Background(), tt.input, tt.separator, false, tt.header, tt.padRows)
+			if err != nil {
+				t.Fatalf("parseCSV: %v", err)
+			}
+			if !reflect.DeepEqual(table.header, tt.wantHeader) {
+				t.Errorf("en-tête %q, attendu %q", table.header, tt.wantHeader)
+			}
+			if !reflect.DeepEqual(table.rows, tt.wantRows) {
+				t.Errorf("lignes %q, attendu %q", table.rows, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		lazy   bool
+		line   int
+		column int
+	}{
+		{"ligne trop courte", "a,b\n1,2\n3\n", false, 3, 1},
+		{"ligne trop longue", "a,b\n1,2,3\n", false, 2, 1},
+		{"ligne irrégulière après un BOM", "\uFEFFa,b\n1\n", false, 2, 1},
+		{"ligne irrégulière après une cellule multiligne", "a,b\n\"x\ny\",1\n2\n", false, 4, 1},
+		{"guillemet mal placé", "a,b\nx\"y,1\n", false, 2, 2},
+		{"guillemet en colonne multi-octets", "a,b\né\"y,1\n", false, 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCSV(context.Background(), tt.input, ',', tt.lazy, true, false)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("erreur %v (%T), attendu une *SyntaxError", err, err)
+			}
+			if syntaxErr.Line != tt.line || syntaxErr.Column != tt.column {
+				t.Errorf("ligne %d, colonne %d, attendu ligne %d, colonne %d (%v)", syntaxErr.Line, syntaxErr.Column, tt.line, tt.column, err)
+			}
+		})
+	}
+}
+
+const csvPeople = "nom,ville,âge\nAlice,Paris,30\nBob,Lyon,9\nChloé,Paris,\nDavid,Nice,120\nBob,Lyon,9\n"
+
+func TestCSVViewModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		options CSVOptions
+		input   string
+		want    string
+	}{
+		{
+			name:    "filtre d'égalité",
+			options: CSVOptions{FilterColumn: "ville", FilterOp: CSVFilterEquals, FilterValue: "Paris"},
+			want:    "nom,ville,âge\nAlice,Paris,30\nChloé,Paris,\n",
+		},
+		{
+			name:    "filtre numérique",
+			options: CSVOptions{FilterColumn: "3", FilterOp: CSVFilterGreater, FilterValue: "10"},
+			want:    "nom,ville,âge\nAlice,Paris,30\nDavid,Nice,120\n",
+		},
+		{
+			name:    "filtre par expression régulière",
+			options: CSVOptions{FilterColumn: "nom", FilterOp: CSVFilterRegex, FilterValue: "^[AB]"},
+			want:    "nom,ville,âge\nAlice,Paris,30\nBob,Lyon,9\nBob,Lyon,9\n",
+		},
+		{
+			name:    "tri numérique décroissant",
+			options: CSVOptions{SortColumn: "âge", SortDescending: true, Columns: "nom"},
+			want:    "nom\nDavid\nAlice\nBob\nBob\nChloé\n",
+		},
+		{
+			name:    "sélection et dédoublonnage",
+			options: CSVOptions{Columns: "ville, nom", Dedupe: true},
+			want:    "ville,nom\nParis,Alice\nLyon,Bob\nParis,Chloé\nNice,David\n",
+		},
+		{
+			name:    "sortie TSV",
+			options: CSVOptions{Columns: "1,3", FilterColumn: "nom", FilterOp: CSVFilterEquals, FilterValue: "Alice", Output: CSVOutputTSV},
+			want:    "nom\tâge\nAlice\t30\n",
+		},
+		{
+			name:    "tableau aligné",
+			options: CSVOptions{Columns: "nom,âge", Dedupe: true, Output: CSVOutputTable},
+			want:    "nom    âge\n-----  ---\nAlice   30\nBob      9\nChloé\nDavid  120\n",
+		},
+		{
+			name:    "lignes complétées",
+			options: CSVOptions{PadRows: true},
+			input:   "a,b,c\n1\n2,3\n",
+			want:    "a,b,c\n1,,\n2,3,\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := tt.options
+			options.Header = true
+			if options.FilterOp == "" {
+				options.FilterOp = CSVFilterEquals
+			}
+			input := tt.input
+			if input == "" {
+				input = csvPeople
+			}
+			vm := NewCSVViewModel()
+			if err := vm.LoadConfiguration(options); err != nil {
+				t.Fatal(err)
+			}
+			got, err := vm.Process(input)
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Process =\n%s\nattendu\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVViewModelColumnErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		options CSVOptions
+	}{
+		{"colonne absente de l'en-tête", CSVOptions{Header: true, SortColumn: "pays"}},
+		{"numéro hors limites", CSVOptions{Header: true, Columns: "4"}},
+		{"nom sans en-tête", CSVOptions{SortColumn: "nom"}},
+		{"expression régulière invalide", CSVOptions{Header: true, FilterColumn: "nom", FilterOp: CSVFilterRegex, FilterValue: "("}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewCSVViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := vm.Process(csvPeople); err == nil {
+				t.Errorf("Process = %q, erreur attendue", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,491 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// csvSeparator séparateur proposé dans l'interface
+type csvSeparator struct {
+	Label string
+	Value string
+}
+
+// csvSeparators séparateurs proposés; la configuration accepte n'importe quel caractère
+var csvSeparators = []csvSeparator{
+	{"Virgule (,)", ","},
+	{"Point-virgule (;)", ";"},
+	{"Tabulation", "\t"},
+	{"Barre verticale (|)", "|"},
+}
+
+// newCSVSeparatorSelect crée la liste des séparateurs; onChanged reçoit le caractère choisi
+func newCSVSeparatorSelect(current string, onChanged func(separator string)) *widget.Select {
+	var labels []string
+	for _, sep := range csvSeparators {
+		labels = append(labels, sep.Label)
+	}
+	separatorSelect := widget.NewSelect(labels, nil)
+	for _, sep := range csvSeparators {
+		if sep.Value == current {
+			separatorSelect.SetSelected(sep.Label)
+		}
+	}
+	separatorSelect.OnChanged = func(label string) {
+		for _, sep := range csvSeparators {
+			if sep.Label == label {
+				onChanged(sep.Value)
+			}
+		}
+	}
+	return separatorSelect
+}
+
+// validateCSVSeparator vérifie qu'un séparateur est un caractère unique utilisable par
+// encoding/csv (valeur vide acceptée)
+func validateCSVSeparator(separator string) error {
+	if separator == "" {
+		return nil
+	}
+	r, size := utf8.DecodeRuneInString(separator)
+	if size != len(separator) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+		return fmt.Errorf("séparateur invalide %q: un seul caractère, autre que guillemet ou retour à la ligne", separator)
+	}
+	return nil
+}
+
+// CSVUI implémente Processor pour les données CSV/TSV
+type CSVUI struct {
+	viewModel *CSVViewModel
+}
+
+func NewCSVUI() Processor {
+	return &CSVUI{
+		viewModel: NewCSVViewModel(),
+	}
+}
+
+func (ui *CSVUI) Name() string {
+	return "CSV / TSV"
+}
+
+func (ui *CSVUI) Description() string {
+	return "Sélectionne, filtre, trie et dédoublonne des données CSV ou TSV"
+}
+
+func (ui *CSVUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *CSVUI) CreateConfigurationUI() fyne.CanvasObject {
+	vm := ui.viewModel
+
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("nom,ville,age\n\"Dupont, Jean\",Paris,42")
+	input.Wrapping = fyne.TextWrapOff
+	input.SetMinRowsVisible(8)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapOff
+	output.TextStyle = fyne.TextStyle{Monospace: true}
+	output.Disable()
+
+	status := widget.NewLabel("")
+
+	headerCheck := widget.NewCheck("Première ligne = en-tête", nil)
+	headerCheck.SetChecked(vm.header)
+
+	lazyCheck := widget.NewCheck("Guillemets permissifs", nil)
+	lazyCheck.SetChecked(vm.lazyQuotes)
+
+	padCheck := widget.NewCheck("Compléter les lignes", nil)
+	padCheck.SetChecked(vm.padRows)
+
+	columnsEntry := widget.NewEntry()
+	columnsEntry.SetPlaceHolder("nom, 3, email (vide: toutes les colonnes)")
+	columnsEntry.SetText(vm.columns)
+
+	filterColumnEntry := widget.NewEntry()
+	filterColumnEntry.SetPlaceHolder("Colonne")
+	filterColumnEntry.SetText(vm.filterColumn)
+
+	filterOpSelect := widget.NewSelect(CSVFilterOps, nil)
+	filterOpSelect.SetSelected(vm.filterOp)
+
+	filterValueEntry := widget.NewEntry()
+	filterValueEntry.SetPlaceHolder("Valeur")
+	filterValueEntry.SetText(vm.filterValue)
+
+	sortColumnEntry := widget.NewEntry()
+	sortColumnEntry.SetPlaceHolder("Colonne de tri (vide: ordre d'origine)")
+	sortColumnEntry.SetText(vm.sortColumn)
+
+	descendingCheck := widget.NewCheck("Décroissant", nil)
+	descendingCheck.SetChecked(vm.sortDescending)
+
+	dedupeCheck := widget.NewCheck("Supprimer les doublons", nil)
+	dedupeCheck.SetChecked(vm.dedupe)
+
+	outputSelect := widget.NewSelect(CSVOutputs, nil)
+	outputSelect.SetSelected(vm.output)
+
+	processBtn := widget.NewButton("Traiter", func() {
+		result, err := vm.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			status.SetText("")
+			highlightJSONError(input, err)
+			return
+		}
+		output.SetText(result)
+		status.SetText(fmt.Sprintf("%d ligne(s)", vm.lastCount))
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := vm.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	rerun := func() {
+		if input.Text != "" {
+			processBtn.OnTapped()
+		}
+	}
+
+	separatorSelect := newCSVSeparatorSelect(vm.separator, func(separator string) {
+		vm.separator = separator
+		rerun()
+	})
+	headerCheck.OnChanged = func(checked bool) {
+		vm.header = checked
+		rerun()
+	}
+	lazyCheck.OnChanged = func(checked bool) {
+		vm.lazyQuotes = checked
+		rerun()
+	}
+	padCheck.OnChanged = func(checked bool) {
+		vm.padRows = checked
+		rerun()
+	}
+	columnsEntry.OnChanged = func(s string) {
+		vm.columns = s
+	}
+	filterColumnEntry.OnChanged = func(s string) {
+		vm.filterColumn = s
+	}
+	filterOpSelect.OnChanged = func(s string) {
+		vm.filterOp = s
+		rerun()
+	}
+	filterValueEntry.OnChanged = func(s string) {
+		vm.filterValue = s
+	}
+	sortColumnEntry.OnChanged = func(s string) {
+		vm.sortColumn = s
+	}
+	descendingCheck.OnChanged = func(checked bool) {
+		vm.sortDescending = checked
+		rerun()
+	}
+	dedupeCheck.OnChanged = func(checked bool) {
+		vm.dedupe = checked
+		rerun()
+	}
+	outputSelect.OnChanged = func(s string) {
+		vm.output = s
+		rerun()
+	}
+	for _, entry := range []*widget.Entry{columnsEntry, filterColumnEntry, filterValueEntry, sortColumnEntry} {
+		entry.OnSubmitted = func(string) { rerun() }
+	}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée CSV:"),
+		input,
+		container.NewHBox(
+			widget.NewLabel("Séparateur:"),
+			separatorSelect,
+			headerCheck,
+			lazyCheck,
+			padCheck,
+		),
+		container.NewBorder(nil, nil, widget.NewLabel("Colonnes:"), nil, columnsEntry),
+		container.NewBorder(nil, nil, widget.NewLabel("Filtre:"), nil,
+			container.NewGridWithColumns(3, filterColumnEntry, filterOpSelect, filterValueEntry)),
+		container.NewBorder(nil, nil, widget.NewLabel("Tri:"), container.NewHBox(descendingCheck, dedupeCheck), sortColumnEntry),
+		container.NewHBox(
+			widget.NewLabel("Sortie:"),
+			outputSelect,
+			processBtn,
+			copyBtn,
+			status,
+		),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// CSVOptions configuration du ViewModel CSV. Les colonnes sont désignées par leur nom dans
+// l'en-tête ou par leur numéro (à partir de 1).
+type CSVOptions struct {
+	Separator      string // Un caractère, "," par défaut
+	LazyQuotes     bool   // Accepter les guillemets mal formés (encoding/csv LazyQuotes)
+	PadRows        bool   // Accepter les lignes de longueurs différentes, complétées par des cellules vides
+	Header         bool   // La première ligne contient les noms des colonnes
+	Columns        string // Colonnes à garder, dans l'ordre, séparées par des virgules (vide: toutes)
+	FilterColumn   string // Colonne filtrée (vide: pas de filtre)
+	FilterOp       string // Une des valeurs de CSVFilterOps
+	FilterValue    string
+	SortColumn     string // Colonne de tri (vide: ordre d'origine)
+	SortDescending bool
+	Dedupe         bool   // Supprimer les lignes en double (après sélection des colonnes)
+	Output         string // Une des valeurs de CSVOutputs
+}
+
+// CSVViewModel implémente ViewModel pour le processeur CSV. Les opérations sont appliquées
+// dans l'ordre: filtre, tri, sélection des colonnes, dédoublonnage.
+type CSVViewModel struct {
+	separator      string
+	lazyQuotes     bool
+	padRows        bool
+	header         bool
+	columns        string
+	filterColumn   string
+	filterOp       string
+	filterValue    string
+	sortColumn     string
+	sortDescending bool
+	dedupe         bool
+	output         string
+	lastResult     string
+	lastCount      int
+}
+
+func NewCSVViewModel() *CSVViewModel {
+	return &CSVViewModel{
+		separator: ",",
+		header:    true,
+		filterOp:  CSVFilterEquals,
+		output:    CSVOutputCSV,
+	}
+}
+
+func (vm *CSVViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext transforme le tableau; l'annulation du contexte est vérifiée à chaque ligne lue
+// puis entre les opérations (filtre, tri, sélection, dédoublonnage)
+func (vm *CSVViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		vm.lastResult, vm.lastCount = "", 0
+		return "", nil
+	}
+
+	separator, _ := utf8.DecodeRuneInString(vm.separator)
+	table, err := parseCSV(ctx, input, separator, vm.lazyQuotes, vm.header, vm.padRows)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSpace(vm.filterColumn) != "" {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		column, err := table.column(vm.filterColumn)
+		if err != nil {
+			return "", fmt.Errorf("filtre: %w", err)
+		}
+		if err := table.filter(column, vm.filterOp, vm.filterValue); err != nil {
+			return "", fmt.Errorf("filtre: %w", err)
+		}
+	}
+
+	if strings.TrimSpace(vm.sortColumn) != "" {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		column, err := table.column(vm.sortColumn)
+		if err != nil {
+			return "", fmt.Errorf("tri: %w", err)
+		}
+		table.sortBy(column, vm.sortDescending)
+	}
+
+	if refs := splitColumnList(vm.columns); len(refs) > 0 {
+		columns := make([]int, len(refs))
+		for i, ref := range refs {
+			if columns[i], err = table.column(ref); err != nil {
+				return "", fmt.Errorf("sélection: %w", err)
+			}
+		}
+		table.selectColumns(columns)
+	}
+
+	if vm.dedupe {
+		table.dedupe()
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	var result string
+	switch vm.output {
+	case CSVOutputTSV:
+		result, err = table.writeDelimited('\t')
+	case CSVOutputTable:
+		result = table.writeAligned()
+	default:
+		result, err = table.writeDelimited(separator)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	vm.lastResult, vm.lastCount = result, len(table.rows)
+	return result, nil
+}
+
+func (vm *CSVViewModel) GetConfiguration() interface{} {
+	return CSVOptions{
+		Separator:      vm.separator,
+		LazyQuotes:     vm.lazyQuotes,
+		PadRows:        vm.padRows,
+		Header:         vm.header,
+		Columns:        vm.columns,
+		FilterColumn:   vm.filterColumn,
+		FilterOp:       vm.filterOp,
+		FilterValue:    vm.filterValue,
+		SortColumn:     vm.sortColumn,
+		SortDescending: vm.sortDescending,
+		Dedupe:         vm.dedupe,
+		Output:         vm.output,
+	}
+}
+
+func (vm *CSVViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(CSVOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.separator = cfg.Separator
+	if vm.separator == "" {
+		vm.separator = ","
+	}
+	vm.lazyQuotes = cfg.LazyQuotes
+	vm.padRows = cfg.PadRows
+	vm.header = cfg.Header
+	vm.columns = cfg.Columns
+	vm.filterColumn = cfg.FilterColumn
+	vm.filterOp = cfg.FilterOp
+	if vm.filterOp == "" {
+		vm.filterOp = CSVFilterEquals
+	}
+	vm.filterValue = cfg.FilterValue
+	vm.sortColumn = cfg.SortColumn
+	vm.sortDescending = cfg.SortDescending
+	vm.dedupe = cfg.Dedupe
+	vm.output = cfg.Output
+	if vm.output == "" {
+		vm.output = CSVOutputCSV
+	}
+	return nil
+}
+
+func (vm *CSVViewModel) Validate() error {
+	return vm.GetConfiguration().(CSVOptions).Validate()
+}
+
+// Validate vérifie le séparateur, le format de sortie et le filtre (valeurs vides acceptées)
+func (o CSVOptions) Validate() error {
+	if err := validateCSVSeparator(o.Separator); err != nil {
+		return err
+	}
+	if o.Output != "" && !slices.Contains(CSVOutputs, o.Output) {
+		return fmt.Errorf("format de sortie invalide: %s", o.Output)
+	}
+	if strings.TrimSpace(o.FilterColumn) == "" {
+		return nil
+	}
+	if o.FilterOp != "" && !slices.Contains(CSVFilterOps, o.FilterOp) {
+		return fmt.Errorf("opérateur de filtre invalide: %s", o.FilterOp)
+	}
+	if o.FilterOp == CSVFilterRegex {
+		if _, err := regexp.Compile(o.FilterValue); err != nil {
+			return fmt.Errorf("filtre: expression régulière invalide: %w", err)
+		}
+	}
+	return nil
+}
+
+func (vm *CSVViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// CSVTool identifiant de l'outil "CSV" dans les pipelines sérialisés
+const CSVTool ToolType = "csv"
+
+func init() {
+	Register(Registration{
+		Type:        CSVTool,
+		Name:        "CSV",
+		Description: "Sélectionne, filtre, trie et dédoublonne des données CSV ou TSV",
+		New:         NewCSVUI,
+		Codec:       NewConfigCodec(CSVConfig.Options, func(o CSVOptions) CSVConfig { return CSVConfig(o) }),
+	})
+}
+
+// CSVConfig configuration pour le processeur CSV/TSV. Les colonnes sont désignées par leur
+// nom dans l'en-tête ou par leur numéro (à partir de 1).
+type CSVConfig struct {
+	Separator      string `json:",omitempty"` // "," par défaut
+	LazyQuotes     bool   `json:",omitempty"`
+	PadRows        bool   `json:",omitempty"` // Lignes courtes complétées au lieu d'être refusées
+	Header         bool
+	Columns        string `json:",omitempty"` // Colonnes gardées, séparées par des virgules
+	FilterColumn   string `json:",omitempty"`
+	FilterOp       string `json:",omitempty"` // "=", "≠", "contient", "ne contient pas", "<", ">", "expression régulière"
+	FilterValue    string `json:",omitempty"`
+	SortColumn     string `json:",omitempty"`
+	SortDescending bool   `json:",omitempty"`
+	Dedupe         bool   `json:",omitempty"`
+	Output         string `json:",omitempty"` // "CSV" (défaut), "TSV" ou "Tableau aligné"
+}
+
+func (c CSVConfig) GetType() ToolType {
+	return CSVTool
+}
+
+func (c CSVConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c CSVConfig) GetDisplayName() string {
+	output := c.Output
+	if output == "" {
+		output = CSVOutputCSV
+	}
+	return fmt.Sprintf("CSV (sortie: %s)", output)
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c CSVConfig) Options() CSVOptions {
+	return CSVOptions(c)
+}
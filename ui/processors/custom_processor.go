@@ -1,8 +1,11 @@
 package processors
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -21,6 +24,14 @@ func NewCustomProcessor(name, script string) Processor {
 	}
 }
 
+// NewCustomProcessorWithOptions crée un processeur personnalisé avec ses limites d'exécution
+func NewCustomProcessorWithOptions(opts CustomProcessorOptions) Processor {
+	vm := NewCustomProcessorViewModel(opts.Name, opts.Script)
+	vm.timeout = opts.Timeout
+	vm.maxOutputSize = opts.MaxOutputSize
+	return &CustomProcessor{viewModel: vm}
+}
+
 func (cp *CustomProcessor) Name() string {
 	if cp.viewModel.name != "" {
 		return cp.viewModel.name
@@ -60,7 +71,7 @@ func (cp *CustomProcessor) CreateConfigurationUI() fyne.CanvasObject {
 		// Mettre à jour le nom et le script
 		cp.viewModel.name = nameEntry.Text
 		cp.viewModel.script = scriptEntry.Text
-		
+
 		result, err := cp.viewModel.Process(input.Text)
 		if err != nil {
 			output.SetText(fmt.Sprintf("Erreur: %s", err.Error()))
@@ -80,7 +91,7 @@ func (cp *CustomProcessor) CreateConfigurationUI() fyne.CanvasObject {
 	nameEntry.OnChanged = func(s string) {
 		cp.viewModel.name = s
 	}
-	
+
 	scriptEntry.OnChanged = func(s string) {
 		cp.viewModel.script = s
 	}
@@ -111,11 +122,35 @@ func (cp *CustomProcessor) CreateConfigurationUI() fyne.CanvasObject {
 	)
 }
 
+// Limites par défaut appliquées aux scripts JavaScript
+const (
+	DefaultScriptTimeout    = 5 * time.Second
+	DefaultMaxOutputSize    = 10 << 20 // 10 Mo
+	DefaultMaxCallStackSize = 10000
+)
+
+var (
+	// ErrScriptTimeout est retournée quand un script dépasse son délai d'exécution
+	ErrScriptTimeout = errors.New("délai d'exécution du script dépassé")
+	// ErrOutputTooLarge est retournée quand le résultat d'un script dépasse la taille autorisée
+	ErrOutputTooLarge = errors.New("résultat du script trop volumineux")
+)
+
+// CustomProcessorOptions configuration du ViewModel d'un processeur personnalisé
+type CustomProcessorOptions struct {
+	Name          string
+	Script        string
+	Timeout       time.Duration // 0: DefaultScriptTimeout
+	MaxOutputSize int           // En octets, 0: DefaultMaxOutputSize
+}
+
 // CustomProcessorViewModel implémente ViewModel pour les processeurs personnalisés
 type CustomProcessorViewModel struct {
-	name       string
-	script     string
-	lastResult string
+	name          string
+	script        string
+	timeout       time.Duration
+	maxOutputSize int
+	lastResult    string
 }
 
 func NewCustomProcessorViewModel(name, script string) *CustomProcessorViewModel {
@@ -125,68 +160,126 @@ func NewCustomProcessorViewModel(name, script string) *CustomProcessorViewModel
 	}
 }
 
+func (vm *CustomProcessorViewModel) effectiveTimeout() time.Duration {
+	if vm.timeout > 0 {
+		return vm.timeout
+	}
+	return DefaultScriptTimeout
+}
+
+func (vm *CustomProcessorViewModel) effectiveMaxOutputSize() int {
+	if vm.maxOutputSize > 0 {
+		return vm.maxOutputSize
+	}
+	return DefaultMaxOutputSize
+}
+
 func (vm *CustomProcessorViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext exécute le script en l'interrompant si le contexte est annulé
+func (vm *CustomProcessorViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
 	if vm.script == "" {
 		return "", fmt.Errorf("aucun script défini")
 	}
 
 	// Créer un runtime JavaScript
 	jsRuntime := goja.New()
-	
+	jsRuntime.SetMaxCallStackSize(DefaultMaxCallStackSize)
+
+	// Interrompre le script s'il dépasse le délai autorisé (ex: while(true){})
+	timeout := vm.effectiveTimeout()
+	timer := time.AfterFunc(timeout, func() {
+		jsRuntime.Interrupt(ErrScriptTimeout)
+	})
+	defer timer.Stop()
+
+	// Interrompre le script si l'exécution est annulée
+	stop := context.AfterFunc(ctx, func() {
+		jsRuntime.Interrupt(context.Cause(ctx))
+	})
+	defer stop()
+
 	// Définir la fonction d'entrée
 	jsRuntime.Set("input", input)
-	
+
 	// Préparer le script avec une fonction wrapper si nécessaire
 	script := vm.script
 	if !strings.Contains(script, "function") && !strings.Contains(script, "=>") {
 		// Si ce n'est pas une fonction, on l'enveloppe
 		script = fmt.Sprintf("function process(input) { %s }", script)
 	}
-	
+
 	// Ajouter une fonction process par défaut si elle n'existe pas
 	if !strings.Contains(script, "process") {
 		script = script + "\nfunction process(input) { return input; }"
 	}
-	
+
 	// Exécuter le script
 	_, err := jsRuntime.RunString(script)
 	if err != nil {
-		return "", fmt.Errorf("erreur dans le script: %v", err)
+		return "", scriptError("erreur dans le script", err, timeout)
 	}
-	
+
 	// Appeler la fonction process
 	processFunc, ok := goja.AssertFunction(jsRuntime.Get("process"))
 	if !ok {
 		return "", fmt.Errorf("fonction 'process' non trouvée dans le script")
 	}
-	
+
 	result, err := processFunc(goja.Undefined(), jsRuntime.ToValue(input))
 	if err != nil {
-		return "", fmt.Errorf("erreur lors de l'exécution: %v", err)
+		return "", scriptError("erreur lors de l'exécution", err, timeout)
 	}
-	
+
 	resultStr := result.String()
+	if maxSize := vm.effectiveMaxOutputSize(); len(resultStr) > maxSize {
+		return "", fmt.Errorf("%w: %d octets (maximum %d)", ErrOutputTooLarge, len(resultStr), maxSize)
+	}
 	vm.lastResult = resultStr
 	return resultStr, nil
 }
 
+// scriptError traduit une erreur goja en message explicite pour l'utilisateur
+func scriptError(prefix string, err error, timeout time.Duration) error {
+	if errors.Is(err, ErrScriptTimeout) {
+		return fmt.Errorf("%w: le script a été interrompu après %s", ErrScriptTimeout, timeout)
+	}
+	for _, ctxErr := range []error{context.Canceled, context.DeadlineExceeded} {
+		if errors.Is(err, ctxErr) {
+			return fmt.Errorf("script interrompu: %w", ctxErr)
+		}
+	}
+	var stackErr *goja.StackOverflowError
+	if errors.As(err, &stackErr) {
+		return fmt.Errorf("%s: pile d'appels dépassée (%d appels imbriqués, récursion infinie ?)", prefix, DefaultMaxCallStackSize)
+	}
+	return fmt.Errorf("%s: %v", prefix, err)
+}
+
 func (vm *CustomProcessorViewModel) GetConfiguration() interface{} {
-	return struct {
-		Name   string
-		Script string
-	}{
-		Name:   vm.name,
-		Script: vm.script,
+	return CustomProcessorOptions{
+		Name:          vm.name,
+		Script:        vm.script,
+		Timeout:       vm.timeout,
+		MaxOutputSize: vm.maxOutputSize,
 	}
 }
 
 func (vm *CustomProcessorViewModel) LoadConfiguration(config interface{}) error {
-	cfg, ok := config.(struct{ Name, Script string })
-	if !ok {
+	switch cfg := config.(type) {
+	case CustomProcessorOptions:
+		vm.name = cfg.Name
+		vm.script = cfg.Script
+		vm.timeout = cfg.Timeout
+		vm.maxOutputSize = cfg.MaxOutputSize
+	case struct{ Name, Script string }:
+		vm.name = cfg.Name
+		vm.script = cfg.Script
+	default:
 		return fmt.Errorf("configuration invalide")
 	}
-	vm.name = cfg.Name
-	vm.script = cfg.Script
 	return nil
 }
 
@@ -197,9 +290,80 @@ func (vm *CustomProcessorViewModel) Validate() error {
 	if vm.script == "" {
 		return fmt.Errorf("le script ne peut pas être vide")
 	}
+	if vm.timeout < 0 {
+		return fmt.Errorf("le délai d'exécution ne peut pas être négatif")
+	}
+	if vm.maxOutputSize < 0 {
+		return fmt.Errorf("la taille maximale du résultat ne peut pas être négative")
+	}
 	return nil
 }
 
 func (vm *CustomProcessorViewModel) GetLastResult() (string, error) {
 	return vm.lastResult, nil
 }
+
+// CustomProcessorTool type d'outil des processeurs personnalisés dans les pipelines sérialisés
+const CustomProcessorTool ToolType = "custom_processor"
+
+// Les processeurs personnalisés ne sont pas proposés dans la grille: ils sont listés à partir
+// des définitions chargées par l'application
+func init() {
+	Register(Registration{
+		Type:        CustomProcessorTool,
+		Name:        "Custom Processor",
+		Description: "Processeur personnalisé",
+		Hidden:      true,
+		New:         func() Processor { return NewCustomProcessor("", "") },
+		Codec: NewConfigCodec(CustomProcessorConfig.Options, func(o CustomProcessorOptions) CustomProcessorConfig {
+			return CustomProcessorConfig{
+				Name:          o.Name,
+				Script:        o.Script,
+				TimeoutMs:     int(o.Timeout / time.Millisecond),
+				MaxOutputSize: o.MaxOutputSize,
+			}
+		}),
+	})
+}
+
+// CustomProcessorConfig configuration pour le processeur personnalisé
+type CustomProcessorConfig struct {
+	Name          string `json:"name"`
+	Script        string `json:"script"`
+	TimeoutMs     int    `json:"timeout_ms,omitempty"`      // 0: délai par défaut
+	MaxOutputSize int    `json:"max_output_size,omitempty"` // En octets, 0: taille par défaut
+}
+
+func (c CustomProcessorConfig) GetType() ToolType {
+	return CustomProcessorTool
+}
+
+func (c CustomProcessorConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("le nom du processeur ne peut pas être vide")
+	}
+	if c.Script == "" {
+		return fmt.Errorf("le script ne peut pas être vide")
+	}
+	if c.TimeoutMs < 0 {
+		return fmt.Errorf("le délai d'exécution ne peut pas être négatif")
+	}
+	if c.MaxOutputSize < 0 {
+		return fmt.Errorf("la taille maximale du résultat ne peut pas être négative")
+	}
+	return nil
+}
+
+func (c CustomProcessorConfig) GetDisplayName() string {
+	return fmt.Sprintf("Custom Processor (%s)", c.Name)
+}
+
+// Options convertit la configuration vers les options du ViewModel
+func (c CustomProcessorConfig) Options() CustomProcessorOptions {
+	return CustomProcessorOptions{
+		Name:          c.Name,
+		Script:        c.Script,
+		Timeout:       time.Duration(c.TimeoutMs) * time.Millisecond,
+		MaxOutputSize: c.MaxOutputSize,
+	}
+}
@@ -0,0 +1,75 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCustomProcessorLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    CustomProcessorOptions
+		input   string
+		want    string
+		wantErr error // nil : succès attendu
+	}{
+		{"script simple", CustomProcessorOptions{Script: "return input.toUpperCase();"}, "abc", "ABC", nil},
+		{"fonction process", CustomProcessorOptions{Script: "function process(s) { return s + '!'; }"}, "abc", "abc!", nil},
+		{"boucle infinie interrompue", CustomProcessorOptions{Script: "while (true) {}", Timeout: 50 * time.Millisecond}, "", "", ErrScriptTimeout},
+		{"boucle infinie dans process", CustomProcessorOptions{Script: "function process(s) { for (;;) {} }", Timeout: 50 * time.Millisecond}, "", "", ErrScriptTimeout},
+		{"résultat trop volumineux", CustomProcessorOptions{Script: "return input + input;", MaxOutputSize: 5}, "abc", "", ErrOutputTooLarge},
+		{"résultat à la taille maximale", CustomProcessorOptions{Script: "return input + input;", MaxOutputSize: 6}, "abc", "abcabc", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Name = "test"
+			got, err := NewCustomProcessorWithOptions(tt.opts).ViewModel().Process(tt.input)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("erreur %v, attendu %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erreur inattendue: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("résultat %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomProcessorCancellation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	vm := NewCustomProcessorViewModel("test", "while (true) {}")
+	if _, err := vm.ProcessContext(ctx, ""); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("erreur %v, attendu %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestCustomProcessorConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CustomProcessorConfig
+		wantErr bool
+	}{
+		{"valide", CustomProcessorConfig{Name: "maj", Script: "input", TimeoutMs: 100, MaxOutputSize: 1024}, false},
+		{"limites par défaut", CustomProcessorConfig{Name: "maj", Script: "input"}, false},
+		{"nom vide", CustomProcessorConfig{Script: "input"}, true},
+		{"script vide", CustomProcessorConfig{Name: "maj"}, true},
+		{"délai négatif", CustomProcessorConfig{Name: "maj", Script: "input", TimeoutMs: -1}, true},
+		{"taille négative", CustomProcessorConfig{Name: "maj", Script: "input", MaxOutputSize: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, erreur attendue: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package processors
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// DualInputProcessor est implémenté par les ViewModel qui travaillent sur deux documents
+// (comparaison, application d'un patch...). Dans un pipeline, le premier document fait partie
+// de la configuration de l'étape et le second est l'entrée de l'étape: Process(input)
+// équivaut alors à ProcessPair(<document configuré>, input).
+type DualInputProcessor interface {
+	ProcessPair(first, second string) (string, error)
+}
+
+// newDualInputArea construit deux zones de saisie côte à côte. Le contenu de la première est
+// transmis à onFirstChanged pour être enregistré dans la configuration du ViewModel.
+func newDualInputArea(firstLabel, secondLabel, firstText string, onFirstChanged func(string)) (fyne.CanvasObject, *widget.Entry, *widget.Entry) {
+	first := widget.NewMultiLineEntry()
+	first.Wrapping = fyne.TextWrapWord
+	first.SetMinRowsVisible(8)
+	first.SetText(firstText)
+	first.OnChanged = onFirstChanged
+
+	second := widget.NewMultiLineEntry()
+	second.Wrapping = fyne.TextWrapWord
+	second.SetMinRowsVisible(8)
+
+	split := container.NewHSplit(
+		container.NewBorder(widget.NewLabel(firstLabel), nil, nil, nil, first),
+		container.NewBorder(widget.NewLabel(secondLabel), nil, nil, nil, second),
+	)
+	return split, first, second
+}
+
+// highlightPairError sélectionne l'erreur JSON dans la zone concernée: la première si son
+// contenu est invalide, la seconde sinon
+func highlightPairError(first, second *widget.Entry, err error) {
+	if firstErr := ValidateJSON(first.Text); firstErr != nil {
+		highlightJSONError(first, firstErr)
+		return
+	}
+	highlightJSONError(second, err)
+}
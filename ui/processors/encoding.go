@@ -0,0 +1,451 @@
+package processors
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"mime/quotedprintable"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Sens de la conversion
+const (
+	EncodingEncode = "Encoder"
+	EncodingDecode = "Décoder"
+)
+
+// EncodingDirections liste les valeurs acceptées pour EncodingOptions.Direction
+var EncodingDirections = []string{EncodingEncode, EncodingDecode}
+
+// Schémas d'encodage
+const (
+	EncodingBase64          = "Base64"
+	EncodingBase64URL       = "Base64 URL"
+	EncodingBase64Raw       = "Base64 sans remplissage"
+	EncodingBase64RawURL    = "Base64 URL sans remplissage"
+	EncodingURLQuery        = "URL (paramètre de requête)"
+	EncodingURLPath         = "URL (segment de chemin)"
+	EncodingHex             = "Hexadécimal"
+	EncodingHexdump         = "Hexdump"
+	EncodingHTML            = "Entités HTML"
+	EncodingQuotedPrintable = "Quoted-printable"
+	EncodingUnicodeEscapes  = `Échappements Unicode (\uXXXX)`
+)
+
+// EncodingSchemes liste les valeurs acceptées pour EncodingOptions.Scheme
+var EncodingSchemes = []string{
+	EncodingBase64, EncodingBase64URL, EncodingBase64Raw, EncodingBase64RawURL,
+	EncodingURLQuery, EncodingURLPath, EncodingHex, EncodingHexdump, EncodingHTML,
+	EncodingQuotedPrintable, EncodingUnicodeEscapes,
+}
+
+// base64Encodings variantes de Base64 par schéma
+var base64Encodings = map[string]*base64.Encoding{
+	EncodingBase64:       base64.StdEncoding,
+	EncodingBase64URL:    base64.URLEncoding,
+	EncodingBase64Raw:    base64.RawStdEncoding,
+	EncodingBase64RawURL: base64.RawURLEncoding,
+}
+
+// DecodeError erreur de décodage localisée dans le texte d'entrée
+type DecodeError struct {
+	Scheme  string
+	Offset  int    // Position de l'octet fautif dans l'entrée (à partir de 0)
+	Line    int    // Ligne de l'erreur (à partir de 1)
+	Column  int    // Colonne de l'erreur en caractères (à partir de 1)
+	Snippet string // Ligne fautive suivie d'un caret sous l'octet fautif
+	Err     error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("décodage %s, octet %d (ligne %d, colonne %d): %v",
+		e.Scheme, e.Offset, e.Line, e.Column, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+func (e *DecodeError) Pretty() string {
+	return fmt.Sprintf("Erreur de décodage %s à l'octet %d (ligne %d, colonne %d): %v\n\n%s",
+		e.Scheme, e.Offset, e.Line, e.Column, e.Err, e.Snippet)
+}
+
+// newDecodeError construit une DecodeError pour la position offset (en octets) de input
+func newDecodeError(scheme, input string, offset int, err error) *DecodeError {
+	offset = max(0, min(offset, len(input)))
+	lineStart := strings.LastIndexByte(input[:offset], '\n') + 1
+	lineEnd := strings.IndexByte(input[offset:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(input)
+	} else {
+		lineEnd += offset
+	}
+	lineText := strings.TrimSuffix(input[lineStart:lineEnd], "\r")
+	prefix := input[lineStart:offset]
+	return &DecodeError{
+		Scheme:  scheme,
+		Offset:  offset,
+		Line:    strings.Count(input[:offset], "\n") + 1,
+		Column:  utf8.RuneCountInString(prefix) + 1,
+		Snippet: snippetWithCaret(lineText, prefix),
+		Err:     err,
+	}
+}
+
+// encodeText encode input avec le schéma. escapeNonASCII concerne les entités HTML: les
+// caractères non ASCII sont aussi remplacés par des références numériques.
+func encodeText(input, scheme string, escapeNonASCII bool) (string, error) {
+	if enc, ok := base64Encodings[scheme]; ok {
+		return enc.EncodeToString([]byte(input)), nil
+	}
+
+	switch scheme {
+	case EncodingURLQuery:
+		return url.QueryEscape(input), nil
+	case EncodingURLPath:
+		return url.PathEscape(input), nil
+	case EncodingHex:
+		return hex.EncodeToString([]byte(input)), nil
+	case EncodingHexdump:
+		return hex.Dump([]byte(input)), nil
+	case EncodingHTML:
+		escaped := html.EscapeString(input)
+		if !escapeNonASCII {
+			return escaped, nil
+		}
+		var out strings.Builder
+		for _, r := range escaped {
+			if r < utf8.RuneSelf {
+				out.WriteRune(r)
+			} else {
+				fmt.Fprintf(&out, "&#x%X;", r)
+			}
+		}
+		return out.String(), nil
+	case EncodingQuotedPrintable:
+		var out strings.Builder
+		w := quotedprintable.NewWriter(&out)
+		if _, err := w.Write([]byte(input)); err != nil {
+			return "", err
+		}
+		if err := w.Close(); err != nil {
+			return "", err
+		}
+		// Le writer produit des fins de ligne CRLF (RFC 2045): on garde celles de l'entrée
+		if !strings.Contains(input, "\r\n") {
+			return strings.ReplaceAll(out.String(), "\r\n", "\n"), nil
+		}
+		return out.String(), nil
+	case EncodingUnicodeEscapes:
+		return encodeUnicodeEscapes(input), nil
+	}
+	return "", fmt.Errorf("schéma d'encodage inconnu: %s", scheme)
+}
+
+// decodeText décode input avec le schéma. Les erreurs sont des *DecodeError indiquant l'octet
+// fautif. Le résultat peut contenir des octets qui ne forment pas du texte UTF-8 valide (voir
+// checkDecodedText).
+func decodeText(input, scheme string) (string, error) {
+	if enc, ok := base64Encodings[scheme]; ok {
+		// Les espaces autour du texte et les retours à la ligne sont ignorés
+		trimmed := strings.TrimLeft(input, " \t\r\n")
+		leading := len(input) - len(trimmed)
+		trimmed = strings.TrimRight(trimmed, " \t\r\n")
+		decoded, err := enc.DecodeString(trimmed)
+		var corrupt base64.CorruptInputError
+		if errors.As(err, &corrupt) {
+			return "", newDecodeError(scheme, input, leading+int(corrupt), errors.New("caractère ou remplissage Base64 invalide"))
+		}
+		return string(decoded), err
+	}
+
+	switch scheme {
+	case EncodingURLQuery, EncodingURLPath:
+		return decodePercent(input, scheme)
+	case EncodingHex:
+		return decodeHex(input)
+	case EncodingHexdump:
+		return decodeHexdump(input)
+	case EncodingHTML:
+		return html.UnescapeString(input), nil
+	case EncodingQuotedPrintable:
+		return decodeQuotedPrintable(input)
+	case EncodingUnicodeEscapes:
+		return decodeUnicodeEscapes(input)
+	}
+	return "", fmt.Errorf("schéma d'encodage inconnu: %s", scheme)
+}
+
+// checkDecodedText vérifie qu'un résultat décodé est du texte UTF-8: les étapes suivantes et
+// la zone de résultat remplaceraient les autres octets. Avec asHexdump, un résultat binaire est
+// affiché en hexdump au lieu d'être refusé.
+func checkDecodedText(decoded string, asHexdump bool) (string, error) {
+	for i := 0; i < len(decoded); {
+		r, size := utf8.DecodeRuneInString(decoded[i:])
+		if r == utf8.RuneError && size == 1 {
+			if asHexdump {
+				return hex.Dump([]byte(decoded)), nil
+			}
+			return "", fmt.Errorf("le résultat décodé n'est pas du texte UTF-8 (octet 0x%02X à la position %d du résultat): "+
+				"activez l'affichage des résultats binaires en hexdump", decoded[i], i)
+		}
+		i += size
+	}
+	return decoded, nil
+}
+
+// decodePercent décode les séquences %XX; en paramètre de requête, + représente une espace
+func decodePercent(input, scheme string) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(input); i++ {
+		switch c := input[i]; {
+		case c == '%':
+			if i+2 >= len(input) || !isHexByte(input[i+1]) || !isHexByte(input[i+2]) {
+				return "", newDecodeError(scheme, input, i, fmt.Errorf("séquence %% invalide %q: deux chiffres hexadécimaux attendus", input[i:min(i+3, len(input))]))
+			}
+			out.WriteByte(unhexByte(input[i+1])<<4 | unhexByte(input[i+2]))
+			i += 2
+		case c == '+' && scheme == EncodingURLQuery:
+			out.WriteByte(' ')
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String(), nil
+}
+
+// decodeHex décode une suite de chiffres hexadécimaux; les espaces et retours à la ligne
+// entre les octets sont ignorés
+func decodeHex(input string) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		if c == ' ' || c == '\t' || c == '\r' || c == '\n' {
+			continue
+		}
+		if !isHexByte(c) {
+			return "", newDecodeError(EncodingHex, input, i, fmt.Errorf("caractère hexadécimal invalide %q", decodeRuneAt(input, i)))
+		}
+		if i+1 == len(input) || input[i+1] == ' ' || input[i+1] == '\t' || input[i+1] == '\r' || input[i+1] == '\n' {
+			return "", newDecodeError(EncodingHex, input, i, errors.New("octet incomplet: les chiffres hexadécimaux vont par deux"))
+		}
+		if !isHexByte(input[i+1]) {
+			return "", newDecodeError(EncodingHex, input, i+1, fmt.Errorf("caractère hexadécimal invalide %q", decodeRuneAt(input, i+1)))
+		}
+		out.WriteByte(unhexByte(c)<<4 | unhexByte(input[i+1]))
+		i++
+	}
+	return out.String(), nil
+}
+
+// decodeHexdump relit la sortie de l'encodage Hexdump (format hexdump -C): sur chaque ligne,
+// la position puis les octets en hexadécimal, la colonne |texte| étant ignorée
+func decodeHexdump(input string) (string, error) {
+	var out strings.Builder
+	lineStart := 0
+	for _, line := range strings.SplitAfter(input, "\n") {
+		content := line
+		if end := strings.Index(content, "  |"); end >= 0 {
+			content = content[:end]
+		}
+
+		first := true
+		for i := 0; i < len(content); {
+			if c := content[i]; c == ' ' || c == '\t' || c == '\r' || c == '\n' {
+				i++
+				continue
+			}
+			end := i
+			for end < len(content) && !strings.ContainsRune(" \t\r\n", rune(content[end])) {
+				end++
+			}
+			field := content[i:end]
+			if first {
+				first = false // Position en début de ligne
+			} else {
+				if len(field) != 2 || !isHexByte(field[0]) || !isHexByte(field[1]) {
+					return "", newDecodeError(EncodingHexdump, input, lineStart+i, fmt.Errorf("octet hexadécimal invalide %q", field))
+				}
+				out.WriteByte(unhexByte(field[0])<<4 | unhexByte(field[1]))
+			}
+			i = end
+		}
+		lineStart += len(line)
+	}
+	return out.String(), nil
+}
+
+// decodeQuotedPrintable décode le quoted-printable (RFC 2045): =XX, sauts de ligne souples
+// (= en fin de ligne) et espaces de fin de ligne ignorées
+func decodeQuotedPrintable(input string) (string, error) {
+	var out strings.Builder
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case c == '=':
+			rest := strings.TrimLeft(input[i+1:], " \t")
+			if rest == "" || rest[0] == '\n' || strings.HasPrefix(rest, "\r\n") {
+				// Saut de ligne souple
+				i = len(input) - len(rest)
+				if strings.HasPrefix(rest, "\r\n") {
+					i++
+				}
+				continue
+			}
+			if i+2 >= len(input) || !isHexByte(input[i+1]) || !isHexByte(input[i+2]) {
+				return "", newDecodeError(EncodingQuotedPrintable, input, i, fmt.Errorf("séquence = invalide %q: deux chiffres hexadécimaux attendus", input[i:min(i+3, len(input))]))
+			}
+			out.WriteByte(unhexByte(input[i+1])<<4 | unhexByte(input[i+2]))
+			i += 2
+		case c == ' ' || c == '\t':
+			// Les espaces de fin de ligne ont été ajoutés par le transport
+			rest := strings.TrimLeft(input[i:], " \t")
+			if rest != "" && rest[0] != '\n' && !strings.HasPrefix(rest, "\r\n") {
+				out.WriteByte(c)
+			}
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.String(), nil
+}
+
+// encodeUnicodeEscapes remplace les caractères non ASCII par \uXXXX (paires de substitution
+// UTF-16 au-delà de U+FFFF, comme en JSON et JavaScript) et double les barres obliques
+// inverses
+func encodeUnicodeEscapes(input string) string {
+	var out strings.Builder
+	for _, r := range input {
+		switch {
+		case r == '\\':
+			out.WriteString(`\\`)
+		case r < utf8.RuneSelf:
+			out.WriteRune(r)
+		case r > 0xFFFF:
+			high, low := utf16.EncodeRune(r)
+			fmt.Fprintf(&out, `\u%04x\u%04x`, high, low)
+		default:
+			fmt.Fprintf(&out, `\u%04x`, r)
+		}
+	}
+	return out.String()
+}
+
+// decodeUnicodeEscapes décode \uXXXX (avec les paires de substitution), \u{X...} et
+// \UXXXXXXXX, ainsi que \\. Les autres séquences \ sont laissées telles quelles.
+func decodeUnicodeEscapes(input string) (string, error) {
+	fail := func(offset int, format string, args ...any) (string, error) {
+		return "", newDecodeError(EncodingUnicodeEscapes, input, offset, fmt.Errorf(format, args...))
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(input); i++ {
+		if input[i] != '\\' || i+1 == len(input) {
+			out.WriteByte(input[i])
+			continue
+		}
+
+		start := i
+		var r rune
+		switch input[i+1] {
+		case '\\':
+			out.WriteByte('\\')
+			i++
+			continue
+		case 'u':
+			if i+2 < len(input) && input[i+2] == '{' {
+				end := strings.IndexByte(input[i:], '}')
+				if end < 0 {
+					return fail(start, `accolade non fermée après \u{`)
+				}
+				digits := input[i+3 : i+end]
+				n, err := strconv.ParseUint(digits, 16, 32)
+				if err != nil || digits == "" || n > unicode.MaxRune {
+					return fail(start, "point de code invalide %q", input[i:i+end+1])
+				}
+				r = rune(n)
+				i += end
+				break
+			}
+			n, ok := parseHexDigits(input, i+2, 4)
+			if !ok {
+				return fail(start, `séquence \u invalide %q: quatre chiffres hexadécimaux attendus`, input[i:min(i+6, len(input))])
+			}
+			r = rune(n)
+			i += 5
+			if utf16.IsSurrogate(r) {
+				if r >= 0xDC00 {
+					return fail(start, "demi-paire de substitution basse isolée %q", input[start:i+1])
+				}
+				low, ok := uint32(0), false
+				if strings.HasPrefix(input[i+1:], `\u`) {
+					low, ok = parseHexDigits(input, i+3, 4)
+				}
+				if !ok || low < 0xDC00 || low > 0xDFFF {
+					return fail(start, "demi-paire de substitution haute %q sans demi-paire basse", input[start:i+1])
+				}
+				r = utf16.DecodeRune(r, rune(low))
+				i += 6
+			}
+		case 'U':
+			n, ok := parseHexDigits(input, i+2, 8)
+			if !ok || n > unicode.MaxRune {
+				return fail(start, `séquence \U invalide %q: huit chiffres hexadécimaux attendus`, input[i:min(i+10, len(input))])
+			}
+			r = rune(n)
+			i += 9
+		default:
+			out.WriteByte('\\')
+			continue
+		}
+
+		if utf16.IsSurrogate(r) {
+			return fail(start, "point de code réservé aux paires de substitution U+%04X", r)
+		}
+		out.WriteRune(r)
+	}
+	return out.String(), nil
+}
+
+// parseHexDigits lit exactement n chiffres hexadécimaux à partir de la position start
+func parseHexDigits(s string, start, n int) (uint32, bool) {
+	if start+n > len(s) {
+		return 0, false
+	}
+	var value uint32
+	for _, c := range []byte(s[start : start+n]) {
+		if !isHexByte(c) {
+			return 0, false
+		}
+		value = value<<4 | uint32(unhexByte(c))
+	}
+	return value, true
+}
+
+func isHexByte(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func unhexByte(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	}
+	return c - 'A' + 10
+}
+
+// decodeRuneAt retourne le caractère qui commence à la position i de s
+func decodeRuneAt(s string, i int) string {
+	_, size := utf8.DecodeRuneInString(s[i:])
+	return s[i : i+size]
+}
@@ -0,0 +1,189 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncodeText(t *testing.T) {
+	tests := []struct {
+		scheme         string
+		escapeNonASCII bool
+		input          string
+		want           string
+	}{
+		{EncodingBase64, false, "é", "w6k="},
+		{EncodingBase64Raw, false, "é", "w6k"},
+		{EncodingBase64URL, false, "ÿ?", "w78_"},
+		{EncodingURLQuery, false, "a b&é", "a+b%26%C3%A9"},
+		{EncodingURLPath, false, "a b/é", "a%20b%2F%C3%A9"},
+		{EncodingHex, false, "éA", "c3a941"},
+		{EncodingHexdump, false, "A", "00000000  41                                                |A|\n"},
+		{EncodingHTML, false, "<a & 'é'>", "&lt;a &amp; &#39;é&#39;&gt;"},
+		{EncodingHTML, true, "<a & 'é'>", "&lt;a &amp; &#39;&#xE9;&#39;&gt;"},
+		{EncodingQuotedPrintable, false, "é=\nsuite", "=C3=A9=3D\nsuite"},
+		{EncodingQuotedPrintable, false, "é\r\n", "=C3=A9\r\n"},
+		{EncodingUnicodeEscapes, false, `é😀\`, `\u00e9\ud83d\ude00\\`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scheme+"/"+tt.input, func(t *testing.T) {
+			got, err := encodeText(tt.input, tt.scheme, tt.escapeNonASCII)
+			if err != nil {
+				t.Fatalf("encodeText: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("encodeText = %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeText(t *testing.T) {
+	tests := []struct {
+		scheme string
+		input  string
+		want   string
+	}{
+		{EncodingBase64, "  w6k=\n", "é"},
+		{EncodingBase64, "w6\r\nk=", "é"},
+		{EncodingBase64RawURL, "w78_", "ÿ?"},
+		{EncodingURLQuery, "a+b%26%C3%A9", "a b&é"},
+		{EncodingURLPath, "a+b%20c", "a+b c"},
+		{EncodingHex, "c3 a9\n41", "éA"},
+		{EncodingHTML, "&lt;&eacute;&#x1F600;&amp;amp;", "<é😀&amp;"},
+		{EncodingQuotedPrintable, "caf=C3=A9 =\nsuite  \nfin", "café suite\nfin"},
+		{EncodingQuotedPrintable, "a=\r\nb", "ab"},
+		{EncodingUnicodeEscapes, `\u00e9\ud83d\ude00\u{1F600}\U0001F600\\u\n\x`, `é😀😀😀\u\n\x`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scheme+"/"+tt.input, func(t *testing.T) {
+			got, err := decodeText(tt.input, tt.scheme)
+			if err != nil {
+				t.Fatalf("decodeText: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("decodeText = %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeTextErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme string
+		input  string
+		offset int
+		line   int
+		column int
+	}{
+		{"Base64 après des espaces", EncodingBase64, "\n  w6!k", 5, 2, 5},
+		{"séquence % tronquée", EncodingURLQuery, "ab%4", 2, 1, 3},
+		{"séquence % non hexadécimale", EncodingURLPath, "é%zz", 2, 1, 2},
+		{"octet hexadécimal incomplet", EncodingHex, "c3a", 2, 1, 3},
+		{"chiffre hexadécimal invalide", EncodingHex, "c3 ag", 4, 1, 5},
+		{"caractère multi-octets", EncodingHex, "41\né", 3, 2, 1},
+		{"octet de hexdump invalide", EncodingHexdump, "00000000  41 4g  |A.|\n", 13, 1, 14},
+		{"séquence = invalide", EncodingQuotedPrintable, "a\nb=G1", 3, 2, 2},
+		{`\u tronqué`, EncodingUnicodeEscapes, `ab\u12`, 2, 1, 3},
+		{"demi-paire basse isolée", EncodingUnicodeEscapes, `\udc00`, 0, 1, 1},
+		{"demi-paire haute sans demi-paire basse", EncodingUnicodeEscapes, `x\ud83dy`, 1, 1, 2},
+		{"point de code hors limites", EncodingUnicodeEscapes, `\u{110000}`, 0, 1, 1},
+		{"accolade non fermée", EncodingUnicodeEscapes, `é\u{12`, 2, 1, 2},
+		{`\U réservé aux paires de substitution`, EncodingUnicodeEscapes, `\U0000D800`, 0, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeText(tt.input, tt.scheme)
+			var decodeErr *DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("erreur %v (%T), attendu une *DecodeError", err, err)
+			}
+			if decodeErr.Offset != tt.offset || decodeErr.Line != tt.line || decodeErr.Column != tt.column {
+				t.Errorf("octet %d, ligne %d, colonne %d, attendu octet %d, ligne %d, colonne %d (%v)",
+					decodeErr.Offset, decodeErr.Line, decodeErr.Column, tt.offset, tt.line, tt.column, err)
+			}
+		})
+	}
+}
+
+// Chaque schéma relit ce qu'il a encodé
+func TestEncodingRoundTrip(t *testing.T) {
+	inputs := []string{
+		"",
+		"Héllo wörld 😀 a+b=c/d?e&f \\u00e9 <p>\n\tligne 2  \n",
+		"fin de ligne Windows\r\n=\r\n",
+	}
+	for _, scheme := range EncodingSchemes {
+		for _, input := range inputs {
+			t.Run(scheme, func(t *testing.T) {
+				encoder := NewEncodingViewModel()
+				if err := encoder.LoadConfiguration(EncodingOptions{Scheme: scheme, EscapeNonASCII: true}); err != nil {
+					t.Fatal(err)
+				}
+				encoded, err := encoder.Process(input)
+				if err != nil {
+					t.Fatalf("encodage: %v", err)
+				}
+				decoder := NewEncodingViewModel()
+				if err := decoder.LoadConfiguration(EncodingOptions{Direction: EncodingDecode, Scheme: scheme}); err != nil {
+					t.Fatal(err)
+				}
+				decoded, err := decoder.Process(encoded)
+				if err != nil {
+					t.Fatalf("décodage de %q: %v", encoded, err)
+				}
+				if decoded != input {
+					t.Errorf("aller-retour %q, attendu %q (encodé %q)", decoded, input, encoded)
+				}
+			})
+		}
+	}
+}
+
+func TestEncodingViewModelErrors(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		options EncodingOptions
+		input   string
+		wantErr string // Extrait attendu du message d'erreur
+	}{
+		{"sens inconnu", context.Background(), EncodingOptions{Direction: "Chiffrer"}, "a", "sens de conversion invalide"},
+		{"schéma inconnu", context.Background(), EncodingOptions{Scheme: "ROT13"}, "a", "schéma d'encodage invalide"},
+		{"résultat binaire", context.Background(), EncodingOptions{Direction: EncodingDecode}, "Qf8=", "octet 0xFF à la position 1"},
+		{"décodage annulé", cancelled, EncodingOptions{Direction: EncodingDecode}, "QQ==", context.Canceled.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewEncodingViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			_, err := vm.ProcessContext(tt.ctx, tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("erreur %v, attendu un message contenant %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Un résultat binaire peut être affiché en hexdump au lieu d'être refusé
+func TestEncodingBinaryAsHexdump(t *testing.T) {
+	vm := NewEncodingViewModel()
+	if err := vm.LoadConfiguration(EncodingOptions{Direction: EncodingDecode, BinaryAsHexdump: true}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := vm.Process("Qf8=")
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if want := "00000000  41 ff "; !strings.HasPrefix(got, want) || !strings.HasSuffix(got, "|A.|\n") {
+		t.Errorf("Process = %q, attendu un hexdump commençant par %q", got, want)
+	}
+}
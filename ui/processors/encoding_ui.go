@@ -0,0 +1,308 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// EncodingUI implémente Processor pour l'encodage et le décodage de texte
+type EncodingUI struct {
+	viewModel *EncodingViewModel
+}
+
+func NewEncodingUI() Processor {
+	return &EncodingUI{
+		viewModel: NewEncodingViewModel(),
+	}
+}
+
+func (ui *EncodingUI) Name() string {
+	return "Encodage / décodage"
+}
+
+func (ui *EncodingUI) Description() string {
+	return "Base64, URL, hexadécimal, entités HTML, quoted-printable et échappements Unicode"
+}
+
+func (ui *EncodingUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *EncodingUI) CreateConfigurationUI() fyne.CanvasObject {
+	vm := ui.viewModel
+
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("Entrez votre texte ici...")
+	input.Wrapping = fyne.TextWrapBreak
+	input.SetMinRowsVisible(8)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapBreak
+	output.Disable()
+
+	status := widget.NewLabel("")
+
+	directionRadio := widget.NewRadioGroup(EncodingDirections, nil)
+	directionRadio.Horizontal = true
+	directionRadio.SetSelected(vm.direction)
+
+	schemeSelect := widget.NewSelect(EncodingSchemes, nil)
+	schemeSelect.SetSelected(vm.scheme)
+
+	nonASCIICheck := widget.NewCheck("Encoder aussi les caractères non ASCII (&#xE9;)", nil)
+	nonASCIICheck.SetChecked(vm.escapeNonASCII)
+
+	hexdumpCheck := widget.NewCheck("Afficher un résultat binaire en hexdump", nil)
+	hexdumpCheck.SetChecked(vm.binaryAsHexdump)
+
+	processBtn := widget.NewButton("Convertir", func() {
+		result, err := vm.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			status.SetText("")
+			return
+		}
+		output.SetText(result)
+		status.SetText(fmt.Sprintf("%d octet(s)", len(result)))
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := vm.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	rerun := func() {
+		if input.Text != "" {
+			processBtn.OnTapped()
+		}
+	}
+
+	// L'option non ASCII ne concerne que l'encodage en entités HTML, l'hexdump que le décodage
+	updateScheme := func() {
+		if vm.direction == EncodingEncode && vm.scheme == EncodingHTML {
+			nonASCIICheck.Enable()
+		} else {
+			nonASCIICheck.Disable()
+		}
+		if vm.direction == EncodingDecode {
+			hexdumpCheck.Enable()
+		} else {
+			hexdumpCheck.Disable()
+		}
+	}
+	updateScheme()
+
+	// Inverser: le résultat devient l'entrée et le sens de conversion change
+	swapBtn := widget.NewButton("Inverser", func() {
+		result, _ := vm.GetLastResult()
+		if vm.direction == EncodingEncode {
+			directionRadio.SetSelected(EncodingDecode)
+		} else {
+			directionRadio.SetSelected(EncodingEncode)
+		}
+		input.SetText(result)
+	})
+
+	input.OnChanged = func(string) {
+		rerun()
+	}
+	directionRadio.OnChanged = func(s string) {
+		vm.direction = s
+		updateScheme()
+		rerun()
+	}
+	schemeSelect.OnChanged = func(s string) {
+		vm.scheme = s
+		updateScheme()
+		rerun()
+	}
+	nonASCIICheck.OnChanged = func(checked bool) {
+		vm.escapeNonASCII = checked
+		rerun()
+	}
+	hexdumpCheck.OnChanged = func(checked bool) {
+		vm.binaryAsHexdump = checked
+		rerun()
+	}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée Texte:"),
+		input,
+		container.NewHBox(
+			directionRadio,
+			widget.NewLabel("Schéma:"),
+			schemeSelect,
+		),
+		nonASCIICheck,
+		hexdumpCheck,
+		container.NewHBox(
+			processBtn,
+			swapBtn,
+			copyBtn,
+			status,
+		),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// EncodingOptions configuration du ViewModel d'encodage
+type EncodingOptions struct {
+	Direction       string // "Encoder" ou "Décoder"
+	Scheme          string // Une des valeurs de EncodingSchemes
+	EscapeNonASCII  bool   // Entités HTML: références numériques pour les caractères non ASCII
+	BinaryAsHexdump bool   // Décodage: hexdump d'un résultat qui n'est pas du texte UTF-8 (erreur sinon)
+}
+
+// EncodingViewModel implémente ViewModel pour l'encodage et le décodage de texte
+type EncodingViewModel struct {
+	direction       string
+	scheme          string
+	escapeNonASCII  bool
+	binaryAsHexdump bool
+	lastResult      string
+}
+
+func NewEncodingViewModel() *EncodingViewModel {
+	return &EncodingViewModel{
+		direction: EncodingEncode,
+		scheme:    EncodingBase64,
+	}
+}
+
+func (vm *EncodingViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext encode ou décode le texte. Chaque schéma est une simple passe linéaire sur
+// l'entrée: l'annulation du contexte est vérifiée entre le décodage et le contrôle UTF-8 du
+// résultat, pas à l'intérieur d'une passe.
+func (vm *EncodingViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	if err := vm.Validate(); err != nil {
+		return "", err
+	}
+
+	var result string
+	var err error
+	if vm.direction == EncodingDecode {
+		result, err = decodeText(input, vm.scheme)
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err == nil {
+			result, err = checkDecodedText(result, vm.binaryAsHexdump)
+		}
+	} else {
+		result, err = encodeText(input, vm.scheme, vm.escapeNonASCII)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	vm.lastResult = result
+	return result, nil
+}
+
+func (vm *EncodingViewModel) GetConfiguration() interface{} {
+	return EncodingOptions{
+		Direction:       vm.direction,
+		Scheme:          vm.scheme,
+		EscapeNonASCII:  vm.escapeNonASCII,
+		BinaryAsHexdump: vm.binaryAsHexdump,
+	}
+}
+
+func (vm *EncodingViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(EncodingOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.direction = cfg.Direction
+	if vm.direction == "" {
+		vm.direction = EncodingEncode
+	}
+	vm.scheme = cfg.Scheme
+	if vm.scheme == "" {
+		vm.scheme = EncodingBase64
+	}
+	vm.escapeNonASCII = cfg.EscapeNonASCII
+	vm.binaryAsHexdump = cfg.BinaryAsHexdump
+	return nil
+}
+
+func (vm *EncodingViewModel) Validate() error {
+	return vm.GetConfiguration().(EncodingOptions).Validate()
+}
+
+// Validate vérifie le sens et le schéma (valeurs vides acceptées)
+func (o EncodingOptions) Validate() error {
+	if o.Direction != "" && !slices.Contains(EncodingDirections, o.Direction) {
+		return fmt.Errorf("sens de conversion invalide: %s", o.Direction)
+	}
+	if o.Scheme != "" && !slices.Contains(EncodingSchemes, o.Scheme) {
+		return fmt.Errorf("schéma d'encodage invalide: %s", o.Scheme)
+	}
+	return nil
+}
+
+func (vm *EncodingViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// EncodingTool identifiant de l'outil "Encodage / décodage" dans les pipelines sérialisés
+const EncodingTool ToolType = "encoding"
+
+func init() {
+	Register(Registration{
+		Type:        EncodingTool,
+		Name:        "Encodage / décodage",
+		Description: "Base64, URL, hexadécimal, entités HTML, quoted-printable et échappements Unicode",
+		New:         NewEncodingUI,
+		Codec:       NewConfigCodec(EncodingConfig.Options, func(o EncodingOptions) EncodingConfig { return EncodingConfig(o) }),
+	})
+}
+
+// EncodingConfig configuration pour l'encodage et le décodage de texte
+type EncodingConfig struct {
+	Direction       string `json:",omitempty"` // "Encoder" (défaut) ou "Décoder"
+	Scheme          string `json:",omitempty"` // "Base64" par défaut
+	EscapeNonASCII  bool   `json:",omitempty"`
+	BinaryAsHexdump bool   `json:",omitempty"`
+}
+
+func (c EncodingConfig) GetType() ToolType {
+	return EncodingTool
+}
+
+func (c EncodingConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c EncodingConfig) GetDisplayName() string {
+	direction, scheme := c.Direction, c.Scheme
+	if direction == "" {
+		direction = EncodingEncode
+	}
+	if scheme == "" {
+		scheme = EncodingBase64
+	}
+	return fmt.Sprintf("%s (%s)", direction, scheme)
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c EncodingConfig) Options() EncodingOptions {
+	return EncodingOptions(c)
+}
@@ -2,18 +2,47 @@ package processors
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
 	"strings"
 )
 
+// Modes de sortie du formateur en plus des indentations
+const (
+	IndentMinified  = "Minifié"              // Une seule ligne, sans espaces
+	IndentCanonical = "Canonique (RFC 8785)" // JSON canonique (JCS) pour le hachage et la comparaison
+)
+
+// JSONIndentTypes liste les valeurs acceptées pour IndentType
+var JSONIndentTypes = []string{"2 espaces", "4 espaces", "Tabulations", IndentMinified, IndentCanonical}
+
+// JSONOutputIndentTypes indentations proposées par les outils qui produisent du JSON (requête, patch...)
+var JSONOutputIndentTypes = []string{"2 espaces", "4 espaces", "Tabulations", IndentMinified}
+
+// validateOutputIndent vérifie une indentation de JSONOutputIndentTypes (vide: valeur par défaut)
+func validateOutputIndent(indentType string) error {
+	if indentType == "" || slices.Contains(JSONOutputIndentTypes, indentType) {
+		return nil
+	}
+	return fmt.Errorf("type d'indentation invalide: %s", indentType)
+}
+
 type Formatter struct {
-	IndentType string
-	IndentSize int
+	IndentType     string
+	IndentSize     int
+	SortKeys       bool // Trie récursivement les clés des objets
+	Lenient        bool // JSONC/JSON5: commentaires, virgules finales, guillemets simples, clés sans guillemets
+	RejectComments bool // Mode permissif: signaler les commentaires au lieu de les supprimer
+	EscapeHTML     bool // Échappe <, > et & en <, >, & dans les chaînes
 }
 
 func NewFormatter(indentType string) *Formatter {
 	f := &Formatter{
 		IndentType: indentType,
+		EscapeHTML: true,
 	}
 
 	switch indentType {
@@ -21,6 +50,8 @@ func NewFormatter(indentType string) *Formatter {
 		f.IndentSize = 2
 	case "4 espaces":
 		f.IndentSize = 4
+	case IndentMinified, IndentCanonical:
+		f.IndentSize = 0
 	default: // Tabulations
 		f.IndentSize = 1
 	}
@@ -28,32 +59,196 @@ func NewFormatter(indentType string) *Formatter {
 	return f
 }
 
+// indent retourne la chaîne utilisée pour un niveau d'indentation (vide en mode minifié)
+func (f *Formatter) indent() string {
+	if f.IndentType == "Tabulations" {
+		return "\t"
+	}
+	return strings.Repeat(" ", f.IndentSize)
+}
+
+// FormatJSON ré-indente le document jeton par jeton: l'ordre des clés, les nombres et
+// les chaînes sont conservés tels qu'écrits dans l'entrée
 func (f *Formatter) FormatJSON(input string) (string, error) {
+	return f.FormatJSONContext(context.Background(), input)
+}
+
+// FormatJSONContext formate comme FormatJSON et s'interrompt si le contexte est annulé
+func (f *Formatter) FormatJSONContext(ctx context.Context, input string) (string, error) {
+	if !f.Lenient {
+		return f.formatStrict(ctx, input)
+	}
+
+	// Mode permissif: conversion en JSON strict, les erreurs sont relocalisées dans l'entrée d'origine
+	normalized, origin, err := normalizeLenientJSON(input, f.RejectComments)
+	if err != nil {
+		return "", err
+	}
+	formatted, err := f.formatStrict(ctx, normalized)
+	if err != nil {
+		return "", relocateJSONError(input, normalized, origin, err)
+	}
+	return formatted, nil
+}
+
+func (f *Formatter) formatStrict(ctx context.Context, input string) (string, error) {
 	// Valider d'abord le JSON
 	if err := ValidateJSON(input); err != nil {
 		return "", err
 	}
-
-	// Parser le JSON
-	var data interface{}
-	if err := json.Unmarshal([]byte(input), &data); err != nil {
+	if err := ctx.Err(); err != nil {
 		return "", err
 	}
 
-	// Formater avec l'indentation spécifiée
-	var indent string
-	if f.IndentType == "Tabulations" {
-		indent = "\t"
-	} else {
-		indent = strings.Repeat(" ", f.IndentSize)
+	if f.IndentType == IndentCanonical {
+		return canonicalizeJSON(input)
 	}
 
-	var formatted bytes.Buffer
-	encoder := json.NewEncoder(&formatted)
-	encoder.SetIndent("", indent)
-	if err := encoder.Encode(data); err != nil {
-		return "", err
+	var formatted strings.Builder
+	if f.SortKeys {
+		// Le tri nécessite le document complet en mémoire
+		tree, err := parseJSONTree(input)
+		if err != nil {
+			return "", err
+		}
+		tree.SortKeys(func(a, b string) bool { return a < b })
+		tree.Write(&formatted, f.indent(), f.EscapeHTML)
+	} else if err := reindentJSON(ctx, &formatted, input, f.indent(), f.EscapeHTML); err != nil {
+		return "", LocateJSONError(input, err)
 	}
+	formatted.WriteString("\n")
 
 	return formatted.String(), nil
 }
+
+// canonicalizeJSON produit la forme canonique RFC 8785 du document (sans retour à la ligne final,
+// pour que le résultat puisse être haché tel quel)
+func canonicalizeJSON(input string) (string, error) {
+	tree, err := parseJSONTree(input)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tree.WriteCanonical(&buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// jsonContainer état d'un objet ou tableau en cours d'écriture
+type jsonContainer struct {
+	delim     json.Delim
+	count     int  // Nombre d'éléments (ou de paires clé/valeur) déjà écrits
+	expectKey bool // Objet: le prochain jeton chaîne est une clé
+}
+
+// reindentJSON réécrit le JSON en recopiant chaque littéral (chaîne, nombre) tel qu'il apparaît
+// dans l'entrée et en ne modifiant que les espaces. Un indent vide produit une sortie minifiée.
+func reindentJSON(ctx context.Context, w io.Writer, input string, indent string, escapeHTML bool) error {
+	dec := json.NewDecoder(strings.NewReader(input))
+	dec.UseNumber()
+
+	var buf bytes.Buffer
+	var stack []*jsonContainer
+
+	newline := func() {
+		if indent == "" {
+			return
+		}
+		buf.WriteByte('\n')
+		for range stack {
+			buf.WriteString(indent)
+		}
+	}
+
+	// Séparateur avant un élément de tableau (les valeurs d'objet sont précédées de leur clé)
+	beforeValue := func() {
+		if len(stack) == 0 {
+			return
+		}
+		top := stack[len(stack)-1]
+		if top.delim == '[' {
+			if top.count > 0 {
+				buf.WriteByte(',')
+			}
+			newline()
+		}
+	}
+
+	afterValue := func() {
+		if len(stack) == 0 {
+			return
+		}
+		top := stack[len(stack)-1]
+		top.count++
+		if top.delim == '{' {
+			top.expectKey = true
+		}
+	}
+
+	for {
+		if cancelled(ctx) {
+			return ctx.Err()
+		}
+		start := dec.InputOffset()
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case json.Delim:
+			switch t {
+			case '{', '[':
+				beforeValue()
+				buf.WriteByte(byte(t))
+				stack = append(stack, &jsonContainer{delim: t, expectKey: t == '{'})
+			case '}', ']':
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				if top.count > 0 {
+					newline()
+				}
+				buf.WriteByte(byte(t))
+				afterValue()
+			}
+			continue
+		}
+
+		// Littéral brut: seuls des espaces et des séparateurs précèdent le jeton
+		raw := strings.TrimLeft(input[start:dec.InputOffset()], " \t\r\n,:")
+		if _, isString := tok.(string); isString && escapeHTML {
+			var escaped bytes.Buffer
+			json.HTMLEscape(&escaped, []byte(raw))
+			raw = escaped.String()
+		}
+
+		if len(stack) > 0 && stack[len(stack)-1].expectKey {
+			top := stack[len(stack)-1]
+			if top.count > 0 {
+				buf.WriteByte(',')
+			}
+			newline()
+			buf.WriteString(raw)
+			buf.WriteByte(':')
+			if indent != "" {
+				buf.WriteByte(' ')
+			}
+			top.expectKey = false
+			continue
+		}
+
+		beforeValue()
+		buf.WriteString(raw)
+		afterValue()
+	}
+
+	if len(stack) != 0 {
+		return fmt.Errorf("document JSON incomplet")
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
@@ -0,0 +1,233 @@
+package processors
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Valeurs IEEE 754 de l'annexe B de la RFC 8785
+func TestCanonicalNumberRFC8785(t *testing.T) {
+	tests := []struct {
+		bits uint64
+		want string
+	}{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0xffefffffffffffff, "-1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x44b52d02c7e14af7, "1.0000000000000001e+23"},
+		{0x444b1ae4d6e2ef4e, "999999999999999700000"},
+		{0x444b1ae4d6e2ef4f, "999999999999999900000"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x41b3de4355555553, "333333333.3333332"},
+		{0x41b3de4355555554, "333333333.33333325"},
+		{0x41b3de4355555555, "333333333.3333333"},
+		{0x41b3de4355555556, "333333333.3333334"},
+		{0x41b3de4355555557, "333333333.33333343"},
+		{0xbecbf647612f3696, "-0.0000033333333333333333"},
+		{0x43143ff3c1cb0959, "1424953923781206.2"},
+	}
+	for _, tt := range tests {
+		literal := strconv.FormatFloat(math.Float64frombits(tt.bits), 'g', -1, 64)
+		t.Run(literal, func(t *testing.T) {
+			got, err := canonicalNumber(literal)
+			if err != nil {
+				t.Fatalf("canonicalNumber(%s): %v", literal, err)
+			}
+			if got != tt.want {
+				t.Errorf("canonicalNumber(%s) = %s, attendu %s", literal, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalNumberLiterals(t *testing.T) {
+	tests := []struct {
+		literal string
+		want    string
+		wantErr bool
+	}{
+		{literal: "-0", want: "0"},
+		{literal: "1.0", want: "1"},
+		{literal: "4.50", want: "4.5"},
+		{literal: "2e-3", want: "0.002"},
+		{literal: "1E30", want: "1e+30"},
+		{literal: "1e20", want: "100000000000000000000"},
+		{literal: "1e21", want: "1e+21"},
+		{literal: "1e-6", want: "0.000001"},
+		{literal: "1e-7", want: "1e-7"},
+		{literal: "0.000000000000000000000000001", want: "1e-27"},
+		{literal: "123456789012345678901234", want: "1.2345678901234569e+23"},
+		{literal: "1e400", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.literal, func(t *testing.T) {
+			got, err := canonicalNumber(tt.literal)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("canonicalNumber(%s) = %s, erreur attendue", tt.literal, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("canonicalNumber(%s): %v", tt.literal, err)
+			}
+			if got != tt.want {
+				t.Errorf("canonicalNumber(%s) = %s, attendu %s", tt.literal, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatJSONCanonical(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "exemple de la RFC 8785",
+			input: `{
+  "numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+  "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+  "literals": [null, true, false]
+}`,
+			want: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],` +
+				`"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			name:  "tri des clés par unités UTF-16",
+			input: `{"\u20ac":1,"\r":2,"\ufb33":3,"1":4,"\ud83d\ude00":5,"\u0080":6,"\u00f6":7}`,
+			want:  "{\"\\r\":2,\"1\":4,\"\u0080\":6,\"ö\":7,\"€\":1,\"😀\":5,\"\ufb33\":3}",
+		},
+		{
+			name:  "tri récursif et tableaux dans l'ordre",
+			input: `{"b": [3, 1, {"z": 1, "a": 2}], "a": {"d": null, "c": "x"}}`,
+			want:  `{"a":{"c":"x","d":null},"b":[3,1,{"a":2,"z":1}]}`,
+		},
+		{
+			name:  "HTML jamais échappé",
+			input: `{"html": "<a href=\"x\">&</a>"}`,
+			want:  `{"html":"<a href=\"x\">&</a>"}`,
+		},
+		{
+			name:    "clé en double",
+			input:   `{"a": 1, "a": 2}`,
+			wantErr: true,
+		},
+		{
+			name:    "nombre hors de la double précision",
+			input:   `[1e400]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFormatter(IndentCanonical).FormatJSON(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("FormatJSON = %s, erreur attendue", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FormatJSON: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatJSON =\n%s\nattendu\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReindentJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		indent     string
+		escapeHTML bool
+		want       string
+	}{
+		{"ordre des clés conservé", `{"b": 1, "a": 2, "c": {"z": 0, "y": 1}}`, "", false, `{"b":1,"a":2,"c":{"z":0,"y":1}}`},
+		{"grands entiers exacts", `{"id": 12345678901234567890, "n": -9007199254740993}`, "", false, `{"id":12345678901234567890,"n":-9007199254740993}`},
+		{"littéraux numériques tels qu'écrits", `[1.0, 1.50, -0, 1E+2, 0.1e-3]`, "", false, `[1.0,1.50,-0,1E+2,0.1e-3]`},
+		{"échappements de chaîne conservés", `["é", "\/", "a\tb"]`, "", false, `["é","\/","a\tb"]`},
+		{"HTML échappé", `{"html": "<a>&</a>"}`, "", true, `{"html":"\u003ca\u003e\u0026\u003c/a\u003e"}`},
+		{"HTML conservé", `{"html": "<a>&</a>"}`, "", false, `{"html":"<a>&</a>"}`},
+		{"indentation", `{"a":[1,{}],"b":{},"c":[]}`, "  ", false, "{\n  \"a\": [\n    1,\n    {}\n  ],\n  \"b\": {},\n  \"c\": []\n}"},
+		{"tabulations", `[{"a":null}]`, "\t", false, "[\n\t{\n\t\t\"a\": null\n\t}\n]"},
+		{"valeur seule", ` "texte" `, "  ", false, `"texte"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := reindentJSON(context.Background(), &out, tt.input, tt.indent, tt.escapeHTML); err != nil {
+				t.Fatalf("reindentJSON: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("reindentJSON =\n%s\nattendu\n%s", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestReindentJSONErrors(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		input string
+	}{
+		{"document incomplet", context.Background(), `{"a": [1`},
+		{"syntaxe invalide", context.Background(), `{"a" 1}`},
+		{"contexte annulé", cancelled, `{"a": 1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := reindentJSON(tt.ctx, &out, tt.input, "", false); err == nil {
+				t.Errorf("reindentJSON = %s, erreur attendue", out.String())
+			}
+		})
+	}
+}
+
+func TestFormatJSONPreservesLiterals(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		sortKeys bool
+		want     string
+	}{
+		{"ordre d'origine", `{"z": 1, "a": 12345678901234567890}`, false, `{"z":1,"a":12345678901234567890}` + "\n"},
+		{"clés triées, nombres exacts", `{"z": 1.10, "a": 12345678901234567890}`, true, `{"a":12345678901234567890,"z":1.10}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter := NewFormatter(IndentMinified)
+			formatter.SortKeys = tt.sortKeys
+			got, err := formatter.FormatJSON(tt.input)
+			if err != nil {
+				t.Fatalf("FormatJSON: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatJSON = %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,482 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Comparaison structurelle de documents JSON et JSON Patch (RFC 6902)
+
+// maxArrayDiffCells limite la taille de la table LCS utilisée pour comparer deux tableaux;
+// au-delà, les tableaux sont comparés indice par indice
+const maxArrayDiffCells = 1 << 20
+
+// JSONChange différence entre deux documents
+type JSONChange struct {
+	Op      string    // "add", "remove" ou "replace"
+	Path    string    // Pointeur JSON dans l'ordre d'application du patch (les indices tiennent compte des opérations précédentes)
+	Display string    // Pointeur JSON lisible: indice dans le document d'origine (suppression, modification) ou final (ajout)
+	Old     *jsonNode // Valeur d'origine (remove, replace)
+	New     *jsonNode // Nouvelle valeur (add, replace)
+}
+
+// DiffJSON compare deux documents sans tenir compte de l'ordre des clés.
+// Les changements sont retournés dans un ordre applicable comme patch de a vers b.
+func DiffJSON(a, b string) ([]JSONChange, error) {
+	return DiffJSONContext(context.Background(), a, b)
+}
+
+// DiffJSONContext compare comme DiffJSON et s'interrompt si le contexte est annulé
+func DiffJSONContext(ctx context.Context, a, b string) ([]JSONChange, error) {
+	before, err := parseJSONTree(a)
+	if err != nil {
+		return nil, fmt.Errorf("premier document: %w", err)
+	}
+	after, err := parseJSONTree(b)
+	if err != nil {
+		return nil, fmt.Errorf("second document: %w", err)
+	}
+	var changes []JSONChange
+	diffNodes(ctx, before, after, "", "", &changes)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// diffNodes ajoute à changes les différences entre a et b. La comparaison s'arrête, incomplète,
+// si le contexte est annulé.
+func diffNodes(ctx context.Context, a, b *jsonNode, path, display string, changes *[]JSONChange) {
+	if cancelled(ctx) || jsonEqual(a, b) {
+		return
+	}
+	switch {
+	case a.Kind == jsonObject && b.Kind == jsonObject:
+		diffObjects(ctx, a, b, path, display, changes)
+	case a.Kind == jsonArray && b.Kind == jsonArray:
+		diffArrays(ctx, a, b, path, display, changes)
+	default:
+		*changes = append(*changes, JSONChange{Op: "replace", Path: path, Display: display, Old: a, New: b})
+	}
+}
+
+func diffObjects(ctx context.Context, a, b *jsonNode, path, display string, changes *[]JSONChange) {
+	for _, m := range a.Members {
+		// En cas de clés en double, seule la dernière occurrence compte
+		if current, _ := a.Get(m.Key); current != m.Value {
+			continue
+		}
+		key := "/" + escapeJSONPointer(m.Key)
+		if other, ok := b.Get(m.Key); ok {
+			diffNodes(ctx, m.Value, other, path+key, display+key, changes)
+		} else {
+			*changes = append(*changes, JSONChange{Op: "remove", Path: path + key, Display: display + key, Old: m.Value})
+		}
+	}
+	for _, m := range b.Members {
+		if current, _ := b.Get(m.Key); current != m.Value {
+			continue
+		}
+		if _, ok := a.Get(m.Key); !ok {
+			key := "/" + escapeJSONPointer(m.Key)
+			*changes = append(*changes, JSONChange{Op: "add", Path: path + key, Display: display + key, New: m.Value})
+		}
+	}
+}
+
+// diffArrays aligne les éléments identiques (plus longue sous-séquence commune) puis décrit
+// les éléments supprimés, ajoutés ou modifiés
+func diffArrays(ctx context.Context, a, b *jsonNode, path, display string, changes *[]JSONChange) {
+	n, m := len(a.Items), len(b.Items)
+	keep := make([]bool, n)  // Éléments de a conservés tels quels
+	match := make([]bool, m) // Éléments de b appariés
+
+	if n*m <= maxArrayDiffCells {
+		lcs := make([][]int, n+1)
+		for i := range lcs {
+			lcs[i] = make([]int, m+1)
+		}
+		for i := n - 1; i >= 0; i-- {
+			if cancelled(ctx) {
+				return
+			}
+			for j := m - 1; j >= 0; j-- {
+				if jsonEqual(a.Items[i], b.Items[j]) {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+		for i, j := 0, 0; i < n && j < m; {
+			switch {
+			case jsonEqual(a.Items[i], b.Items[j]):
+				keep[i], match[j] = true, true
+				i++
+				j++
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				i++
+			default:
+				j++
+			}
+		}
+	}
+
+	// Parcours des blocs entre éléments conservés: les paires supprimé/ajouté deviennent des modifications
+	index := 0 // Indice courant dans le tableau en cours de transformation
+	i, j := 0, 0
+	for i < n || j < m {
+		if i < n && j < m && keep[i] && match[j] {
+			i++
+			j++
+			index++
+			continue
+		}
+		var removed, added []int
+		for i < n && !keep[i] {
+			removed = append(removed, i)
+			i++
+		}
+		for j < m && !match[j] {
+			added = append(added, j)
+			j++
+		}
+		pairs := min(len(removed), len(added))
+		for k := 0; k < pairs; k++ {
+			diffNodes(ctx, a.Items[removed[k]], b.Items[added[k]],
+				path+"/"+strconv.Itoa(index), display+"/"+strconv.Itoa(removed[k]), changes)
+			index++
+		}
+		for _, r := range removed[pairs:] {
+			*changes = append(*changes, JSONChange{Op: "remove", Path: path + "/" + strconv.Itoa(index),
+				Display: display + "/" + strconv.Itoa(r), Old: a.Items[r]})
+		}
+		for _, ad := range added[pairs:] {
+			*changes = append(*changes, JSONChange{Op: "add", Path: path + "/" + strconv.Itoa(index),
+				Display: display + "/" + strconv.Itoa(ad), New: b.Items[ad]})
+			index++
+		}
+	}
+}
+
+// FormatJSONChanges produit la liste lisible des différences
+func FormatJSONChanges(changes []JSONChange) string {
+	if len(changes) == 0 {
+		return "Aucune différence\n"
+	}
+	var out strings.Builder
+	for _, c := range changes {
+		path := c.Display
+		if path == "" {
+			path = "(racine)"
+		}
+		switch c.Op {
+		case "add":
+			fmt.Fprintf(&out, "+ %s: %s\n", path, jsonLiteral(c.New))
+		case "remove":
+			fmt.Fprintf(&out, "- %s: %s\n", path, jsonLiteral(c.Old))
+		case "replace":
+			fmt.Fprintf(&out, "~ %s: %s → %s\n", path, jsonLiteral(c.Old), jsonLiteral(c.New))
+		}
+	}
+	return out.String()
+}
+
+// jsonLiteral écrit une valeur en JSON compact (les chaînes gardent leurs guillemets)
+func jsonLiteral(node *jsonNode) string {
+	var buf bytes.Buffer
+	node.Write(&buf, "", false)
+	return buf.String()
+}
+
+// JSONChangesToPatch convertit les différences en document JSON Patch
+func JSONChangesToPatch(changes []JSONChange) *jsonNode {
+	patch := newJSONArray()
+	for _, c := range changes {
+		op := newJSONObject()
+		op.Set("op", newJSONString(c.Op))
+		op.Set("path", newJSONString(c.Path))
+		if c.New != nil {
+			op.Set("value", c.New)
+		}
+		patch.Items = append(patch.Items, op)
+	}
+	return patch
+}
+
+// JSONPatchError erreur d'application d'une opération de patch
+type JSONPatchError struct {
+	Index int // Indice de l'opération dans le patch (à partir de 0)
+	Op    string
+	Path  string
+	Err   error
+}
+
+func (e *JSONPatchError) Error() string {
+	return fmt.Sprintf("opération %d (%s %s): %v", e.Index, e.Op, e.Path, e.Err)
+}
+
+func (e *JSONPatchError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyJSONPatch applique un patch RFC 6902 (add, remove, replace, move, copy, test) au document
+func ApplyJSONPatch(document *jsonNode, patch *jsonNode) (*jsonNode, error) {
+	return ApplyJSONPatchContext(context.Background(), document, patch)
+}
+
+// ApplyJSONPatchContext applique le patch comme ApplyJSONPatch et s'interrompt si le contexte
+// est annulé (vérifié avant chaque opération)
+func ApplyJSONPatchContext(ctx context.Context, document *jsonNode, patch *jsonNode) (*jsonNode, error) {
+	if patch.Kind != jsonArray {
+		return nil, fmt.Errorf("le patch doit être un tableau d'opérations")
+	}
+
+	root := document
+	for i, opNode := range patch.Items {
+		if cancelled(ctx) {
+			return nil, ctx.Err()
+		}
+		op, path, err := patchOperation(opNode)
+		if err != nil {
+			return nil, &JSONPatchError{Index: i, Op: op, Path: path, Err: err}
+		}
+		root, err = applyPatchOperation(root, opNode, op, path)
+		if err != nil {
+			return nil, &JSONPatchError{Index: i, Op: op, Path: path, Err: err}
+		}
+	}
+	return root, nil
+}
+
+func patchOperation(opNode *jsonNode) (string, string, error) {
+	if opNode.Kind != jsonObject {
+		return "", "", fmt.Errorf("une opération doit être un objet")
+	}
+	op, ok := opNode.Get("op")
+	if !ok || op.Kind != jsonString {
+		return "", "", fmt.Errorf("membre \"op\" manquant")
+	}
+	path, ok := opNode.Get("path")
+	if !ok || path.Kind != jsonString {
+		return op.Str, "", fmt.Errorf("membre \"path\" manquant")
+	}
+	return op.Str, path.Str, nil
+}
+
+func applyPatchOperation(root, opNode *jsonNode, op, path string) (*jsonNode, error) {
+	tokens, err := parseJSONPointer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	value := func() (*jsonNode, error) {
+		v, ok := opNode.Get("value")
+		if !ok {
+			return nil, fmt.Errorf("membre \"value\" manquant")
+		}
+		return v.Clone(), nil
+	}
+	from := func() ([]string, error) {
+		f, ok := opNode.Get("from")
+		if !ok || f.Kind != jsonString {
+			return nil, fmt.Errorf("membre \"from\" manquant")
+		}
+		return parseJSONPointer(f.Str)
+	}
+
+	switch op {
+	case "add":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(root, tokens, v)
+	case "remove":
+		root, _, err := pointerRemove(root, tokens)
+		return root, err
+	case "replace":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		return pointerReplace(root, tokens, v)
+	case "move":
+		source, err := from()
+		if err != nil {
+			return nil, err
+		}
+		if len(source) < len(tokens) && strings.Join(tokens[:len(source)], "/") == strings.Join(source, "/") {
+			return nil, fmt.Errorf("impossible de déplacer une valeur dans l'un de ses descendants")
+		}
+		root, moved, err := pointerRemove(root, source)
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(root, tokens, moved)
+	case "copy":
+		source, err := from()
+		if err != nil {
+			return nil, err
+		}
+		v, err := pointerGet(root, source)
+		if err != nil {
+			return nil, err
+		}
+		return pointerAdd(root, tokens, v.Clone())
+	case "test":
+		expected, err := value()
+		if err != nil {
+			return nil, err
+		}
+		actual, err := pointerGet(root, tokens)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonEqual(actual, expected) {
+			return nil, fmt.Errorf("test échoué: valeur %s, attendu %s", jsonLiteral(actual), jsonLiteral(expected))
+		}
+		return root, nil
+	}
+	return nil, fmt.Errorf("opération inconnue %q", op)
+}
+
+// parseJSONPointer découpe un pointeur JSON (RFC 6901) en jetons décodés
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("pointeur JSON invalide %q: doit commencer par '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// arrayIndex convertit un jeton en indice de tableau (limit inclus pour les insertions)
+func arrayIndex(token string, limit int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("indice de tableau invalide %q", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > limit {
+		return 0, fmt.Errorf("indice de tableau hors limites %q", token)
+	}
+	return i, nil
+}
+
+func pointerGet(root *jsonNode, tokens []string) (*jsonNode, error) {
+	node := root
+	for _, t := range tokens {
+		switch node.Kind {
+		case jsonObject:
+			next, ok := node.Get(t)
+			if !ok {
+				return nil, fmt.Errorf("propriété introuvable %q", t)
+			}
+			node = next
+		case jsonArray:
+			i, err := arrayIndex(t, len(node.Items)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = node.Items[i]
+		default:
+			return nil, fmt.Errorf("impossible de descendre dans une valeur de type %s", jsonTypeName(node))
+		}
+	}
+	return node, nil
+}
+
+func pointerAdd(root *jsonNode, tokens []string, value *jsonNode) (*jsonNode, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	parent, err := pointerGet(root, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := tokens[len(tokens)-1]
+	switch parent.Kind {
+	case jsonObject:
+		parent.Set(last, value)
+	case jsonArray:
+		i := len(parent.Items)
+		if last != "-" {
+			if i, err = arrayIndex(last, len(parent.Items)); err != nil {
+				return nil, err
+			}
+		}
+		parent.Items = append(parent.Items[:i], append([]*jsonNode{value}, parent.Items[i:]...)...)
+	default:
+		return nil, fmt.Errorf("impossible d'ajouter dans une valeur de type %s", jsonTypeName(parent))
+	}
+	return root, nil
+}
+
+// pointerReplace remplace la valeur désignée en conservant sa position dans son parent
+func pointerReplace(root *jsonNode, tokens []string, value *jsonNode) (*jsonNode, error) {
+	if _, err := pointerGet(root, tokens); err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	parent, err := pointerGet(root, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := tokens[len(tokens)-1]
+	if parent.Kind == jsonArray {
+		i, err := arrayIndex(last, len(parent.Items)-1)
+		if err != nil {
+			return nil, err
+		}
+		parent.Items[i] = value
+	} else {
+		parent.Set(last, value)
+	}
+	return root, nil
+}
+
+// pointerRemove supprime la valeur désignée et la retourne
+func pointerRemove(root *jsonNode, tokens []string) (*jsonNode, *jsonNode, error) {
+	if len(tokens) == 0 {
+		return newJSONNull(), root, nil
+	}
+	parent, err := pointerGet(root, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	last := tokens[len(tokens)-1]
+	switch parent.Kind {
+	case jsonObject:
+		removed, ok := parent.Get(last)
+		if !ok {
+			return nil, nil, fmt.Errorf("propriété introuvable %q", last)
+		}
+		members := parent.Members[:0]
+		for _, m := range parent.Members {
+			if m.Key != last {
+				members = append(members, m)
+			}
+		}
+		parent.Members = members
+		return root, removed, nil
+	case jsonArray:
+		i, err := arrayIndex(last, len(parent.Items)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		removed := parent.Items[i]
+		parent.Items = append(parent.Items[:i], parent.Items[i+1:]...)
+		return root, removed, nil
+	}
+	return nil, nil, fmt.Errorf("impossible de supprimer dans une valeur de type %s", jsonTypeName(parent))
+}
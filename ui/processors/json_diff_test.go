@@ -0,0 +1,119 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDiffJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string // Résultat de FormatJSONChanges
+	}{
+		{"documents identiques", `{"a": 1, "b": [1, 2]}`, `{"b": [1, 2], "a": 1}`, "Aucune différence\n"},
+		{"nombres égaux écrits différemment", `{"a": 1.0}`, `{"a": 1}`, "Aucune différence\n"},
+		{"valeur modifiée", `{"a": 1, "b": "x"}`, `{"a": 2, "b": "x"}`, "~ /a: 1 → 2\n"},
+		{"clés ajoutée et supprimée", `{"a": 1}`, `{"b": true}`, "- /a: 1\n+ /b: true\n"},
+		{"objet imbriqué", `{"o": {"x": 1, "y": 2}}`, `{"o": {"x": 1, "y": 3}}`, "~ /o/y: 2 → 3\n"},
+		{"racine remplacée", `[1]`, `{"a": 1}`, "~ (racine): [1] → {\"a\":1}\n"},
+		{"élément inséré", `[1, 2, 3]`, `[1, 9, 2, 3]`, "+ /1: 9\n"},
+		{"élément supprimé", `[1, 2, 3]`, `[1, 3]`, "- /1: 2\n"},
+		{"élément modifié", `[{"id": 1}, {"id": 2}]`, `[{"id": 1}, {"id": 3}]`, "~ /1/id: 2 → 3\n"},
+		{"suppressions successives", `["a", "b", "c", "d"]`, `["a", "d"]`, "- /1: \"b\"\n- /2: \"c\"\n"},
+		{"clé à échapper", `{"a/b": 1, "m~n": 1}`, `{"a/b": 2, "m~n": 2}`, "~ /a~1b: 1 → 2\n~ /m~0n: 1 → 2\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := DiffJSON(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("DiffJSON: %v", err)
+			}
+			if got := FormatJSONChanges(changes); got != tt.want {
+				t.Errorf("FormatJSONChanges =\n%s\nattendu\n%s", got, tt.want)
+			}
+
+			// Le patch produit transforme le premier document en le second
+			before, _ := parseJSONTree(tt.a)
+			after, _ := parseJSONTree(tt.b)
+			patched, err := ApplyJSONPatch(before, JSONChangesToPatch(changes))
+			if err != nil {
+				t.Fatalf("ApplyJSONPatch: %v", err)
+			}
+			if !jsonEqual(patched, after) {
+				t.Errorf("document patché %s, attendu %s", jsonLiteral(patched), jsonLiteral(after))
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		document  string
+		patch     string
+		want      string // Document compact attendu
+		wantIndex int    // Indice de l'opération en échec (-1 : succès attendu)
+	}{
+		{"ajout d'un membre", `{"a": 1}`, `[{"op": "add", "path": "/b", "value": [1]}]`, `{"a":1,"b":[1]}`, -1},
+		{"insertion dans un tableau", `[1, 3]`, `[{"op": "add", "path": "/1", "value": 2}]`, `[1,2,3]`, -1},
+		{"ajout en fin de tableau", `[1]`, `[{"op": "add", "path": "/-", "value": 2}]`, `[1,2]`, -1},
+		{"remplacement de la racine", `{"a": 1}`, `[{"op": "add", "path": "", "value": 2}]`, `2`, -1},
+		{"suppression", `{"a": 1, "b": 2}`, `[{"op": "remove", "path": "/a"}]`, `{"b":2}`, -1},
+		{"remplacement", `{"a": [1, 2]}`, `[{"op": "replace", "path": "/a/0", "value": 9}]`, `{"a":[9,2]}`, -1},
+		{"déplacement", `{"a": {"x": 1}, "b": {}}`, `[{"op": "move", "from": "/a/x", "path": "/b/y"}]`, `{"a":{},"b":{"y":1}}`, -1},
+		{"copie", `{"a": [1]}`, `[{"op": "copy", "from": "/a", "path": "/b"}]`, `{"a":[1],"b":[1]}`, -1},
+		{"test réussi", `{"a": 1.0}`, `[{"op": "test", "path": "/a", "value": 1}]`, `{"a":1.0}`, -1},
+		{"clé échappée", `{"a/b": 1}`, `[{"op": "replace", "path": "/a~1b", "value": 2}]`, `{"a/b":2}`, -1},
+		{"test échoué", `{"a": 1}`, `[{"op": "remove", "path": "/a"}, {"op": "test", "path": "/a", "value": 1}]`, "", 1},
+		{"chemin absent", `{"a": 1}`, `[{"op": "replace", "path": "/b", "value": 1}]`, "", 0},
+		{"indice hors limites", `[1]`, `[{"op": "add", "path": "/5", "value": 1}]`, "", 0},
+		{"déplacement dans un descendant", `{"a": {"b": {}}}`, `[{"op": "move", "from": "/a", "path": "/a/b/c"}]`, "", 0},
+		{"opération inconnue", `{}`, `[{"op": "merge", "path": ""}]`, "", 0},
+		{"valeur manquante", `{}`, `[{"op": "add", "path": "/a"}]`, "", 0},
+		{"pointeur invalide", `{}`, `[{"op": "add", "path": "a", "value": 1}]`, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := parseJSONTree(tt.document)
+			if err != nil {
+				t.Fatal(err)
+			}
+			patch, err := parseJSONTree(tt.patch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ApplyJSONPatch(document, patch)
+			if tt.wantIndex >= 0 {
+				var patchErr *JSONPatchError
+				if !errors.As(err, &patchErr) {
+					t.Fatalf("erreur %v, attendu une *JSONPatchError", err)
+				}
+				if patchErr.Index != tt.wantIndex {
+					t.Errorf("opération %d en échec, attendu %d (%v)", patchErr.Index, tt.wantIndex, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApplyJSONPatch: %v", err)
+			}
+			if literal := jsonLiteral(got); literal != tt.want {
+				t.Errorf("ApplyJSONPatch = %s, attendu %s", literal, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	document, _ := parseJSONTree(`{}`)
+	patch, _ := parseJSONTree(`[{"op": "add", "path": "/a", "value": 1}]`)
+	if _, err := ApplyJSONPatchContext(ctx, document, patch); !errors.Is(err, context.Canceled) {
+		t.Errorf("erreur %v, attendu %v", err, context.Canceled)
+	}
+	if _, err := DiffJSONContext(ctx, `[1]`, `[2]`); !errors.Is(err, context.Canceled) {
+		t.Errorf("DiffJSONContext: erreur %v, attendu %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,459 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Formats de sortie de la comparaison JSON
+const (
+	JSONDiffOutputList  = "Liste des différences"
+	JSONDiffOutputPatch = "JSON Patch (RFC 6902)"
+)
+
+// JSONDiffOutputs liste les valeurs acceptées pour JSONDiffOptions.Output
+var JSONDiffOutputs = []string{JSONDiffOutputList, JSONDiffOutputPatch}
+
+// JSONDiffUI implémente Processor pour la comparaison structurelle de deux documents JSON
+type JSONDiffUI struct {
+	viewModel *JSONDiffViewModel
+}
+
+func NewJSONDiffUI() Processor {
+	return &JSONDiffUI{
+		viewModel: NewJSONDiffViewModel(),
+	}
+}
+
+func (ui *JSONDiffUI) Name() string {
+	return "Comparaison JSON"
+}
+
+func (ui *JSONDiffUI) Description() string {
+	return "Compare deux documents JSON sans tenir compte de l'ordre des clés"
+}
+
+func (ui *JSONDiffUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *JSONDiffUI) CreateConfigurationUI() fyne.CanvasObject {
+	inputs, reference, input := newDualInputArea(
+		"Document de référence (avant):", "Document comparé (après, entrée du pipeline):",
+		ui.viewModel.reference, func(s string) { ui.viewModel.reference = s })
+	reference.SetPlaceHolder(`{"id": 1, "tags": ["a"]}`)
+	input.SetPlaceHolder(`{"tags": ["a", "b"], "id": 2}`)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapWord
+	output.Disable()
+
+	status := widget.NewLabel("")
+
+	outputSelect := widget.NewSelect(JSONDiffOutputs, nil)
+	outputSelect.SetSelected(ui.viewModel.output)
+
+	compareBtn := widget.NewButton("Comparer", func() {
+		result, err := ui.viewModel.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			status.SetText("")
+			highlightPairError(reference, input, err)
+			return
+		}
+		output.SetText(result)
+		status.SetText(fmt.Sprintf("%d différence(s)", ui.viewModel.lastCount))
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := ui.viewModel.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	outputSelect.OnChanged = func(s string) {
+		ui.viewModel.output = s
+		if reference.Text != "" && input.Text != "" {
+			compareBtn.OnTapped()
+		}
+	}
+
+	topSection := container.NewVBox(
+		inputs,
+		container.NewHBox(
+			widget.NewLabel("Sortie:"),
+			outputSelect,
+			compareBtn,
+			copyBtn,
+			status,
+		),
+		widget.NewLabel("Différences:"),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// JSONDiffOptions configuration du ViewModel de comparaison
+type JSONDiffOptions struct {
+	Reference string // Document de référence (« avant »), comparé à l'entrée
+	Output    string // Une des valeurs de JSONDiffOutputs
+}
+
+// JSONDiffViewModel implémente ViewModel et DualInputProcessor pour la comparaison JSON
+type JSONDiffViewModel struct {
+	reference  string
+	output     string
+	lastResult string
+	lastCount  int
+}
+
+func NewJSONDiffViewModel() *JSONDiffViewModel {
+	return &JSONDiffViewModel{
+		output: JSONDiffOutputList,
+	}
+}
+
+// Process compare le document de référence configuré à l'entrée
+func (vm *JSONDiffViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext compare comme Process en s'interrompant si le contexte est annulé
+func (vm *JSONDiffViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	return vm.processPair(ctx, vm.reference, input)
+}
+
+// ProcessPair décrit les changements qui transforment before en after
+func (vm *JSONDiffViewModel) ProcessPair(before, after string) (string, error) {
+	return vm.processPair(context.Background(), before, after)
+}
+
+func (vm *JSONDiffViewModel) processPair(ctx context.Context, before, after string) (string, error) {
+	changes, err := DiffJSONContext(ctx, before, after)
+	if err != nil {
+		return "", err
+	}
+
+	var result string
+	if vm.output == JSONDiffOutputPatch {
+		var out strings.Builder
+		JSONChangesToPatch(changes).Write(&out, "  ", false)
+		out.WriteString("\n")
+		result = out.String()
+	} else {
+		result = FormatJSONChanges(changes)
+	}
+
+	vm.lastResult, vm.lastCount = result, len(changes)
+	return result, nil
+}
+
+func (vm *JSONDiffViewModel) GetConfiguration() interface{} {
+	return JSONDiffOptions{
+		Reference: vm.reference,
+		Output:    vm.output,
+	}
+}
+
+func (vm *JSONDiffViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(JSONDiffOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.reference = cfg.Reference
+	vm.output = cfg.Output
+	if vm.output == "" {
+		vm.output = JSONDiffOutputList
+	}
+	return nil
+}
+
+func (vm *JSONDiffViewModel) Validate() error {
+	return vm.GetConfiguration().(JSONDiffOptions).Validate()
+}
+
+// Validate vérifie que le document de référence est du JSON valide
+func (o JSONDiffOptions) Validate() error {
+	if o.Output != "" && o.Output != JSONDiffOutputList && o.Output != JSONDiffOutputPatch {
+		return fmt.Errorf("format de sortie invalide: %s", o.Output)
+	}
+	if strings.TrimSpace(o.Reference) == "" {
+		return fmt.Errorf("le document de référence est requis")
+	}
+	if err := ValidateJSON(o.Reference); err != nil {
+		return fmt.Errorf("document de référence: %w", err)
+	}
+	return nil
+}
+
+func (vm *JSONDiffViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// JSONPatchUI implémente Processor pour l'application d'un JSON Patch (RFC 6902)
+type JSONPatchUI struct {
+	viewModel *JSONPatchViewModel
+}
+
+func NewJSONPatchUI() Processor {
+	return &JSONPatchUI{
+		viewModel: NewJSONPatchViewModel(),
+	}
+}
+
+func (ui *JSONPatchUI) Name() string {
+	return "JSON Patch"
+}
+
+func (ui *JSONPatchUI) Description() string {
+	return "Applique un JSON Patch (RFC 6902) à un document"
+}
+
+func (ui *JSONPatchUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *JSONPatchUI) CreateConfigurationUI() fyne.CanvasObject {
+	inputs, patch, input := newDualInputArea(
+		"Patch (RFC 6902):", "Document (entrée du pipeline):",
+		ui.viewModel.patch, func(s string) { ui.viewModel.patch = s })
+	patch.SetPlaceHolder(`[{"op": "replace", "path": "/id", "value": 2}]`)
+	input.SetPlaceHolder(`{"id": 1}`)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapWord
+	output.Disable()
+
+	indentSelect := widget.NewSelect(JSONOutputIndentTypes, nil)
+	indentSelect.SetSelected(ui.viewModel.indentType)
+
+	applyBtn := widget.NewButton("Appliquer", func() {
+		result, err := ui.viewModel.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			highlightPairError(patch, input, err)
+			return
+		}
+		output.SetText(result)
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := ui.viewModel.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	indentSelect.OnChanged = func(s string) {
+		ui.viewModel.indentType = s
+		if patch.Text != "" && input.Text != "" {
+			applyBtn.OnTapped()
+		}
+	}
+
+	topSection := container.NewVBox(
+		inputs,
+		container.NewHBox(
+			widget.NewLabel("Indentation:"),
+			indentSelect,
+			applyBtn,
+			copyBtn,
+		),
+		widget.NewLabel("Document modifié:"),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// JSONPatchOptions configuration du ViewModel d'application de patch
+type JSONPatchOptions struct {
+	Patch      string // Tableau d'opérations RFC 6902
+	IndentType string // Une des valeurs de JSONOutputIndentTypes
+}
+
+// JSONPatchViewModel implémente ViewModel et DualInputProcessor pour JSON Patch
+type JSONPatchViewModel struct {
+	patch      string
+	indentType string
+	lastResult string
+}
+
+func NewJSONPatchViewModel() *JSONPatchViewModel {
+	return &JSONPatchViewModel{
+		indentType: "2 espaces",
+	}
+}
+
+// Process applique le patch configuré à l'entrée
+func (vm *JSONPatchViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext applique le patch comme Process en s'interrompant si le contexte est annulé
+func (vm *JSONPatchViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	return vm.processPair(ctx, vm.patch, input)
+}
+
+// ProcessPair applique patch au document
+func (vm *JSONPatchViewModel) ProcessPair(patch, document string) (string, error) {
+	return vm.processPair(context.Background(), patch, document)
+}
+
+func (vm *JSONPatchViewModel) processPair(ctx context.Context, patch, document string) (string, error) {
+	operations, err := parseJSONTree(patch)
+	if err != nil {
+		return "", fmt.Errorf("patch: %w", err)
+	}
+	tree, err := parseJSONTree(document)
+	if err != nil {
+		return "", err
+	}
+	patched, err := ApplyJSONPatchContext(ctx, tree, operations)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	patched.Write(&out, NewFormatter(vm.indentType).indent(), false)
+	out.WriteString("\n")
+
+	vm.lastResult = out.String()
+	return vm.lastResult, nil
+}
+
+func (vm *JSONPatchViewModel) GetConfiguration() interface{} {
+	return JSONPatchOptions{
+		Patch:      vm.patch,
+		IndentType: vm.indentType,
+	}
+}
+
+func (vm *JSONPatchViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(JSONPatchOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.patch = cfg.Patch
+	vm.indentType = cfg.IndentType
+	if vm.indentType == "" {
+		vm.indentType = "2 espaces"
+	}
+	return nil
+}
+
+func (vm *JSONPatchViewModel) Validate() error {
+	return vm.GetConfiguration().(JSONPatchOptions).Validate()
+}
+
+// Validate vérifie que le patch est un tableau JSON et que l'indentation est connue
+func (o JSONPatchOptions) Validate() error {
+	if strings.TrimSpace(o.Patch) == "" {
+		return fmt.Errorf("le patch est requis")
+	}
+	operations, err := parseJSONTree(o.Patch)
+	if err != nil {
+		return fmt.Errorf("patch: %w", err)
+	}
+	if operations.Kind != jsonArray {
+		return fmt.Errorf("le patch doit être un tableau d'opérations")
+	}
+	return validateOutputIndent(o.IndentType)
+}
+
+func (vm *JSONPatchViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// JSONDiffTool identifiant de l'outil "JSON Diff" dans les pipelines sérialisés
+const JSONDiffTool ToolType = "json_diff"
+
+func init() {
+	Register(Registration{
+		Type:        JSONDiffTool,
+		Name:        "JSON Diff",
+		Description: "Compare deux documents JSON et produit la liste des différences ou un JSON Patch",
+		New:         NewJSONDiffUI,
+		Codec:       NewConfigCodec(JSONDiffConfig.Options, func(o JSONDiffOptions) JSONDiffConfig { return JSONDiffConfig(o) }),
+	})
+}
+
+// JSONDiffConfig configuration pour la comparaison JSON: l'entrée de l'étape est comparée
+// au document de référence
+type JSONDiffConfig struct {
+	Reference string
+	Output    string `json:",omitempty"` // "Liste des différences" (défaut) ou "JSON Patch (RFC 6902)"
+}
+
+func (c JSONDiffConfig) GetType() ToolType {
+	return JSONDiffTool
+}
+
+func (c JSONDiffConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c JSONDiffConfig) GetDisplayName() string {
+	if c.Output == JSONDiffOutputPatch {
+		return "JSON Diff (JSON Patch)"
+	}
+	return "JSON Diff"
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c JSONDiffConfig) Options() JSONDiffOptions {
+	return JSONDiffOptions{Reference: c.Reference, Output: c.Output}
+}
+
+// JSONPatchTool identifiant de l'outil "JSON Patch" dans les pipelines sérialisés
+const JSONPatchTool ToolType = "json_patch"
+
+func init() {
+	Register(Registration{
+		Type:        JSONPatchTool,
+		Name:        "JSON Patch",
+		Description: "Applique un JSON Patch (RFC 6902) à l'entrée",
+		New:         NewJSONPatchUI,
+		Codec:       NewConfigCodec(JSONPatchConfig.Options, func(o JSONPatchOptions) JSONPatchConfig { return JSONPatchConfig(o) }),
+	})
+}
+
+// JSONPatchConfig configuration pour l'application d'un JSON Patch à l'entrée
+type JSONPatchConfig struct {
+	Patch      string
+	IndentType string `json:",omitempty"`
+}
+
+func (c JSONPatchConfig) GetType() ToolType {
+	return JSONPatchTool
+}
+
+func (c JSONPatchConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c JSONPatchConfig) GetDisplayName() string {
+	return "JSON Patch"
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c JSONPatchConfig) Options() JSONPatchOptions {
+	return JSONPatchOptions{Patch: c.Patch, IndentType: c.IndentType}
+}
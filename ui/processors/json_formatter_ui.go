@@ -1,10 +1,15 @@
 package processors
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"unicode"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -41,16 +46,43 @@ func (ui *JSONFormatterUI) CreateConfigurationUI() fyne.CanvasObject {
 	output.Wrapping = fyne.TextWrapWord
 	output.Scroll = container.ScrollBoth
 
-	indentOptions := []string{"2 espaces", "4 espaces", "Tabulations"}
-	indentSelect := widget.NewSelect(indentOptions, func(s string) {
+	indentSelect := widget.NewSelect(JSONIndentTypes, func(s string) {
 		ui.viewModel.indentType = s
 	})
 	indentSelect.SetSelected(ui.viewModel.indentType)
 
+	escapeCheck := widget.NewCheck("Ne pas échapper <, > et &", nil)
+	escapeCheck.SetChecked(ui.viewModel.disableHTMLEscape)
+
+	sortCheck := widget.NewCheck("Trier les clés", nil)
+	sortCheck.SetChecked(ui.viewModel.sortKeys)
+
+	lenientCheck := widget.NewCheck("Mode permissif (JSONC, JSON5)", nil)
+	lenientCheck.SetChecked(ui.viewModel.lenient)
+
+	rejectCommentsCheck := widget.NewCheck("Refuser les commentaires", nil)
+	rejectCommentsCheck.SetChecked(ui.viewModel.rejectComments)
+	if !ui.viewModel.lenient {
+		rejectCommentsCheck.Disable()
+	}
+
+	// Le mode canonique impose le tri des clés et n'échappe jamais le HTML
+	updateChecks := func() {
+		if ui.viewModel.indentType == IndentCanonical {
+			sortCheck.Disable()
+			escapeCheck.Disable()
+		} else {
+			sortCheck.Enable()
+			escapeCheck.Enable()
+		}
+	}
+	updateChecks()
+
 	formatBtn := widget.NewButton("Formater", func() {
 		result, err := ui.viewModel.Process(input.Text)
 		if err != nil {
 			output.ParseMarkdown("```\n" + PrettyValidationError(err) + "\n```")
+			highlightJSONError(input, err)
 		} else {
 			output.ParseMarkdown("```json\n" + result + "\n```")
 		}
@@ -64,6 +96,41 @@ func (ui *JSONFormatterUI) CreateConfigurationUI() fyne.CanvasObject {
 	})
 
 	indentSelect.OnChanged = func(s string) {
+		ui.viewModel.indentType = s
+		updateChecks()
+		if input.Text != "" {
+			formatBtn.OnTapped()
+		}
+	}
+
+	escapeCheck.OnChanged = func(checked bool) {
+		ui.viewModel.disableHTMLEscape = checked
+		if input.Text != "" {
+			formatBtn.OnTapped()
+		}
+	}
+
+	sortCheck.OnChanged = func(checked bool) {
+		ui.viewModel.sortKeys = checked
+		if input.Text != "" {
+			formatBtn.OnTapped()
+		}
+	}
+
+	lenientCheck.OnChanged = func(checked bool) {
+		ui.viewModel.lenient = checked
+		if checked {
+			rejectCommentsCheck.Enable()
+		} else {
+			rejectCommentsCheck.Disable()
+		}
+		if input.Text != "" {
+			formatBtn.OnTapped()
+		}
+	}
+
+	rejectCommentsCheck.OnChanged = func(checked bool) {
+		ui.viewModel.rejectComments = checked
 		if input.Text != "" {
 			formatBtn.OnTapped()
 		}
@@ -76,6 +143,12 @@ func (ui *JSONFormatterUI) CreateConfigurationUI() fyne.CanvasObject {
 			formatBtn,
 			widget.NewLabel("Indentation:"),
 			indentSelect,
+			sortCheck,
+			escapeCheck,
+		),
+		container.NewHBox(
+			lenientCheck,
+			rejectCommentsCheck,
 		),
 		container.NewHBox(
 			widget.NewLabel("Résultat formaté:"),
@@ -92,10 +165,92 @@ func (ui *JSONFormatterUI) CreateConfigurationUI() fyne.CanvasObject {
 	)
 }
 
+// highlightJSONError sélectionne dans la zone de saisie le jeton à la position de l'erreur JSON
+// (ou d'une SyntaxError d'un autre format), toute la ligne pour une erreur en fin de ligne, et
+// donne le focus à la zone de saisie
+func highlightJSONError(input *widget.Entry, err error) {
+	var jsonErr *JSONError
+	var syntaxErr *SyntaxError
+	var row, column int
+	switch {
+	case errors.As(err, &jsonErr):
+		row, column = jsonErr.Line-1, jsonErr.Column-1
+	case errors.As(err, &syntaxErr) && syntaxErr.Line > 0:
+		row, column = syntaxErr.Line-1, max(syntaxErr.Column-1, 0)
+	default:
+		return
+	}
+
+	// La position est bornée au texte pour une erreur en fin de document
+	lines := strings.Split(input.Text, "\n")
+	row = min(max(row, 0), len(lines)-1)
+	start, end := errorTokenSpan([]rune(lines[row]), column)
+
+	// widget.Entry n'expose pas de sélection programmatique: la sélection est faite comme au
+	// clavier (Maj+→ depuis le début du jeton), après avoir annulé une sélection précédente
+	if input.SelectedText() != "" {
+		input.TypedKey(&fyne.KeyEvent{Name: fyne.KeyLeft})
+	}
+	input.CursorRow, input.CursorColumn = row, start
+	shift := &fyne.KeyEvent{Name: desktop.KeyShiftLeft}
+	input.KeyDown(shift)
+	for i := start; i < end; i++ {
+		input.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	}
+	input.KeyUp(shift)
+	input.Refresh()
+	if canvas := fyne.CurrentApp().Driver().CanvasForObject(input); canvas != nil {
+		canvas.Focus(input)
+	}
+}
+
+// errorTokenSpan retourne les colonnes (début inclus, fin exclue) du jeton de line commençant à
+// column: mot ou nombre, chaîne entre guillemets, ou caractère seul. Une position en fin de
+// ligne désigne toute la ligne.
+func errorTokenSpan(line []rune, column int) (start, end int) {
+	column = max(column, 0)
+	if column >= len(line) {
+		return 0, len(line)
+	}
+
+	isWordRune := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.+-", r)
+	}
+	end = column + 1
+	switch r := line[column]; {
+	case r == '"' || r == '\'':
+		for end < len(line) && line[end] != r {
+			if line[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		end = min(end+1, len(line))
+	case isWordRune(r):
+		for end < len(line) && isWordRune(line[end]) {
+			end++
+		}
+	}
+	return column, end
+}
+
+// JSONFormatterOptions configuration du ViewModel du formateur JSON
+type JSONFormatterOptions struct {
+	IndentType        string // Une des valeurs de JSONIndentTypes
+	SortKeys          bool
+	DisableHTMLEscape bool
+	Lenient           bool // Accepter JSONC/JSON5 et produire du JSON strict
+	RejectComments    bool // Mode permissif: refuser les commentaires au lieu de les supprimer
+}
+
 // JSONFormatterViewModel implémente ViewModel pour le formateur JSON
 type JSONFormatterViewModel struct {
-	indentType string
-	lastResult string
+	indentType        string
+	sortKeys          bool
+	disableHTMLEscape bool
+	lenient           bool
+	rejectComments    bool
+	lastResult        string
 }
 
 func NewJSONFormatterViewModel() *JSONFormatterViewModel {
@@ -105,13 +260,22 @@ func NewJSONFormatterViewModel() *JSONFormatterViewModel {
 }
 
 func (vm *JSONFormatterViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext formate le document en s'interrompant si le contexte est annulé
+func (vm *JSONFormatterViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
 	if input == "" {
 		vm.lastResult = ""
 		return "", nil
 	}
 
 	formatter := NewFormatter(vm.indentType)
-	formatted, err := formatter.FormatJSON(input)
+	formatter.SortKeys = vm.sortKeys
+	formatter.EscapeHTML = !vm.disableHTMLEscape
+	formatter.Lenient = vm.lenient
+	formatter.RejectComments = vm.rejectComments
+	formatted, err := formatter.FormatJSONContext(ctx, input)
 	if err != nil {
 		return "", err
 	}
@@ -121,25 +285,33 @@ func (vm *JSONFormatterViewModel) Process(input string) (string, error) {
 }
 
 func (vm *JSONFormatterViewModel) GetConfiguration() interface{} {
-	return struct {
-		IndentType string
-	}{
-		IndentType: vm.indentType,
+	return JSONFormatterOptions{
+		IndentType:        vm.indentType,
+		SortKeys:          vm.sortKeys,
+		DisableHTMLEscape: vm.disableHTMLEscape,
+		Lenient:           vm.lenient,
+		RejectComments:    vm.rejectComments,
 	}
 }
 
 func (vm *JSONFormatterViewModel) LoadConfiguration(config interface{}) error {
-	cfg, ok := config.(struct{ IndentType string })
-	if !ok {
+	switch cfg := config.(type) {
+	case JSONFormatterOptions:
+		vm.indentType = cfg.IndentType
+		vm.sortKeys = cfg.SortKeys
+		vm.disableHTMLEscape = cfg.DisableHTMLEscape
+		vm.lenient = cfg.Lenient
+		vm.rejectComments = cfg.RejectComments
+	case struct{ IndentType string }:
+		vm.indentType = cfg.IndentType
+	default:
 		return fmt.Errorf("configuration invalide")
 	}
-	vm.indentType = cfg.IndentType
 	return nil
 }
 
 func (vm *JSONFormatterViewModel) Validate() error {
-	validTypes := []string{"2 espaces", "4 espaces", "Tabulations"}
-	for _, valid := range validTypes {
+	for _, valid := range JSONIndentTypes {
 		if vm.indentType == valid {
 			return nil
 		}
@@ -150,3 +322,50 @@ func (vm *JSONFormatterViewModel) Validate() error {
 func (vm *JSONFormatterViewModel) GetLastResult() (string, error) {
 	return vm.lastResult, nil
 }
+
+// JSONFormatterTool identifiant de l'outil "JSON Formatter" dans les pipelines sérialisés
+const JSONFormatterTool ToolType = "json_formatter"
+
+func init() {
+	Register(Registration{
+		Type:        JSONFormatterTool,
+		Name:        "JSON Formatter",
+		Description: "Formate et valide du JSON",
+		New:         NewJSONFormatterUI,
+		Codec:       NewConfigCodec(JSONFormatterConfig.Options, func(o JSONFormatterOptions) JSONFormatterConfig { return JSONFormatterConfig(o) }),
+	})
+}
+
+// JSONFormatterConfig configuration pour le formateur JSON
+type JSONFormatterConfig struct {
+	IndentType        string // "2 espaces", "4 espaces", "Tabulations", "Minifié", "Canonique (RFC 8785)"
+	SortKeys          bool   `json:",omitempty"` // Trier récursivement les clés des objets
+	DisableHTMLEscape bool   `json:",omitempty"` // Conserver <, > et & tels quels
+	Lenient           bool   `json:",omitempty"` // Accepter JSONC/JSON5 (commentaires, virgules finales...)
+	RejectComments    bool   `json:",omitempty"` // Mode permissif: refuser les commentaires
+}
+
+func (c JSONFormatterConfig) GetType() ToolType {
+	return JSONFormatterTool
+}
+
+func (c JSONFormatterConfig) Validate() error {
+	for _, valid := range JSONIndentTypes {
+		if c.IndentType == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("type d'indentation invalide: %s", c.IndentType)
+}
+
+func (c JSONFormatterConfig) GetDisplayName() string {
+	if c.SortKeys && c.IndentType != IndentCanonical {
+		return fmt.Sprintf("JSON Formatter (Indentation: %s, clés triées)", c.IndentType)
+	}
+	return fmt.Sprintf("JSON Formatter (Indentation: %s)", c.IndentType)
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c JSONFormatterConfig) Options() JSONFormatterOptions {
+	return JSONFormatterOptions(c)
+}
@@ -0,0 +1,298 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// jsonQueryHelp aide-mémoire affiché sous le champ de requête
+const jsonQueryHelp = "$.a.b  $.liste[0]  $.liste[-1]  $.liste[1:3]  $.liste[*].nom  $..prix\n" +
+	"$.liste[?@.prix < 10 && @.stock]  $.liste[*].{nom, prix: @.tarif.ttc}"
+
+// JSONQueryUI implémente Processor pour les requêtes JSONPath
+type JSONQueryUI struct {
+	viewModel *JSONQueryViewModel
+}
+
+func NewJSONQueryUI() Processor {
+	return &JSONQueryUI{
+		viewModel: NewJSONQueryViewModel(),
+	}
+}
+
+func (ui *JSONQueryUI) Name() string {
+	return "Requête JSON"
+}
+
+func (ui *JSONQueryUI) Description() string {
+	return "Extrait des valeurs d'un document JSON avec une expression JSONPath"
+}
+
+func (ui *JSONQueryUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *JSONQueryUI) CreateConfigurationUI() fyne.CanvasObject {
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("Entrez votre document JSON ici...")
+	input.Wrapping = fyne.TextWrapWord
+	input.Resize(fyne.NewSize(0, 120))
+
+	expressionEntry := widget.NewEntry()
+	expressionEntry.SetPlaceHolder("$.items[?@.price < 10].name")
+	expressionEntry.SetText(ui.viewModel.expression)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapWord
+	output.Disable()
+
+	status := widget.NewLabel("")
+
+	rawCheck := widget.NewCheck("Texte brut (chaînes sans guillemets)", nil)
+	rawCheck.SetChecked(ui.viewModel.rawOutput)
+
+	wrapCheck := widget.NewCheck("Toujours un tableau", nil)
+	wrapCheck.SetChecked(ui.viewModel.wrapArray)
+
+	indentSelect := widget.NewSelect(JSONOutputIndentTypes, nil)
+	indentSelect.SetSelected(ui.viewModel.indentType)
+
+	runBtn := widget.NewButton("Exécuter", func() {
+		result, err := ui.viewModel.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			status.SetText("")
+			highlightJSONError(input, err)
+			return
+		}
+		output.SetText(result)
+		status.SetText(fmt.Sprintf("%d résultat(s)", ui.viewModel.lastCount))
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := ui.viewModel.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	rerun := func() {
+		if input.Text != "" && ui.viewModel.expression != "" {
+			runBtn.OnTapped()
+		}
+	}
+
+	expressionEntry.OnChanged = func(s string) {
+		ui.viewModel.expression = s
+	}
+	expressionEntry.OnSubmitted = func(string) {
+		runBtn.OnTapped()
+	}
+
+	rawCheck.OnChanged = func(checked bool) {
+		ui.viewModel.rawOutput = checked
+		rerun()
+	}
+
+	wrapCheck.OnChanged = func(checked bool) {
+		ui.viewModel.wrapArray = checked
+		rerun()
+	}
+
+	indentSelect.OnChanged = func(s string) {
+		ui.viewModel.indentType = s
+		rerun()
+	}
+
+	help := widget.NewLabel(jsonQueryHelp)
+	help.TextStyle = fyne.TextStyle{Monospace: true}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée JSON:"),
+		input,
+		widget.NewLabel("Requête (JSONPath):"),
+		container.NewBorder(nil, nil, nil, container.NewHBox(runBtn, copyBtn), expressionEntry),
+		help,
+		container.NewHBox(
+			widget.NewLabel("Indentation:"),
+			indentSelect,
+			rawCheck,
+			wrapCheck,
+		),
+		container.NewHBox(
+			widget.NewLabel("Résultat:"),
+			status,
+		),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// JSONQueryOptions configuration du ViewModel de requête JSON
+type JSONQueryOptions struct {
+	Expression string
+	RawOutput  bool   // Chaînes sans guillemets, un résultat par ligne
+	WrapArray  bool   // Toujours produire un tableau, même pour un chemin singulier ($.a.b[0])
+	IndentType string // "2 espaces", "4 espaces", "Tabulations" ou "Minifié"
+}
+
+// JSONQueryViewModel implémente ViewModel pour les requêtes JSON
+type JSONQueryViewModel struct {
+	expression string
+	rawOutput  bool
+	wrapArray  bool
+	indentType string
+	lastResult string
+	lastCount  int
+}
+
+func NewJSONQueryViewModel() *JSONQueryViewModel {
+	return &JSONQueryViewModel{
+		expression: "$",
+		indentType: "2 espaces",
+	}
+}
+
+func (vm *JSONQueryViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext exécute la requête en s'interrompant si le contexte est annulé
+func (vm *JSONQueryViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	query, err := CompileJSONQuery(vm.expression)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(input) == "" {
+		vm.lastResult, vm.lastCount = "", 0
+		return "", nil
+	}
+
+	tree, err := parseJSONTree(input)
+	if err != nil {
+		return "", err
+	}
+	results, err := query.EvaluateContext(ctx, tree)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	indent := NewFormatter(vm.indentType).indent()
+	switch {
+	case vm.rawOutput:
+		// Un résultat par ligne: chaînes telles quelles, autres valeurs en JSON compact
+		for _, node := range results {
+			out.WriteString(node.String())
+			out.WriteString("\n")
+		}
+	case len(results) == 1 && query.IsSingular() && !vm.wrapArray:
+		// Seul un chemin singulier produit la valeur elle-même: le type du résultat d'un
+		// joker, d'une tranche ou d'un filtre ne dépend pas du nombre de nœuds trouvés
+		results[0].Write(&out, indent, false)
+		out.WriteString("\n")
+	default:
+		newJSONArray(results...).Write(&out, indent, false)
+		out.WriteString("\n")
+	}
+
+	vm.lastResult, vm.lastCount = out.String(), len(results)
+	return vm.lastResult, nil
+}
+
+func (vm *JSONQueryViewModel) GetConfiguration() interface{} {
+	return JSONQueryOptions{
+		Expression: vm.expression,
+		RawOutput:  vm.rawOutput,
+		WrapArray:  vm.wrapArray,
+		IndentType: vm.indentType,
+	}
+}
+
+func (vm *JSONQueryViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(JSONQueryOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.expression = cfg.Expression
+	vm.rawOutput = cfg.RawOutput
+	vm.wrapArray = cfg.WrapArray
+	vm.indentType = cfg.IndentType
+	if vm.indentType == "" {
+		vm.indentType = "2 espaces"
+	}
+	return nil
+}
+
+func (vm *JSONQueryViewModel) Validate() error {
+	return vm.GetConfiguration().(JSONQueryOptions).Validate()
+}
+
+// Validate vérifie la syntaxe de l'expression et le type d'indentation
+func (o JSONQueryOptions) Validate() error {
+	if strings.TrimSpace(o.Expression) == "" {
+		return fmt.Errorf("l'expression de requête est requise")
+	}
+	if _, err := CompileJSONQuery(o.Expression); err != nil {
+		return err
+	}
+	return validateOutputIndent(o.IndentType)
+}
+
+func (vm *JSONQueryViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// JSONQueryTool identifiant de l'outil "JSON Query" dans les pipelines sérialisés
+const JSONQueryTool ToolType = "json_query"
+
+func init() {
+	Register(Registration{
+		Type:        JSONQueryTool,
+		Name:        "JSON Query",
+		Description: "Extrait des valeurs JSON avec une expression JSONPath",
+		New:         NewJSONQueryUI,
+		Codec:       NewConfigCodec(JSONQueryConfig.Options, func(o JSONQueryOptions) JSONQueryConfig { return JSONQueryConfig(o) }),
+	})
+}
+
+// JSONQueryConfig configuration pour les requêtes JSONPath
+type JSONQueryConfig struct {
+	Expression string
+	RawOutput  bool   `json:",omitempty"` // Chaînes sans guillemets, un résultat par ligne
+	WrapArray  bool   `json:",omitempty"` // Toujours produire un tableau
+	IndentType string `json:",omitempty"` // "2 espaces" par défaut
+}
+
+func (c JSONQueryConfig) GetType() ToolType {
+	return JSONQueryTool
+}
+
+func (c JSONQueryConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c JSONQueryConfig) GetDisplayName() string {
+	return fmt.Sprintf("JSON Query (%s)", c.Expression)
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c JSONQueryConfig) Options() JSONQueryOptions {
+	return JSONQueryOptions{
+		Expression: c.Expression,
+		RawOutput:  c.RawOutput,
+		WrapArray:  c.WrapArray,
+		IndentType: c.IndentType,
+	}
+}
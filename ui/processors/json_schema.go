@@ -0,0 +1,636 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validation JSON Schema (sous-ensemble de draft 2020-12). Mots-clés pris en charge:
+//
+//	type, enum, const, $ref (références locales "#/..."), $defs
+//	allOf, anyOf, oneOf, not, if/then/else
+//	properties, patternProperties, additionalProperties, required, propertyNames,
+//	minProperties, maxProperties, dependentRequired, dependentSchemas
+//	prefixItems, items, contains, minContains, maxContains, minItems, maxItems, uniqueItems
+//	minLength, maxLength, pattern
+//	minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf
+//
+// Les autres mots-clés (format, title, description...) sont ignorés comme de simples annotations.
+
+// maxSchemaDepth limite l'imbrication des références pour détecter les schémas cycliques
+const maxSchemaDepth = 512
+
+// SchemaViolation non-conformité d'une valeur à son schéma
+type SchemaViolation struct {
+	Pointer string // Pointeur JSON (RFC 6901) vers la valeur fautive, "" pour la racine
+	Keyword string // Mot-clé du schéma non respecté
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	pointer := v.Pointer
+	if pointer == "" {
+		pointer = "(racine)"
+	}
+	return fmt.Sprintf("%s: %s [%s]", pointer, v.Message, v.Keyword)
+}
+
+// SchemaValidationError liste toutes les violations d'un document
+type SchemaValidationError struct {
+	Violations []SchemaViolation
+}
+
+func (e *SchemaValidationError) Error() string {
+	if len(e.Violations) == 1 {
+		return "document non conforme au schéma: " + e.Violations[0].String()
+	}
+	return fmt.Sprintf("document non conforme au schéma (%d violations), première: %s",
+		len(e.Violations), e.Violations[0])
+}
+
+func (e *SchemaValidationError) Pretty() string {
+	lines := []string{fmt.Sprintf("Document non conforme au schéma: %d violation(s)", len(e.Violations)), ""}
+	for _, violation := range e.Violations {
+		lines = append(lines, "- "+violation.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSONSchema schéma analysé, prêt à valider des documents
+type JSONSchema struct {
+	root     *jsonNode
+	patterns map[string]*regexp.Regexp
+}
+
+// CompileJSONSchema analyse un schéma et vérifie ses expressions régulières et ses références
+func CompileJSONSchema(text string) (*JSONSchema, error) {
+	root, err := parseJSONTree(text)
+	if err != nil {
+		return nil, fmt.Errorf("schéma JSON invalide: %s", PrettyValidationError(err))
+	}
+	s := &JSONSchema{root: root, patterns: map[string]*regexp.Regexp{}}
+	if err := s.check(root, ""); err != nil {
+		return nil, fmt.Errorf("schéma JSON invalide: %w", err)
+	}
+	return s, nil
+}
+
+// check parcourt le schéma pour précompiler les motifs et résoudre les références
+func (s *JSONSchema) check(schema *jsonNode, location string) error {
+	if schema.Kind == jsonBool {
+		return nil
+	}
+	if schema.Kind != jsonObject {
+		return fmt.Errorf("%s: un schéma doit être un objet ou un booléen", schemaLocation(location))
+	}
+
+	for _, m := range schema.Members {
+		at := location + "/" + escapeJSONPointer(m.Key)
+		fail := func(err error) error {
+			return fmt.Errorf("%s: %w", schemaLocation(at), err)
+		}
+		switch m.Key {
+		case "pattern":
+			if _, err := s.regexp(m.Value.Str); err != nil {
+				return fail(err)
+			}
+		case "$ref":
+			if _, err := s.resolve(m.Value.Str); err != nil {
+				return fail(err)
+			}
+		case "patternProperties":
+			for _, p := range m.Value.Members {
+				if _, err := s.regexp(p.Key); err != nil {
+					return fail(err)
+				}
+				if err := s.check(p.Value, at+"/"+escapeJSONPointer(p.Key)); err != nil {
+					return err
+				}
+			}
+		case "properties", "$defs", "definitions", "dependentSchemas":
+			for _, p := range m.Value.Members {
+				if err := s.check(p.Value, at+"/"+escapeJSONPointer(p.Key)); err != nil {
+					return err
+				}
+			}
+		case "allOf", "anyOf", "oneOf", "prefixItems":
+			if m.Value.Kind != jsonArray {
+				return fail(fmt.Errorf("un tableau de schémas est attendu"))
+			}
+			for i, item := range m.Value.Items {
+				if err := s.check(item, at+"/"+strconv.Itoa(i)); err != nil {
+					return err
+				}
+			}
+		case "not", "if", "then", "else", "items", "contains", "additionalProperties", "propertyNames":
+			if err := s.check(m.Value, at); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func schemaLocation(location string) string {
+	if location == "" {
+		return "#"
+	}
+	return "#" + location
+}
+
+// regexp compile (une seule fois) un motif du schéma
+func (s *JSONSchema) regexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := s.patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("motif invalide %q: %v", pattern, err)
+	}
+	s.patterns[pattern] = re
+	return re, nil
+}
+
+// resolve retourne le sous-schéma désigné par une référence locale ("#", "#/$defs/nom")
+func (s *JSONSchema) resolve(ref string) (*jsonNode, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("référence non prise en charge %q (seules les références locales \"#/...\" sont acceptées)", ref)
+	}
+	node := s.root
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer == "" {
+		return node, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("référence invalide %q", ref)
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch node.Kind {
+		case jsonObject:
+			next, ok := node.Get(token)
+			if !ok {
+				return nil, fmt.Errorf("référence introuvable %q", ref)
+			}
+			node = next
+		case jsonArray:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node.Items) {
+				return nil, fmt.Errorf("référence introuvable %q", ref)
+			}
+			node = node.Items[i]
+		default:
+			return nil, fmt.Errorf("référence introuvable %q", ref)
+		}
+	}
+	return node, nil
+}
+
+// Validate retourne toutes les violations du document (nil s'il est conforme)
+func (s *JSONSchema) Validate(instance *jsonNode) []SchemaViolation {
+	violations, _ := s.validateContext(context.Background(), instance)
+	return violations
+}
+
+// validateContext valide comme Validate; les violations sont incomplètes si le contexte est annulé
+func (s *JSONSchema) validateContext(ctx context.Context, instance *jsonNode) ([]SchemaViolation, error) {
+	v := &schemaValidator{schema: s, ctx: ctx}
+	v.validate(s.root, instance, "")
+	return v.violations, ctx.Err()
+}
+
+// ValidateJSON valide un document JSON texte et retourne une *SchemaValidationError
+// s'il n'est pas conforme
+func (s *JSONSchema) ValidateJSON(input string) error {
+	return s.ValidateJSONContext(context.Background(), input)
+}
+
+// ValidateJSONContext valide comme ValidateJSON et s'interrompt si le contexte est annulé
+func (s *JSONSchema) ValidateJSONContext(ctx context.Context, input string) error {
+	instance, err := parseJSONTree(input)
+	if err != nil {
+		return err
+	}
+	violations, err := s.validateContext(ctx, instance)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return &SchemaValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// escapeJSONPointer échappe un jeton de pointeur JSON (RFC 6901)
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+type schemaValidator struct {
+	schema     *JSONSchema
+	ctx        context.Context
+	violations []SchemaViolation
+	depth      int
+}
+
+func (v *schemaValidator) report(pointer, keyword, format string, args ...interface{}) {
+	v.violations = append(v.violations, SchemaViolation{
+		Pointer: pointer,
+		Keyword: keyword,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// collect valide sans enregistrer les violations dans le validateur courant
+func (v *schemaValidator) collect(schema, instance *jsonNode, pointer string) []SchemaViolation {
+	sub := &schemaValidator{schema: v.schema, ctx: v.ctx, depth: v.depth}
+	sub.validate(schema, instance, pointer)
+	return sub.violations
+}
+
+func (v *schemaValidator) valid(schema, instance *jsonNode, pointer string) bool {
+	return len(v.collect(schema, instance, pointer)) == 0
+}
+
+func (v *schemaValidator) validate(schema, instance *jsonNode, pointer string) {
+	if cancelled(v.ctx) {
+		return
+	}
+	if schema.Kind == jsonBool {
+		if !schema.Bool {
+			v.report(pointer, "false", "aucune valeur n'est autorisée ici")
+		}
+		return
+	}
+
+	v.depth++
+	defer func() { v.depth-- }()
+	if v.depth > maxSchemaDepth {
+		v.report(pointer, "$ref", "références trop imbriquées (schéma cyclique ?)")
+		return
+	}
+
+	for _, m := range schema.Members {
+		kw, value := m.Key, m.Value
+		switch kw {
+		case "$ref":
+			if target, err := v.schema.resolve(value.Str); err == nil {
+				v.validate(target, instance, pointer)
+			}
+
+		case "type":
+			v.checkType(value, instance, pointer)
+
+		case "enum":
+			found := false
+			for _, item := range value.Items {
+				if jsonEqual(item, instance) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				v.report(pointer, kw, "valeur non autorisée, attendu une de: %s", value.String())
+			}
+
+		case "const":
+			if !jsonEqual(value, instance) {
+				v.report(pointer, kw, "valeur attendue: %s", value.String())
+			}
+
+		case "allOf":
+			for _, sub := range value.Items {
+				v.validate(sub, instance, pointer)
+			}
+
+		case "anyOf":
+			matched := false
+			for _, sub := range value.Items {
+				if v.valid(sub, instance, pointer) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				v.report(pointer, kw, "ne correspond à aucun des schémas de anyOf")
+			}
+
+		case "oneOf":
+			count := 0
+			for _, sub := range value.Items {
+				if v.valid(sub, instance, pointer) {
+					count++
+				}
+			}
+			if count == 0 {
+				v.report(pointer, kw, "ne correspond à aucun des schémas de oneOf")
+			} else if count > 1 {
+				v.report(pointer, kw, "correspond à %d schémas de oneOf, un seul attendu", count)
+			}
+
+		case "not":
+			if v.valid(value, instance, pointer) {
+				v.report(pointer, kw, "ne doit pas correspondre au schéma de not")
+			}
+
+		case "if":
+			if v.valid(value, instance, pointer) {
+				if then, ok := schema.Get("then"); ok {
+					v.validate(then, instance, pointer)
+				}
+			} else if otherwise, ok := schema.Get("else"); ok {
+				v.validate(otherwise, instance, pointer)
+			}
+
+		default:
+			switch instance.Kind {
+			case jsonObject:
+				v.checkObject(schema, kw, value, instance, pointer)
+			case jsonArray:
+				v.checkArray(schema, kw, value, instance, pointer)
+			case jsonString:
+				v.checkString(kw, value, instance, pointer)
+			case jsonNumber:
+				v.checkNumber(kw, value, instance, pointer)
+			}
+		}
+	}
+}
+
+// jsonTypeName retourne le type JSON Schema d'une valeur
+func jsonTypeName(instance *jsonNode) string {
+	switch instance.Kind {
+	case jsonNull:
+		return "null"
+	case jsonBool:
+		return "boolean"
+	case jsonNumber:
+		return "number"
+	case jsonString:
+		return "string"
+	case jsonArray:
+		return "array"
+	}
+	return "object"
+}
+
+func (v *schemaValidator) checkType(value, instance *jsonNode, pointer string) {
+	types := []*jsonNode{value}
+	if value.Kind == jsonArray {
+		types = value.Items
+	}
+	actual := jsonTypeName(instance)
+	var names []string
+	for _, t := range types {
+		if t.Str == actual {
+			return
+		}
+		if t.Str == "integer" && instance.Kind == jsonNumber {
+			if r, ok := jsonRat(instance); ok && r.IsInt() {
+				return
+			}
+		}
+		names = append(names, t.Str)
+	}
+	v.report(pointer, "type", "type attendu %s, reçu %s", strings.Join(names, " ou "), actual)
+}
+
+func (v *schemaValidator) checkObject(schema *jsonNode, kw string, value, instance *jsonNode, pointer string) {
+	switch kw {
+	case "required":
+		for _, name := range value.Items {
+			if _, ok := instance.Get(name.Str); !ok {
+				v.report(pointer, kw, "propriété requise manquante: %q", name.Str)
+			}
+		}
+
+	case "properties":
+		for _, p := range value.Members {
+			if child, ok := instance.Get(p.Key); ok {
+				v.validate(p.Value, child, pointer+"/"+escapeJSONPointer(p.Key))
+			}
+		}
+
+	case "patternProperties":
+		for _, p := range value.Members {
+			re, _ := v.schema.regexp(p.Key)
+			for _, m := range instance.Members {
+				if re.MatchString(m.Key) {
+					v.validate(p.Value, m.Value, pointer+"/"+escapeJSONPointer(m.Key))
+				}
+			}
+		}
+
+	case "additionalProperties":
+		properties, _ := schema.Get("properties")
+		patterns, _ := schema.Get("patternProperties")
+		for _, m := range instance.Members {
+			if properties != nil {
+				if _, declared := properties.Get(m.Key); declared {
+					continue
+				}
+			}
+			if patterns != nil && v.matchesPatternProperty(patterns, m.Key) {
+				continue
+			}
+			childPointer := pointer + "/" + escapeJSONPointer(m.Key)
+			if value.Kind == jsonBool && !value.Bool {
+				v.report(childPointer, kw, "propriété non autorisée")
+				continue
+			}
+			v.validate(value, m.Value, childPointer)
+		}
+
+	case "propertyNames":
+		for _, m := range instance.Members {
+			for _, violation := range v.collect(value, newJSONString(m.Key), pointer) {
+				v.report(pointer+"/"+escapeJSONPointer(m.Key), kw, "nom de propriété invalide %q: %s", m.Key, violation.Message)
+			}
+		}
+
+	case "minProperties":
+		if limit, ok := jsonInt(value); ok && len(instance.Members) < limit {
+			v.report(pointer, kw, "%d propriété(s), minimum %d", len(instance.Members), limit)
+		}
+
+	case "maxProperties":
+		if limit, ok := jsonInt(value); ok && len(instance.Members) > limit {
+			v.report(pointer, kw, "%d propriété(s), maximum %d", len(instance.Members), limit)
+		}
+
+	case "dependentRequired":
+		for _, dep := range value.Members {
+			if _, present := instance.Get(dep.Key); !present {
+				continue
+			}
+			for _, name := range dep.Value.Items {
+				if _, ok := instance.Get(name.Str); !ok {
+					v.report(pointer, kw, "propriété %q requise lorsque %q est présente", name.Str, dep.Key)
+				}
+			}
+		}
+
+	case "dependentSchemas":
+		for _, dep := range value.Members {
+			if _, present := instance.Get(dep.Key); present {
+				v.validate(dep.Value, instance, pointer)
+			}
+		}
+	}
+}
+
+func (v *schemaValidator) matchesPatternProperty(patterns *jsonNode, key string) bool {
+	for _, p := range patterns.Members {
+		if re, err := v.schema.regexp(p.Key); err == nil && re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *schemaValidator) checkArray(schema *jsonNode, kw string, value, instance *jsonNode, pointer string) {
+	itemPointer := func(i int) string {
+		return pointer + "/" + strconv.Itoa(i)
+	}
+
+	switch kw {
+	case "prefixItems":
+		for i, sub := range value.Items {
+			if i < len(instance.Items) {
+				v.validate(sub, instance.Items[i], itemPointer(i))
+			}
+		}
+
+	case "items":
+		start := 0
+		if prefix, ok := schema.Get("prefixItems"); ok {
+			start = len(prefix.Items)
+		}
+		for i := start; i < len(instance.Items); i++ {
+			if value.Kind == jsonBool && !value.Bool {
+				v.report(itemPointer(i), kw, "élément non autorisé (au plus %d élément(s))", start)
+				continue
+			}
+			v.validate(value, instance.Items[i], itemPointer(i))
+		}
+
+	case "contains":
+		count := 0
+		for i, item := range instance.Items {
+			if v.valid(value, item, itemPointer(i)) {
+				count++
+			}
+		}
+		minContains, maxContains := 1, -1
+		if n, ok := schema.Get("minContains"); ok {
+			minContains, _ = jsonInt(n)
+		}
+		if n, ok := schema.Get("maxContains"); ok {
+			maxContains, _ = jsonInt(n)
+		}
+		if count < minContains {
+			if minContains == 1 {
+				v.report(pointer, kw, "aucun élément ne correspond au schéma de contains")
+			} else {
+				v.report(pointer, "minContains", "%d élément(s) correspondent au schéma de contains, minimum %d", count, minContains)
+			}
+		}
+		if maxContains >= 0 && count > maxContains {
+			v.report(pointer, "maxContains", "%d élément(s) correspondent au schéma de contains, maximum %d", count, maxContains)
+		}
+
+	case "minItems":
+		if limit, ok := jsonInt(value); ok && len(instance.Items) < limit {
+			v.report(pointer, kw, "%d élément(s), minimum %d", len(instance.Items), limit)
+		}
+
+	case "maxItems":
+		if limit, ok := jsonInt(value); ok && len(instance.Items) > limit {
+			v.report(pointer, kw, "%d élément(s), maximum %d", len(instance.Items), limit)
+		}
+
+	case "uniqueItems":
+		if value.Kind != jsonBool || !value.Bool {
+			return
+		}
+		for i := range instance.Items {
+			for j := i + 1; j < len(instance.Items); j++ {
+				if jsonEqual(instance.Items[i], instance.Items[j]) {
+					v.report(pointer, kw, "éléments identiques aux indices %d et %d", i, j)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (v *schemaValidator) checkString(kw string, value, instance *jsonNode, pointer string) {
+	length := utf8.RuneCountInString(instance.Str)
+	switch kw {
+	case "minLength":
+		if limit, ok := jsonInt(value); ok && length < limit {
+			v.report(pointer, kw, "longueur %d, minimum %d", length, limit)
+		}
+	case "maxLength":
+		if limit, ok := jsonInt(value); ok && length > limit {
+			v.report(pointer, kw, "longueur %d, maximum %d", length, limit)
+		}
+	case "pattern":
+		if re, err := v.schema.regexp(value.Str); err == nil && !re.MatchString(instance.Str) {
+			v.report(pointer, kw, "%q ne correspond pas au motif %q", instance.Str, value.Str)
+		}
+	}
+}
+
+func (v *schemaValidator) checkNumber(kw string, value, instance *jsonNode, pointer string) {
+	n, ok := jsonRat(instance)
+	limit, limitOK := jsonRat(value)
+	if !ok || !limitOK {
+		return
+	}
+	cmp := n.Cmp(limit)
+	switch kw {
+	case "minimum":
+		if cmp < 0 {
+			v.report(pointer, kw, "%s est inférieur au minimum %s", instance.Raw, value.Raw)
+		}
+	case "maximum":
+		if cmp > 0 {
+			v.report(pointer, kw, "%s est supérieur au maximum %s", instance.Raw, value.Raw)
+		}
+	case "exclusiveMinimum":
+		if cmp <= 0 {
+			v.report(pointer, kw, "%s doit être strictement supérieur à %s", instance.Raw, value.Raw)
+		}
+	case "exclusiveMaximum":
+		if cmp >= 0 {
+			v.report(pointer, kw, "%s doit être strictement inférieur à %s", instance.Raw, value.Raw)
+		}
+	case "multipleOf":
+		if limit.Sign() > 0 && !new(big.Rat).Quo(n, limit).IsInt() {
+			v.report(pointer, kw, "%s n'est pas un multiple de %s", instance.Raw, value.Raw)
+		}
+	}
+}
+
+// jsonRat convertit un nombre JSON en rationnel exact
+func jsonRat(node *jsonNode) (*big.Rat, bool) {
+	if node.Kind != jsonNumber {
+		return nil, false
+	}
+	return new(big.Rat).SetString(node.Raw)
+}
+
+// jsonInt convertit un nombre JSON entier positif ou nul (limites des mots-clés min/max)
+func jsonInt(node *jsonNode) (int, bool) {
+	r, ok := jsonRat(node)
+	if !ok || !r.IsInt() || r.Sign() < 0 || !r.Num().IsInt64() {
+		return 0, false
+	}
+	return int(r.Num().Int64()), true
+}
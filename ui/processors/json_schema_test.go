@@ -0,0 +1,196 @@
+package processors
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONSchemaKeywords(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		instance string
+		want     []string // Violations attendues: "mot-clé pointeur", nil si le document est conforme
+	}{
+		{"schéma true", `true`, `1`, nil},
+		{"schéma false", `false`, `1`, []string{"false"}},
+		{"annotations ignorées", `{"format": "email", "title": "t", "description": "d"}`, `"pas un email"`, nil},
+
+		{"type conforme", `{"type": "string"}`, `"x"`, nil},
+		{"type incorrect", `{"type": "string"}`, `1`, []string{"type"}},
+		{"liste de types", `{"type": ["integer", "null"]}`, `null`, nil},
+		{"entier écrit avec une décimale nulle", `{"type": "integer"}`, `1.0`, nil},
+		{"nombre non entier", `{"type": "integer"}`, `1.5`, []string{"type"}},
+		{"entier accepté comme nombre", `{"type": "number"}`, `3`, nil},
+
+		{"enum", `{"enum": [1, "a", null]}`, `"a"`, nil},
+		{"enum refusé", `{"enum": [1, "a", null]}`, `2`, []string{"enum"}},
+		{"enum et égalité numérique", `{"enum": [1]}`, `1.0`, nil},
+		{"const sans ordre des clés", `{"const": {"a": 2, "b": 1}}`, `{"b": 1, "a": 2}`, nil},
+		{"const refusé", `{"const": {"a": [1, 2]}}`, `{"a": [2, 1]}`, []string{"const"}},
+
+		{"allOf", `{"allOf": [{"minimum": 1}, {"maximum": 3}]}`, `5`, []string{"maximum"}},
+		{"anyOf conforme", `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, `1`, nil},
+		{"anyOf refusé", `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, `true`, []string{"anyOf"}},
+		{"oneOf conforme", `{"oneOf": [{"type": "integer"}, {"minimum": 0}]}`, `-1`, nil},
+		{"oneOf ambigu", `{"oneOf": [{"type": "integer"}, {"minimum": 0}]}`, `1`, []string{"oneOf"}},
+		{"oneOf refusé", `{"oneOf": [{"type": "integer"}, {"type": "string"}]}`, `null`, []string{"oneOf"}},
+		{"not", `{"not": {"type": "null"}}`, `null`, []string{"not"}},
+
+		{"if/then", ifThenElseSchema, `{"pays": "FR"}`, []string{"required"}},
+		{"if/else conforme", ifThenElseSchema, `{"pays": "US", "zip": "1"}`, nil},
+		{"if/else", ifThenElseSchema, `{"pays": "US"}`, []string{"required"}},
+
+		{"$ref et $defs", `{"$defs": {"pos": {"type": "integer", "minimum": 0}}, "properties": {"n": {"$ref": "#/$defs/pos"}}}`,
+			`{"n": -1}`, []string{"minimum /n"}},
+
+		{"properties imbriquées", `{"properties": {"a": {"properties": {"b": {"type": "string"}}}}}`,
+			`{"a": {"b": 1}}`, []string{"type /a/b"}},
+		{"pointeur échappé", `{"properties": {"a/b~": {"type": "string"}}}`, `{"a/b~": 1}`, []string{"type /a~1b~0"}},
+		{"patternProperties", `{"patternProperties": {"^x-": {"type": "string"}}}`, `{"x-a": 1, "y": 1}`, []string{"type /x-a"}},
+		{"additionalProperties false",
+			`{"properties": {"a": {}}, "patternProperties": {"^x-": {}}, "additionalProperties": false}`,
+			`{"a": 1, "x-b": 2, "c": 3}`, []string{"additionalProperties /c"}},
+		{"additionalProperties schéma", `{"properties": {"a": {}}, "additionalProperties": {"type": "integer"}}`,
+			`{"a": "x", "b": "y"}`, []string{"type /b"}},
+		{"required", `{"required": ["a", "b"]}`, `{"a": 1}`, []string{"required"}},
+		{"propertyNames", `{"propertyNames": {"maxLength": 3}}`, `{"abc": 1, "abcd": 2}`, []string{"propertyNames /abcd"}},
+		{"minProperties", `{"minProperties": 2}`, `{"a": 1}`, []string{"minProperties"}},
+		{"maxProperties", `{"maxProperties": 1}`, `{"a": 1, "b": 2}`, []string{"maxProperties"}},
+		{"dependentRequired", `{"dependentRequired": {"cb": ["cvv"]}}`, `{"cb": "1"}`, []string{"dependentRequired"}},
+		{"dependentRequired absent", `{"dependentRequired": {"cb": ["cvv"]}}`, `{"iban": "1"}`, nil},
+		{"dependentSchemas", `{"dependentSchemas": {"cb": {"required": ["cvv"]}}}`, `{"cb": "1"}`, []string{"required"}},
+
+		{"prefixItems", `{"prefixItems": [{"type": "string"}, {"type": "integer"}]}`, `["a", "b"]`, []string{"type /1"}},
+		{"items après prefixItems", `{"prefixItems": [{"type": "string"}], "items": false}`, `["a", 1]`, []string{"items /1"}},
+		{"items", `{"items": {"type": "integer"}}`, `[1, "x", 3]`, []string{"type /1"}},
+		{"contains", `{"contains": {"type": "string"}}`, `[1, 2]`, []string{"contains"}},
+		{"minContains", `{"contains": {"type": "string"}, "minContains": 2}`, `["a", 1]`, []string{"minContains"}},
+		{"maxContains", `{"contains": {"type": "string"}, "maxContains": 1}`, `["a", "b"]`, []string{"maxContains"}},
+		{"minItems", `{"minItems": 1}`, `[]`, []string{"minItems"}},
+		{"maxItems", `{"maxItems": 1}`, `[1, 2]`, []string{"maxItems"}},
+		{"uniqueItems", `{"uniqueItems": true}`, `[1, {"a": 1}, {"a": 1}]`, []string{"uniqueItems"}},
+		{"uniqueItems et égalité numérique", `{"uniqueItems": true}`, `[1, 1.0]`, []string{"uniqueItems"}},
+
+		{"minLength en caractères", `{"minLength": 2}`, `"é"`, []string{"minLength"}},
+		{"maxLength en caractères", `{"maxLength": 2}`, `"😀😀"`, nil},
+		{"pattern non ancré", `{"pattern": "b"}`, `"abc"`, nil},
+		{"pattern", `{"pattern": "^[0-9]+$"}`, `"12a"`, []string{"pattern"}},
+
+		{"minimum inclus", `{"minimum": 1.5}`, `1.5`, nil},
+		{"exclusiveMinimum", `{"exclusiveMinimum": 1.5}`, `1.5`, []string{"exclusiveMinimum"}},
+		{"maximum", `{"maximum": 10}`, `10.01`, []string{"maximum"}},
+		{"exclusiveMaximum", `{"exclusiveMaximum": 10}`, `10`, []string{"exclusiveMaximum"}},
+		{"multipleOf décimal exact", `{"multipleOf": 0.1}`, `0.3`, nil},
+		{"multipleOf", `{"multipleOf": 0.1}`, `0.35`, []string{"multipleOf"}},
+
+		{"mots-clés d'un autre type ignorés", `{"minimum": 5, "minLength": 5}`, `"abc"`, []string{"minLength"}},
+		{"toutes les violations", `{"type": "object", "required": ["a"], "properties": {"b": {"type": "string"}}}`,
+			`{"b": 1}`, []string{"required", "type /b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := CompileJSONSchema(tt.schema)
+			if err != nil {
+				t.Fatalf("CompileJSONSchema: %v", err)
+			}
+			err = schema.ValidateJSON(tt.instance)
+			var got []string
+			var schemaErr *SchemaValidationError
+			if errors.As(err, &schemaErr) {
+				for _, violation := range schemaErr.Violations {
+					got = append(got, strings.TrimSpace(violation.Keyword+" "+violation.Pointer))
+				}
+			} else if err != nil {
+				t.Fatalf("ValidateJSON: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
+
+const ifThenElseSchema = `{
+  "if": {"properties": {"pays": {"const": "FR"}}},
+  "then": {"required": ["cp"]},
+  "else": {"required": ["zip"]}
+}`
+
+func TestCompileJSONSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"JSON invalide", `{"type": }`},
+		{"schéma qui n'est pas un objet", `[1]`},
+		{"sous-schéma qui n'est pas un objet", `{"properties": {"a": 1}}`},
+		{"motif invalide", `{"pattern": "("}`},
+		{"référence introuvable", `{"$ref": "#/$defs/absent"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CompileJSONSchema(tt.schema); err == nil {
+				t.Errorf("CompileJSONSchema(%s): erreur attendue", tt.schema)
+			}
+		})
+	}
+}
+
+func TestJSONSchemaConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	const schema = `{"type": "object", "required": ["id"]}`
+	if err := os.WriteFile(filepath.Join(dir, "schema.json"), []byte(schema), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "invalide.json"), []byte(`{"pattern": "("}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		config      JSONSchemaConfig
+		wantInvalid bool // Validate doit échouer
+	}{
+		{"schéma collé", JSONSchemaConfig{Schema: schema}, false},
+		{"fichier relatif au répertoire du pipeline", JSONSchemaConfig{SchemaFile: "schema.json", BaseDir: dir}, false},
+		{"fichier absolu", JSONSchemaConfig{SchemaFile: filepath.Join(dir, "schema.json"), BaseDir: t.TempDir()}, false},
+		{"fichier prioritaire sur le schéma collé", JSONSchemaConfig{Schema: `false`, SchemaFile: "schema.json", BaseDir: dir}, false},
+		{"WithBaseDir", JSONSchemaConfig{SchemaFile: "schema.json"}.WithBaseDir(dir).(JSONSchemaConfig), false},
+		{"aucun schéma", JSONSchemaConfig{Schema: "  "}, true},
+		{"schéma collé invalide", JSONSchemaConfig{Schema: `{"type": }`}, true},
+		{"fichier absent", JSONSchemaConfig{SchemaFile: "absent.json", BaseDir: dir}, true},
+		{"fichier relatif hors du répertoire du pipeline", JSONSchemaConfig{SchemaFile: "schema.json", BaseDir: t.TempDir()}, true},
+		{"fichier invalide", JSONSchemaConfig{SchemaFile: "invalide.json", BaseDir: dir}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantInvalid {
+				if err == nil {
+					t.Error("Validate: erreur attendue")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+
+			processor, err := NewConfiguredProcessor(tt.config)
+			if err != nil {
+				t.Fatalf("NewConfiguredProcessor: %v", err)
+			}
+			if got, err := processor.ViewModel().Process(`{"id": 1}`); err != nil || got != `{"id": 1}` {
+				t.Errorf("Process d'un document conforme = %q, %v", got, err)
+			}
+			var validationErr *SchemaValidationError
+			if _, err := processor.ViewModel().Process(`{}`); !errors.As(err, &validationErr) {
+				t.Errorf("Process d'un document non conforme: erreur %v, attendu une *SchemaValidationError", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// JSONSchemaUI implémente Processor pour la validation par schéma JSON
+type JSONSchemaUI struct {
+	viewModel *JSONSchemaViewModel
+}
+
+func NewJSONSchemaUI() Processor {
+	return &JSONSchemaUI{
+		viewModel: NewJSONSchemaViewModel(),
+	}
+}
+
+func (ui *JSONSchemaUI) Name() string {
+	return "Validateur JSON Schema"
+}
+
+func (ui *JSONSchemaUI) Description() string {
+	return "Vérifie qu'un document JSON respecte un schéma (draft 2020-12)"
+}
+
+func (ui *JSONSchemaUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *JSONSchemaUI) CreateConfigurationUI() fyne.CanvasObject {
+	schemaEntry := widget.NewMultiLineEntry()
+	schemaEntry.SetPlaceHolder(`{"type": "object", "required": ["id"]}`)
+	schemaEntry.Wrapping = fyne.TextWrapWord
+	schemaEntry.SetText(ui.viewModel.schema)
+	schemaEntry.SetMinRowsVisible(6)
+
+	fileEntry := widget.NewEntry()
+	fileEntry.SetPlaceHolder("ou chemin d'un fichier de schéma (prioritaire)")
+	fileEntry.SetText(ui.viewModel.schemaFile)
+
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("Entrez le document JSON à valider...")
+	input.Wrapping = fyne.TextWrapWord
+	input.SetMinRowsVisible(6)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapWord
+	output.Disable()
+
+	schemaEntry.OnChanged = func(s string) {
+		ui.viewModel.schema = s
+	}
+	fileEntry.OnChanged = func(s string) {
+		ui.viewModel.schemaFile = s
+	}
+
+	browseBtn := widget.NewButton("Parcourir...", func() {
+		window := fyne.CurrentApp().Driver().AllWindows()[0]
+		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil || reader == nil {
+				return
+			}
+			defer reader.Close()
+			fileEntry.SetText(reader.URI().Path())
+		}, window)
+	})
+
+	validateBtn := widget.NewButton("Valider", func() {
+		if _, err := ui.viewModel.Process(input.Text); err != nil {
+			output.SetText(PrettyValidationError(err))
+			highlightJSONError(input, err)
+			return
+		}
+		output.SetText("Document conforme au schéma")
+	})
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Schéma JSON:"),
+		schemaEntry,
+		container.NewBorder(nil, nil, widget.NewLabel("Fichier:"), browseBtn, fileEntry),
+		widget.NewLabel("Document JSON:"),
+		input,
+		container.NewHBox(
+			validateBtn,
+		),
+		widget.NewLabel("Résultat de la validation:"),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewVScroll(output),
+	)
+}
+
+// JSONSchemaOptions configuration du ViewModel de validation par schéma
+type JSONSchemaOptions struct {
+	Schema     string // Schéma collé dans la configuration
+	SchemaFile string // Chemin d'un fichier de schéma, prioritaire sur Schema
+	BaseDir    string // Répertoire de résolution d'un SchemaFile relatif (répertoire courant si vide)
+}
+
+// JSONSchemaViewModel implémente ViewModel pour la validation par schéma.
+// Un document conforme est transmis sans modification, sinon le traitement échoue
+// avec une *SchemaValidationError listant toutes les violations.
+type JSONSchemaViewModel struct {
+	schema     string
+	schemaFile string
+	baseDir    string
+	lastResult string
+
+	// Schéma compilé, réutilisé tant que son texte ne change pas
+	compiled     *JSONSchema
+	compiledText string
+}
+
+func NewJSONSchemaViewModel() *JSONSchemaViewModel {
+	return &JSONSchemaViewModel{}
+}
+
+// readSchemaText retourne le texte du schéma: le contenu de schemaFile (résolu par rapport à
+// baseDir s'il est relatif) s'il est défini, sinon schema
+func readSchemaText(schema, schemaFile, baseDir string) (string, error) {
+	text := schema
+	if schemaFile != "" {
+		path := schemaFile
+		if baseDir != "" && !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("lecture du schéma: %w", err)
+		}
+		text = string(data)
+	}
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("aucun schéma défini")
+	}
+	return text, nil
+}
+
+// loadSchema lit le schéma (fichier ou texte) et le compile si nécessaire
+func (vm *JSONSchemaViewModel) loadSchema() (*JSONSchema, error) {
+	text, err := readSchemaText(vm.schema, vm.schemaFile, vm.baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if vm.compiled == nil || vm.compiledText != text {
+		compiled, err := CompileJSONSchema(text)
+		if err != nil {
+			return nil, err
+		}
+		vm.compiled, vm.compiledText = compiled, text
+	}
+	return vm.compiled, nil
+}
+
+func (vm *JSONSchemaViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext valide le document en s'interrompant si le contexte est annulé
+func (vm *JSONSchemaViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	schema, err := vm.loadSchema()
+	if err != nil {
+		return "", err
+	}
+	if err := schema.ValidateJSONContext(ctx, input); err != nil {
+		return "", err
+	}
+
+	vm.lastResult = input
+	return input, nil
+}
+
+func (vm *JSONSchemaViewModel) GetConfiguration() interface{} {
+	return JSONSchemaOptions{
+		Schema:     vm.schema,
+		SchemaFile: vm.schemaFile,
+		BaseDir:    vm.baseDir,
+	}
+}
+
+func (vm *JSONSchemaViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(JSONSchemaOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.schema = cfg.Schema
+	vm.schemaFile = cfg.SchemaFile
+	vm.baseDir = cfg.BaseDir
+	return nil
+}
+
+func (vm *JSONSchemaViewModel) Validate() error {
+	_, err := vm.loadSchema()
+	return err
+}
+
+func (vm *JSONSchemaViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// JSONSchemaTool identifiant de l'outil "JSON Schema" dans les pipelines sérialisés
+const JSONSchemaTool ToolType = "json_schema"
+
+func init() {
+	Register(Registration{
+		Type:        JSONSchemaTool,
+		Name:        "JSON Schema",
+		Description: "Valide du JSON avec un schéma et bloque les documents non conformes",
+		New:         NewJSONSchemaUI,
+		Codec:       NewConfigCodec(JSONSchemaConfig.Options, func(o JSONSchemaOptions) JSONSchemaConfig { return JSONSchemaConfig(o) }),
+	})
+}
+
+// JSONSchemaConfig configuration pour la validation par schéma JSON
+type JSONSchemaConfig struct {
+	Schema     string `json:",omitempty"` // Schéma collé
+	SchemaFile string `json:",omitempty"` // Fichier de schéma, relu à chaque exécution (prioritaire)
+	BaseDir    string `json:"-"`          // Répertoire du fichier pipeline (voir BaseDirConfig)
+}
+
+func (c JSONSchemaConfig) GetType() ToolType {
+	return JSONSchemaTool
+}
+
+// Validate vérifie que le schéma, collé ou lu depuis SchemaFile, se compile
+func (c JSONSchemaConfig) Validate() error {
+	if c.SchemaFile == "" && strings.TrimSpace(c.Schema) == "" {
+		return fmt.Errorf("un schéma ou un fichier de schéma est requis")
+	}
+	text, err := readSchemaText(c.Schema, c.SchemaFile, c.BaseDir)
+	if err != nil {
+		return err
+	}
+	_, err = CompileJSONSchema(text)
+	return err
+}
+
+// WithBaseDir résout un SchemaFile relatif par rapport au répertoire du fichier pipeline
+func (c JSONSchemaConfig) WithBaseDir(dir string) ToolConfig {
+	c.BaseDir = dir
+	return c
+}
+
+func (c JSONSchemaConfig) GetDisplayName() string {
+	if c.SchemaFile != "" {
+		return fmt.Sprintf("JSON Schema (%s)", filepath.Base(c.SchemaFile))
+	}
+	return "JSON Schema"
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c JSONSchemaConfig) Options() JSONSchemaOptions {
+	return JSONSchemaOptions(c)
+}
@@ -0,0 +1,449 @@
+package processors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// jsonKind type d'une valeur JSON
+type jsonKind int
+
+const (
+	jsonNull jsonKind = iota
+	jsonBool
+	jsonNumber
+	jsonString
+	jsonArray
+	jsonObject
+)
+
+// jsonMember paire clé/valeur d'un objet JSON
+type jsonMember struct {
+	Key   string
+	Value *jsonNode
+}
+
+// jsonNode valeur JSON qui conserve l'ordre des clés et les littéraux d'origine
+// (contrairement à un décodage vers interface{})
+type jsonNode struct {
+	Kind    jsonKind
+	Raw     string // Littéral tel qu'écrit dans l'entrée (nombres, chaînes avec guillemets)
+	Str     string // Valeur décodée (chaînes)
+	Bool    bool
+	Items   []*jsonNode  // Tableaux
+	Members []jsonMember // Objets, dans l'ordre d'origine
+}
+
+// parseJSONTree analyse un document JSON complet
+func parseJSONTree(input string) (*jsonNode, error) {
+	if err := ValidateJSON(input); err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(strings.NewReader(input))
+	dec.UseNumber()
+	node, err := readJSONNode(dec, input)
+	if err != nil {
+		return nil, LocateJSONError(input, err)
+	}
+	return node, nil
+}
+
+// readJSONNode lit la prochaine valeur complète du décodeur
+func readJSONNode(dec *json.Decoder, input string) (*jsonNode, error) {
+	start := dec.InputOffset()
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	raw := func() string {
+		return strings.TrimLeft(input[start:dec.InputOffset()], " \t\r\n,:")
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '[':
+			node := &jsonNode{Kind: jsonArray, Items: []*jsonNode{}}
+			for dec.More() {
+				item, err := readJSONNode(dec, input)
+				if err != nil {
+					return nil, err
+				}
+				node.Items = append(node.Items, item)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return node, nil
+		case '{':
+			node := &jsonNode{Kind: jsonObject, Members: []jsonMember{}}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ := keyTok.(string)
+				value, err := readJSONNode(dec, input)
+				if err != nil {
+					return nil, err
+				}
+				node.Members = append(node.Members, jsonMember{Key: key, Value: value})
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return node, nil
+		}
+		return nil, fmt.Errorf("délimiteur inattendu: %v", t)
+	case string:
+		return &jsonNode{Kind: jsonString, Str: t, Raw: raw()}, nil
+	case json.Number:
+		return &jsonNode{Kind: jsonNumber, Raw: t.String()}, nil
+	case bool:
+		return &jsonNode{Kind: jsonBool, Bool: t}, nil
+	case nil:
+		return &jsonNode{Kind: jsonNull}, nil
+	}
+	return nil, fmt.Errorf("jeton inattendu: %v", tok)
+}
+
+// Constructeurs utilisés par les conversions vers JSON
+
+func newJSONString(s string) *jsonNode {
+	return &jsonNode{Kind: jsonString, Str: s}
+}
+
+func newJSONNumber(literal string) *jsonNode {
+	return &jsonNode{Kind: jsonNumber, Raw: literal}
+}
+
+func newJSONBool(b bool) *jsonNode {
+	return &jsonNode{Kind: jsonBool, Bool: b}
+}
+
+func newJSONNull() *jsonNode {
+	return &jsonNode{Kind: jsonNull}
+}
+
+func newJSONArray(items ...*jsonNode) *jsonNode {
+	return &jsonNode{Kind: jsonArray, Items: append([]*jsonNode{}, items...)}
+}
+
+func newJSONObject() *jsonNode {
+	return &jsonNode{Kind: jsonObject, Members: []jsonMember{}}
+}
+
+// Get retourne la valeur associée à une clé d'objet (la dernière en cas de doublon)
+func (n *jsonNode) Get(key string) (*jsonNode, bool) {
+	if n.Kind != jsonObject {
+		return nil, false
+	}
+	for i := len(n.Members) - 1; i >= 0; i-- {
+		if n.Members[i].Key == key {
+			return n.Members[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// Set remplace la valeur d'une clé d'objet, ou l'ajoute à la fin
+func (n *jsonNode) Set(key string, value *jsonNode) {
+	for i := range n.Members {
+		if n.Members[i].Key == key {
+			n.Members[i].Value = value
+			return
+		}
+	}
+	n.Members = append(n.Members, jsonMember{Key: key, Value: value})
+}
+
+// Clone retourne une copie profonde de la valeur
+func (n *jsonNode) Clone() *jsonNode {
+	c := *n
+	if n.Items != nil {
+		c.Items = make([]*jsonNode, len(n.Items))
+		for i, item := range n.Items {
+			c.Items[i] = item.Clone()
+		}
+	}
+	if n.Members != nil {
+		c.Members = make([]jsonMember, len(n.Members))
+		for i, m := range n.Members {
+			c.Members[i] = jsonMember{Key: m.Key, Value: m.Value.Clone()}
+		}
+	}
+	return &c
+}
+
+// SortKeys trie récursivement les clés des objets
+func (n *jsonNode) SortKeys(less func(a, b string) bool) {
+	switch n.Kind {
+	case jsonObject:
+		sort.SliceStable(n.Members, func(i, j int) bool {
+			return less(n.Members[i].Key, n.Members[j].Key)
+		})
+		for _, m := range n.Members {
+			m.Value.SortKeys(less)
+		}
+	case jsonArray:
+		for _, item := range n.Items {
+			item.SortKeys(less)
+		}
+	}
+}
+
+// lessUTF16 compare deux chaînes selon leurs unités de code UTF-16 (ordre imposé par RFC 8785)
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
+
+// Scalar retourne la valeur Go d'un scalaire (string, json.Number, bool, nil)
+func (n *jsonNode) Scalar() interface{} {
+	switch n.Kind {
+	case jsonString:
+		return n.Str
+	case jsonNumber:
+		return json.Number(n.Raw)
+	case jsonBool:
+		return n.Bool
+	}
+	return nil
+}
+
+// String retourne la représentation textuelle d'un scalaire (chaîne brute sans guillemets)
+// ou le JSON compact d'un objet/tableau
+func (n *jsonNode) String() string {
+	switch n.Kind {
+	case jsonString:
+		return n.Str
+	case jsonNumber:
+		return n.Raw
+	case jsonBool:
+		return strconv.FormatBool(n.Bool)
+	case jsonNull:
+		return "null"
+	}
+	var buf bytes.Buffer
+	n.Write(&buf, "", false)
+	return buf.String()
+}
+
+// Write écrit la valeur en JSON. Un indent vide produit une sortie compacte (minifiée).
+func (n *jsonNode) Write(w io.Writer, indent string, escapeHTML bool) {
+	var buf bytes.Buffer
+	n.write(&buf, indent, 0, escapeHTML)
+	buf.WriteTo(w)
+}
+
+func (n *jsonNode) write(buf *bytes.Buffer, indent string, depth int, escapeHTML bool) {
+	newline := func(level int) {
+		if indent == "" {
+			return
+		}
+		buf.WriteByte('\n')
+		for i := 0; i < level; i++ {
+			buf.WriteString(indent)
+		}
+	}
+
+	switch n.Kind {
+	case jsonNull:
+		buf.WriteString("null")
+	case jsonBool:
+		buf.WriteString(strconv.FormatBool(n.Bool))
+	case jsonNumber:
+		buf.WriteString(n.Raw)
+	case jsonString:
+		buf.WriteString(encodeJSONString(n, escapeHTML))
+	case jsonArray:
+		buf.WriteByte('[')
+		for i, item := range n.Items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			newline(depth + 1)
+			item.write(buf, indent, depth+1, escapeHTML)
+		}
+		if len(n.Items) > 0 {
+			newline(depth)
+		}
+		buf.WriteByte(']')
+	case jsonObject:
+		buf.WriteByte('{')
+		for i, m := range n.Members {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			newline(depth + 1)
+			buf.WriteString(encodeJSONString(newJSONString(m.Key), escapeHTML))
+			buf.WriteByte(':')
+			if indent != "" {
+				buf.WriteByte(' ')
+			}
+			m.Value.write(buf, indent, depth+1, escapeHTML)
+		}
+		if len(n.Members) > 0 {
+			newline(depth)
+		}
+		buf.WriteByte('}')
+	}
+}
+
+// encodeJSONString retourne le littéral d'une chaîne: celui de l'entrée s'il existe, sinon un encodage standard
+func encodeJSONString(n *jsonNode, escapeHTML bool) string {
+	raw := n.Raw
+	if raw == "" {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.Encode(n.Str)
+		raw = strings.TrimSuffix(buf.String(), "\n")
+	}
+	if escapeHTML {
+		var escaped bytes.Buffer
+		json.HTMLEscape(&escaped, []byte(raw))
+		raw = escaped.String()
+	}
+	return raw
+}
+
+// WriteCanonical écrit la valeur selon la canonicalisation JSON (RFC 8785, JCS):
+// clés triées par unités UTF-16, aucun espace, nombres et chaînes sérialisés comme en ECMAScript
+func (n *jsonNode) WriteCanonical(buf *bytes.Buffer) error {
+	switch n.Kind {
+	case jsonNull:
+		buf.WriteString("null")
+	case jsonBool:
+		buf.WriteString(strconv.FormatBool(n.Bool))
+	case jsonNumber:
+		s, err := canonicalNumber(n.Raw)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(s)
+	case jsonString:
+		writeCanonicalString(buf, n.Str)
+	case jsonArray:
+		buf.WriteByte('[')
+		for i, item := range n.Items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := item.WriteCanonical(buf); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case jsonObject:
+		members := append([]jsonMember(nil), n.Members...)
+		sort.SliceStable(members, func(i, j int) bool {
+			return lessUTF16(members[i].Key, members[j].Key)
+		})
+		buf.WriteByte('{')
+		for i, m := range members {
+			if i > 0 {
+				if members[i-1].Key == m.Key {
+					return fmt.Errorf("clé en double: %q", m.Key)
+				}
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, m.Key)
+			buf.WriteByte(':')
+			if err := m.Value.WriteCanonical(buf); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	}
+	return nil
+}
+
+// writeCanonicalString échappe uniquement ", \ et les caractères de contrôle
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// canonicalNumber sérialise un nombre comme Number.prototype.toString en ECMAScript
+func canonicalNumber(literal string) (string, error) {
+	f, err := strconv.ParseFloat(literal, 64)
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return "", fmt.Errorf("nombre non représentable en double précision: %s", literal)
+	}
+	if f == 0 {
+		return "0", nil
+	}
+
+	sign := ""
+	if f < 0 {
+		sign = "-"
+		f = -f
+	}
+
+	// Plus courte représentation: chiffres significatifs et exposant décimal
+	mantissa, expPart, _ := strings.Cut(strconv.FormatFloat(f, 'e', -1, 64), "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	exp, _ := strconv.Atoi(expPart)
+	k := len(digits)
+	n := exp + 1 // Position de la virgule décimale
+
+	var out string
+	switch {
+	case k <= n && n <= 21:
+		out = digits + strings.Repeat("0", n-k)
+	case 0 < n && n <= 21:
+		out = digits[:n] + "." + digits[n:]
+	case -6 < n && n <= 0:
+		out = "0." + strings.Repeat("0", -n) + digits
+	default:
+		out = digits[:1]
+		if k > 1 {
+			out += "." + digits[1:]
+		}
+		e := n - 1
+		if e >= 0 {
+			out += "e+" + strconv.Itoa(e)
+		} else {
+			out += "e-" + strconv.Itoa(-e)
+		}
+	}
+	return sign + out, nil
+}
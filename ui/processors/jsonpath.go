@@ -0,0 +1,873 @@
+package processors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Langage de requête JSON: sous-ensemble de JSONPath (RFC 9535) compatible avec la notation jq
+//
+//	$.store.book[0].title      accès aux champs et indices ($ est facultatif: .store.book)
+//	$.items[-1], $.items[1:3]  indices négatifs et tranches [début:fin:pas]
+//	$.items[*].name, $.*       jokers
+//	$..price                   descente récursive
+//	$.items[0,2], $['a','b']   unions
+//	$.items[?@.price < 10]     filtres (==, !=, <, <=, >, >=, &&, ||, !, parenthèses, existence)
+//	$.items[*].{name, prix: @.price.value}  projections vers un nouvel objet
+
+// QueryError erreur de syntaxe d'une expression de requête
+type QueryError struct {
+	Expression string
+	Pos        int // Position en octets dans l'expression
+	Msg        string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("requête invalide (position %d): %s", utf8.RuneCountInString(e.Expression[:e.Pos])+1, e.Msg)
+}
+
+// Snippet retourne l'expression suivie d'un caret sous la position de l'erreur
+func (e *QueryError) Snippet() string {
+	return snippetWithCaret(e.Expression, e.Expression[:e.Pos])
+}
+
+func (e *QueryError) Pretty() string {
+	return fmt.Sprintf("Erreur de requête: %v\n\n%s", e, e.Snippet())
+}
+
+// JSONQuery requête compilée
+type JSONQuery struct {
+	expression string
+	segments   []querySegment
+}
+
+// querySegment étape de navigation: sélecteurs appliqués aux enfants (ou à tous les descendants)
+type querySegment struct {
+	recursive  bool
+	selectors  []querySelector
+	projection []projectionField // Non vide: le segment construit un objet à partir de chaque nœud
+}
+
+type projectionField struct {
+	key  string
+	path []querySegment // Relatif au nœud courant
+}
+
+// querySelector sélectionne des nœuds à partir d'un nœud
+type querySelector interface {
+	selectNodes(ctx context.Context, node, root *jsonNode, out []*jsonNode) []*jsonNode
+}
+
+type nameSelector struct{ name string }
+type wildcardSelector struct{}
+type indexSelector struct{ index int }
+type sliceSelector struct{ start, end, step *int }
+type filterSelector struct{ expr filterExpr }
+
+// CompileJSONQuery analyse une expression de requête
+func CompileJSONQuery(expression string) (*JSONQuery, error) {
+	p := &queryParser{input: expression}
+	p.skipSpaces()
+	if p.peek() == '$' {
+		p.pos++
+	}
+	segments, err := p.parseSegments(false)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if !p.eof() {
+		return nil, p.errorf("caractère inattendu %q", p.peek())
+	}
+	return &JSONQuery{expression: expression, segments: segments}, nil
+}
+
+// IsSingular indique si la requête désigne au plus un nœud: chaque segment est un nom ou un
+// indice seul (ou une projection). Les jokers, tranches, filtres, unions et descentes
+// récursives peuvent sélectionner plusieurs nœuds.
+func (q *JSONQuery) IsSingular() bool {
+	for _, seg := range q.segments {
+		if len(seg.projection) > 0 {
+			continue
+		}
+		if seg.recursive || len(seg.selectors) != 1 {
+			return false
+		}
+		switch seg.selectors[0].(type) {
+		case nameSelector, indexSelector:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluate applique la requête au document et retourne les nœuds sélectionnés dans l'ordre du document
+func (q *JSONQuery) Evaluate(root *jsonNode) []*jsonNode {
+	return evaluateSegments(context.Background(), q.segments, root, root)
+}
+
+// EvaluateContext applique la requête comme Evaluate et s'interrompt si le contexte est annulé
+func (q *JSONQuery) EvaluateContext(ctx context.Context, root *jsonNode) ([]*jsonNode, error) {
+	nodes := evaluateSegments(ctx, q.segments, root, root)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// evaluateSegments applique les segments à partir de current. La sélection s'arrête, incomplète,
+// si le contexte est annulé.
+func evaluateSegments(ctx context.Context, segments []querySegment, current, root *jsonNode) []*jsonNode {
+	nodes := []*jsonNode{current}
+	for _, seg := range segments {
+		var next []*jsonNode
+		for _, node := range nodes {
+			if len(seg.projection) > 0 {
+				next = append(next, project(ctx, seg.projection, node, root))
+				continue
+			}
+			targets := []*jsonNode{node}
+			if seg.recursive {
+				targets = descendants(node, nil)
+			}
+			for _, target := range targets {
+				if cancelled(ctx) {
+					return nil
+				}
+				for _, sel := range seg.selectors {
+					next = sel.selectNodes(ctx, target, root, next)
+				}
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// descendants retourne le nœud et tous ses descendants en ordre préfixe
+func descendants(node *jsonNode, out []*jsonNode) []*jsonNode {
+	out = append(out, node)
+	switch node.Kind {
+	case jsonArray:
+		for _, item := range node.Items {
+			out = descendants(item, out)
+		}
+	case jsonObject:
+		for _, m := range node.Members {
+			out = descendants(m.Value, out)
+		}
+	}
+	return out
+}
+
+// children retourne les valeurs d'un objet ou les éléments d'un tableau
+func children(node *jsonNode) []*jsonNode {
+	switch node.Kind {
+	case jsonArray:
+		return node.Items
+	case jsonObject:
+		values := make([]*jsonNode, len(node.Members))
+		for i, m := range node.Members {
+			values[i] = m.Value
+		}
+		return values
+	}
+	return nil
+}
+
+func project(ctx context.Context, fields []projectionField, node, root *jsonNode) *jsonNode {
+	obj := newJSONObject()
+	for _, field := range fields {
+		value := newJSONNull()
+		if found := evaluateSegments(ctx, field.path, node, root); len(found) > 0 {
+			value = found[0]
+		}
+		obj.Members = append(obj.Members, jsonMember{Key: field.key, Value: value})
+	}
+	return obj
+}
+
+func (s nameSelector) selectNodes(_ context.Context, node, _ *jsonNode, out []*jsonNode) []*jsonNode {
+	if value, ok := node.Get(s.name); ok {
+		out = append(out, value)
+	}
+	return out
+}
+
+func (wildcardSelector) selectNodes(_ context.Context, node, _ *jsonNode, out []*jsonNode) []*jsonNode {
+	return append(out, children(node)...)
+}
+
+func (s indexSelector) selectNodes(_ context.Context, node, _ *jsonNode, out []*jsonNode) []*jsonNode {
+	if node.Kind != jsonArray {
+		return out
+	}
+	i := s.index
+	if i < 0 {
+		i += len(node.Items)
+	}
+	if i >= 0 && i < len(node.Items) {
+		out = append(out, node.Items[i])
+	}
+	return out
+}
+
+// selectNodes suit l'algorithme des tranches de RFC 9535 (pas négatif compris)
+func (s sliceSelector) selectNodes(_ context.Context, node, _ *jsonNode, out []*jsonNode) []*jsonNode {
+	if node.Kind != jsonArray {
+		return out
+	}
+	n := len(node.Items)
+	step := 1
+	if s.step != nil {
+		step = *s.step
+	}
+	normalize := func(i int) int {
+		if i < 0 {
+			return n + i
+		}
+		return i
+	}
+	clamp := func(i, lo, hi int) int {
+		return max(lo, min(i, hi))
+	}
+
+	switch {
+	case step > 0:
+		start, end := 0, n
+		if s.start != nil {
+			start = normalize(*s.start)
+		}
+		if s.end != nil {
+			end = normalize(*s.end)
+		}
+		for i := clamp(start, 0, n); i < clamp(end, 0, n); i += step {
+			out = append(out, node.Items[i])
+		}
+	case step < 0:
+		start, end := n-1, -n-1
+		if s.start != nil {
+			start = normalize(*s.start)
+		}
+		if s.end != nil {
+			end = normalize(*s.end)
+		}
+		lower := clamp(end, -1, n-1)
+		for i := clamp(start, -1, n-1); i > lower; i += step {
+			out = append(out, node.Items[i])
+		}
+	}
+	return out
+}
+
+func (s filterSelector) selectNodes(ctx context.Context, node, root *jsonNode, out []*jsonNode) []*jsonNode {
+	for _, child := range children(node) {
+		if cancelled(ctx) {
+			return out
+		}
+		if s.expr.test(ctx, child, root) {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+// Expressions de filtre
+
+type filterExpr interface {
+	test(ctx context.Context, current, root *jsonNode) bool
+}
+
+type orExpr struct{ left, right filterExpr }
+type andExpr struct{ left, right filterExpr }
+type notExpr struct{ expr filterExpr }
+
+// existsExpr vrai si le chemin sélectionne au moins un nœud
+type existsExpr struct{ operand filterOperand }
+
+type comparisonExpr struct {
+	op          string
+	left, right filterOperand
+}
+
+// filterOperand valeur littérale ou chemin (relatif à @ ou absolu depuis $)
+type filterOperand struct {
+	literal  *jsonNode
+	relative bool
+	path     []querySegment
+}
+
+func (e orExpr) test(ctx context.Context, c, r *jsonNode) bool {
+	return e.left.test(ctx, c, r) || e.right.test(ctx, c, r)
+}
+func (e andExpr) test(ctx context.Context, c, r *jsonNode) bool {
+	return e.left.test(ctx, c, r) && e.right.test(ctx, c, r)
+}
+func (e notExpr) test(ctx context.Context, c, r *jsonNode) bool { return !e.expr.test(ctx, c, r) }
+
+func (e existsExpr) test(ctx context.Context, c, r *jsonNode) bool {
+	_, ok := e.operand.value(ctx, c, r)
+	return ok
+}
+
+// value retourne le premier nœud désigné par l'opérande
+func (o filterOperand) value(ctx context.Context, current, root *jsonNode) (*jsonNode, bool) {
+	if o.literal != nil {
+		return o.literal, true
+	}
+	start := root
+	if o.relative {
+		start = current
+	}
+	found := evaluateSegments(ctx, o.path, start, root)
+	if len(found) == 0 {
+		return nil, false
+	}
+	return found[0], true
+}
+
+func (e comparisonExpr) test(ctx context.Context, current, root *jsonNode) bool {
+	left, leftOK := e.left.value(ctx, current, root)
+	right, rightOK := e.right.value(ctx, current, root)
+	if !leftOK || !rightOK {
+		// Deux chemins vides sont égaux, un chemin vide n'est égal à aucune valeur
+		switch e.op {
+		case "==", "<=", ">=":
+			return !leftOK && !rightOK
+		case "!=":
+			return leftOK != rightOK
+		}
+		return false
+	}
+
+	switch e.op {
+	case "==":
+		return jsonEqual(left, right)
+	case "!=":
+		return !jsonEqual(left, right)
+	}
+
+	cmp, ok := compareJSON(left, right)
+	if !ok {
+		return false
+	}
+	switch e.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// jsonEqual compare deux valeurs en profondeur (les nombres par leur valeur)
+func jsonEqual(a, b *jsonNode) bool {
+	if a.Kind != b.Kind {
+		return false
+	}
+	switch a.Kind {
+	case jsonNull:
+		return true
+	case jsonBool:
+		return a.Bool == b.Bool
+	case jsonNumber:
+		cmp, _ := compareJSON(a, b)
+		return cmp == 0
+	case jsonString:
+		return a.Str == b.Str
+	case jsonArray:
+		if len(a.Items) != len(b.Items) {
+			return false
+		}
+		for i := range a.Items {
+			if !jsonEqual(a.Items[i], b.Items[i]) {
+				return false
+			}
+		}
+		return true
+	case jsonObject:
+		if len(a.Members) != len(b.Members) {
+			return false
+		}
+		for _, m := range a.Members {
+			other, ok := b.Get(m.Key)
+			if !ok || !jsonEqual(m.Value, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// compareJSON ordonne deux nombres ou deux chaînes; ok est faux pour les autres combinaisons
+func compareJSON(a, b *jsonNode) (int, bool) {
+	switch {
+	case a.Kind == jsonNumber && b.Kind == jsonNumber:
+		x, errX := strconv.ParseFloat(a.Raw, 64)
+		y, errY := strconv.ParseFloat(b.Raw, 64)
+		if errX != nil || errY != nil {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case a.Kind == jsonString && b.Kind == jsonString:
+		return strings.Compare(a.Str, b.Str), true
+	}
+	return 0, false
+}
+
+// Analyseur syntaxique
+
+type queryParser struct {
+	input string
+	pos   int
+}
+
+func (p *queryParser) errorf(format string, args ...interface{}) error {
+	return &QueryError{Expression: p.input, Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *queryParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *queryParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *queryParser) skipSpaces() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t' || p.peek() == '\n' || p.peek() == '\r') {
+		p.pos++
+	}
+}
+
+func (p *queryParser) consume(s string) bool {
+	if strings.HasPrefix(p.input[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+// parseSegments lit une suite de segments .nom, [..], ..nom. Dans un filtre (inFilter),
+// les espaces ne sont pas autorisés entre segments pour ne pas absorber l'opérateur suivant.
+func (p *queryParser) parseSegments(inFilter bool) ([]querySegment, error) {
+	var segments []querySegment
+	for {
+		save := p.pos
+		if !inFilter {
+			p.skipSpaces()
+		}
+		switch {
+		case p.consume(".."):
+			seg, err := p.parseDotMember(true)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+		case p.consume("."):
+			seg, err := p.parseDotMember(false)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+		case p.peek() == '[':
+			selectors, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, querySegment{selectors: selectors})
+		default:
+			p.pos = save
+			return segments, nil
+		}
+	}
+}
+
+// parseDotMember lit ce qui suit . ou .. : un nom, *, une projection {..} ou des crochets
+func (p *queryParser) parseDotMember(recursive bool) (querySegment, error) {
+	seg := querySegment{recursive: recursive}
+	switch {
+	case p.consume("*"):
+		seg.selectors = []querySelector{wildcardSelector{}}
+	case recursive && p.peek() == '[':
+		selectors, err := p.parseBracket()
+		if err != nil {
+			return seg, err
+		}
+		seg.selectors = selectors
+	case !recursive && p.peek() == '{':
+		fields, err := p.parseProjection()
+		if err != nil {
+			return seg, err
+		}
+		seg.projection = fields
+	default:
+		name, ok := p.parseName()
+		if !ok {
+			return seg, p.errorf("nom de champ attendu")
+		}
+		seg.selectors = []querySelector{nameSelector{name: name}}
+	}
+	return seg, nil
+}
+
+// parseName lit un identifiant (lettres, chiffres, _ et caractères non ASCII)
+func (p *queryParser) parseName() (string, bool) {
+	start := p.pos
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		isStart := r == '_' || unicode.IsLetter(r) || r >= 0x80
+		if !isStart && (p.pos == start || !unicode.IsDigit(r)) {
+			break
+		}
+		p.pos += size
+	}
+	return p.input[start:p.pos], p.pos > start
+}
+
+// parseBracket lit [sélecteur, sélecteur...]
+func (p *queryParser) parseBracket() ([]querySelector, error) {
+	p.pos++ // [
+	var selectors []querySelector
+	for {
+		p.skipSpaces()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+		p.skipSpaces()
+		if p.consume(",") {
+			continue
+		}
+		if p.consume("]") {
+			return selectors, nil
+		}
+		return nil, p.errorf("',' ou ']' attendu")
+	}
+}
+
+func (p *queryParser) parseSelector() (querySelector, error) {
+	switch c := p.peek(); {
+	case c == '*':
+		p.pos++
+		return wildcardSelector{}, nil
+	case c == '\'' || c == '"':
+		name, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return nameSelector{name: name}, nil
+	case c == '?':
+		p.pos++
+		p.skipSpaces()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return filterSelector{expr: expr}, nil
+	case c == '-' || c == ':' || (c >= '0' && c <= '9'):
+		return p.parseIndexOrSlice()
+	}
+	return nil, p.errorf("sélecteur attendu (nom entre guillemets, indice, tranche, * ou filtre ?)")
+}
+
+func (p *queryParser) parseIndexOrSlice() (querySelector, error) {
+	var parts [3]*int
+	part := 0
+	for {
+		p.skipSpaces()
+		if n, ok, err := p.parseInt(); err != nil {
+			return nil, err
+		} else if ok {
+			parts[part] = &n
+		}
+		p.skipSpaces()
+		if p.peek() != ':' {
+			break
+		}
+		if part == 2 {
+			return nil, p.errorf("tranche invalide: trop de ':'")
+		}
+		p.pos++
+		part++
+	}
+	if part == 0 {
+		if parts[0] == nil {
+			return nil, p.errorf("indice attendu")
+		}
+		return indexSelector{index: *parts[0]}, nil
+	}
+	return sliceSelector{start: parts[0], end: parts[1], step: parts[2]}, nil
+}
+
+func (p *queryParser) parseInt() (int, bool, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	if p.pos == start {
+		return 0, false, nil
+	}
+	n, err := strconv.Atoi(p.input[start:p.pos])
+	if err != nil {
+		p.pos = start
+		return 0, false, p.errorf("entier invalide")
+	}
+	return n, true, nil
+}
+
+// parseString lit une chaîne entre guillemets simples ou doubles (échappements JSON)
+func (p *queryParser) parseString() (string, error) {
+	quote := p.peek()
+	start := p.pos
+	p.pos++
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			p.pos = start
+			return "", p.errorf("chaîne non terminée")
+		}
+		c := p.peek()
+		switch {
+		case c == quote:
+			p.pos++
+			return sb.String(), nil
+		case c == '\\':
+			p.pos++
+			esc := p.peek()
+			switch esc {
+			case '\'', '"', '\\', '/':
+				sb.WriteByte(esc)
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'u':
+				if p.pos+5 > len(p.input) {
+					return "", p.errorf("séquence \\u incomplète")
+				}
+				code, err := strconv.ParseUint(p.input[p.pos+1:p.pos+5], 16, 32)
+				if err != nil {
+					return "", p.errorf("séquence \\u invalide")
+				}
+				sb.WriteRune(rune(code))
+				p.pos += 4
+			default:
+				return "", p.errorf("échappement inconnu \\%c", esc)
+			}
+			p.pos++
+		default:
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+// parseProjection lit {champ, alias: chemin, "clé": @.chemin}
+func (p *queryParser) parseProjection() ([]projectionField, error) {
+	p.pos++ // {
+	var fields []projectionField
+	for {
+		p.skipSpaces()
+		var key string
+		if p.peek() == '\'' || p.peek() == '"' {
+			k, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			key = k
+		} else if k, ok := p.parseName(); ok {
+			key = k
+		} else {
+			return nil, p.errorf("nom de champ attendu dans la projection")
+		}
+
+		field := projectionField{key: key, path: []querySegment{{selectors: []querySelector{nameSelector{name: key}}}}}
+		p.skipSpaces()
+		if p.consume(":") {
+			p.skipSpaces()
+			path, err := p.parseProjectionPath()
+			if err != nil {
+				return nil, err
+			}
+			field.path = path
+		}
+		fields = append(fields, field)
+
+		p.skipSpaces()
+		if p.consume(",") {
+			continue
+		}
+		if p.consume("}") {
+			return fields, nil
+		}
+		return nil, p.errorf("',' ou '}' attendu")
+	}
+}
+
+// parseProjectionPath lit un chemin relatif: @.a.b, ou a.b (équivalent)
+func (p *queryParser) parseProjectionPath() ([]querySegment, error) {
+	if p.consume("@") {
+		return p.parseSegments(false)
+	}
+	name, ok := p.parseName()
+	if !ok {
+		return nil, p.errorf("chemin attendu après ':'")
+	}
+	rest, err := p.parseSegments(false)
+	if err != nil {
+		return nil, err
+	}
+	return append([]querySegment{{selectors: []querySelector{nameSelector{name: name}}}}, rest...), nil
+}
+
+func (p *queryParser) parseOr() (filterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !p.consume("||") {
+			return left, nil
+		}
+		p.skipSpaces()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left: left, right: right}
+	}
+}
+
+func (p *queryParser) parseAnd() (filterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpaces()
+		if !p.consume("&&") {
+			return left, nil
+		}
+		p.skipSpaces()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left: left, right: right}
+	}
+}
+
+func (p *queryParser) parseUnary() (filterExpr, error) {
+	p.skipSpaces()
+	if p.peek() == '!' && !strings.HasPrefix(p.input[p.pos:], "!=") {
+		p.pos++
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr: expr}, nil
+	}
+	if p.consume("(") {
+		p.skipSpaces()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.consume(")") {
+			return nil, p.errorf("')' attendu")
+		}
+		return expr, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			p.skipSpaces()
+			right, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			return comparisonExpr{op: op, left: left, right: right}, nil
+		}
+	}
+	if left.literal != nil {
+		return nil, p.errorf("opérateur de comparaison attendu")
+	}
+	return existsExpr{operand: left}, nil
+}
+
+func (p *queryParser) parseOperand() (filterOperand, error) {
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		p.pos++
+		path, err := p.parseSegments(true)
+		if err != nil {
+			return filterOperand{}, err
+		}
+		return filterOperand{relative: c == '@', path: path}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return filterOperand{}, err
+		}
+		return filterOperand{literal: newJSONString(s)}, nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := p.pos
+		p.pos++
+		for !p.eof() && strings.IndexByte("0123456789.eE+-", p.peek()) >= 0 {
+			p.pos++
+		}
+		literal := p.input[start:p.pos]
+		if !json.Valid([]byte(literal)) {
+			p.pos = start
+			return filterOperand{}, p.errorf("nombre invalide %q", literal)
+		}
+		return filterOperand{literal: newJSONNumber(literal)}, nil
+	}
+	switch {
+	case p.consume("true"):
+		return filterOperand{literal: newJSONBool(true)}, nil
+	case p.consume("false"):
+		return filterOperand{literal: newJSONBool(false)}, nil
+	case p.consume("null"):
+		return filterOperand{literal: newJSONNull()}, nil
+	}
+	return filterOperand{}, p.errorf("valeur attendue (@, $, nombre, chaîne, true, false ou null)")
+}
@@ -0,0 +1,205 @@
+package processors
+
+import (
+	"errors"
+	"testing"
+)
+
+const jsonPathStore = `{
+  "store": {
+    "book": [
+      {"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+      {"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+      {"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+      {"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+    ],
+    "bicycle": {"color": "red", "price": 399}
+  },
+  "a.b": 1,
+  "empty": [],
+  "flags": [true, false, null, 0, ""]
+}`
+
+func TestJSONQuerySelectors(t *testing.T) {
+	tests := []jsonQueryTest{
+		{"objet", "$.store.bicycle", `[{"color":"red","price":399}]`},
+		{"champ", "$.store.bicycle.color", `["red"]`},
+		{"$ facultatif", ".store.bicycle.color", `["red"]`},
+		{"crochets", "$['store']['bicycle']['price']", `[399]`},
+		{"clé avec un point", "$['a.b']", `[1]`},
+		{"champ absent", "$.store.car", `[]`},
+		{"indice", "$.store.book[0].author", `["Nigel Rees"]`},
+		{"indice négatif", "$.store.book[-1].author", `["J. R. R. Tolkien"]`},
+		{"indice hors limites", "$.store.book[10]", `[]`},
+		{"tranche", "$.store.book[1:3].price", `[12.99,8.99]`},
+		{"tranche ouverte", "$.store.book[2:].price", `[8.99,22.99]`},
+		{"tranche avec pas", "$.store.book[::2].price", `[8.95,8.99]`},
+		{"tranche à pas négatif", "$.store.book[::-1].price", `[22.99,8.99,12.99,8.95]`},
+		{"tranche de pas nul", "$.store.book[::0]", `[]`},
+		{"joker", "$.store.book[*].author",
+			`["Nigel Rees","Evelyn Waugh","Herman Melville","J. R. R. Tolkien"]`},
+		{"joker d'objet", "$.store.bicycle.*", `["red",399]`},
+		{"union d'indices", "$.store.book[0,2].title", `["Sayings of the Century","Moby Dick"]`},
+		{"union de noms", "$.store.bicycle['color','price']", `["red",399]`},
+		{"descente récursive", "$..isbn", `["0-553-21311-3","0-395-19395-8"]`},
+		{"descente récursive dans l'ordre du document", "$..price", `[8.95,12.99,8.99,22.99,399]`},
+		{"tableau vide", "$.empty[*]", `[]`},
+	}
+	runJSONQueryTests(t, tests)
+}
+
+func TestJSONQueryFilters(t *testing.T) {
+	tests := []jsonQueryTest{
+		{"comparaison numérique", "$.store.book[?@.price < 10].title", `["Sayings of the Century","Moby Dick"]`},
+		{"égalité de chaînes", "$.store.book[?@.category == 'reference'].author", `["Nigel Rees"]`},
+		{"différence", "$.store.book[?@.category != 'fiction'].price", `[8.95]`},
+		{"bornes incluses", "$.store.book[?@.price >= 12.99 && @.price <= 22.99].price", `[12.99,22.99]`},
+		{"ou", "$.store.book[?@.price > 20 || @.price < 9].price", `[8.95,8.99,22.99]`},
+		{"existence", "$.store.book[?@.isbn].title", `["Moby Dick","The Lord of the Rings"]`},
+		{"négation", "$.store.book[?!@.isbn].title", `["Sayings of the Century","Sword of Honour"]`},
+		{"parenthèses", "$.store.book[?!(@.price < 10 && @.category == 'fiction')].title",
+			`["Sayings of the Century","Sword of Honour","The Lord of the Rings"]`},
+		{"comparaison à un chemin absolu", "$.store.book[?@.price > $.store.book[2].price].price", `[12.99,22.99]`},
+		{"chaînes ordonnées", "$.store.book[?@.author < 'H'].author", `["Evelyn Waugh"]`},
+		{"types différents jamais égaux", "$.flags[?@ == 0]", `[0]`},
+		{"null", "$.flags[?@ == null]", `[null]`},
+		{"booléen", "$.flags[?@ == false]", `[false]`},
+		{"filtre sur un objet", "$.store[?@.color]", `[{"color":"red","price":399}]`},
+		{"filtre puis descente", "$..book[?@.price > 20].author", `["J. R. R. Tolkien"]`},
+	}
+	runJSONQueryTests(t, tests)
+}
+
+func TestJSONQueryProjections(t *testing.T) {
+	tests := []jsonQueryTest{
+		{"projection", "$.store.book[0].{title, price}", `[{"title":"Sayings of the Century","price":8.95}]`},
+		{"renommage et chemin", "$.store.book[-1].{auteur: @.author, isbn}",
+			`[{"auteur":"J. R. R. Tolkien","isbn":"0-395-19395-8"}]`},
+		{"champ absent projeté à null", "$.store.book[:2].{isbn}", `[{"isbn":null},{"isbn":null}]`},
+	}
+	runJSONQueryTests(t, tests)
+}
+
+// jsonQueryTest expression évaluée sur jsonPathStore et résultats attendus sous forme de
+// tableau JSON compact
+type jsonQueryTest struct {
+	name       string
+	expression string
+	want       string
+}
+
+func runJSONQueryTests(t *testing.T, tests []jsonQueryTest) {
+	t.Helper()
+	root, err := parseJSONTree(jsonPathStore)
+	if err != nil {
+		t.Fatalf("document de test invalide: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := CompileJSONQuery(tt.expression)
+			if err != nil {
+				t.Fatalf("CompileJSONQuery(%q): %v", tt.expression, err)
+			}
+			if got := newJSONArray(query.Evaluate(root)...).String(); got != tt.want {
+				t.Errorf("%s =\n%s\nattendu\n%s", tt.expression, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONQuerySyntaxErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantPos    int
+	}{
+		{"$.store.", 8},
+		{"$.store[", 8},
+		{"$.store['book'", 14},
+		{"$.store.book[0", 14},
+		{"$.store.book[?@.price <]", 23},
+		{"$.store.book[?@.price ~ 1]", 22},
+		{"$.store.book[0]]", 15},
+		{"$.store.book[1.5]", 14},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := CompileJSONQuery(tt.expression)
+			var queryErr *QueryError
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("CompileJSONQuery(%q) = %v, *QueryError attendue", tt.expression, err)
+			}
+			if queryErr.Pos != tt.wantPos {
+				t.Errorf("CompileJSONQuery(%q): position %d, attendu %d (%v)", tt.expression, queryErr.Pos, tt.wantPos, err)
+			}
+		})
+	}
+}
+
+func TestJSONQueryIsSingular(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"$", true},
+		{"$.store.bicycle.color", true},
+		{"$['store']['book'][0]", true},
+		{"$.store.book[-1].{title, price}", true},
+		{"$.store.book[*]", false},
+		{"$.store.book[0:1]", false},
+		{"$.store.book[0,1]", false},
+		{"$.store.book[?@.price < 10]", false},
+		{"$..isbn", false},
+		{"$.store.*", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			query, err := CompileJSONQuery(tt.expression)
+			if err != nil {
+				t.Fatalf("CompileJSONQuery(%q): %v", tt.expression, err)
+			}
+			if got := query.IsSingular(); got != tt.want {
+				t.Errorf("IsSingular(%q) = %v, attendu %v", tt.expression, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONQueryResultShape(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		rawOutput  bool
+		wrapArray  bool
+		want       string
+	}{
+		{"chemin singulier déballé", "$.store.bicycle.color", false, false, `"red"` + "\n"},
+		{"chemin singulier toujours en tableau", "$.store.bicycle.color", false, true, `["red"]` + "\n"},
+		{"chemin singulier sans résultat", "$.store.car", false, false, "[]\n"},
+		{"filtre à un seul résultat", "$.store.book[?@.category == 'reference'].price", false, false, "[8.95]\n"},
+		{"joker sans résultat", "$.empty[*]", false, false, "[]\n"},
+		{"tranche à un seul résultat", "$.store.book[0:1].price", false, false, "[8.95]\n"},
+		{"sortie brute", "$.store.book[:2].author", true, false, "Nigel Rees\nEvelyn Waugh\n"},
+		{"sortie brute de valeurs non textuelles", "$.store.bicycle.*", true, false, "red\n399\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewJSONQueryViewModel()
+			err := vm.LoadConfiguration(JSONQueryOptions{
+				Expression: tt.expression,
+				RawOutput:  tt.rawOutput,
+				WrapArray:  tt.wrapArray,
+				IndentType: IndentMinified,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := vm.Process(jsonPathStore)
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("%s = %q, attendu %q", tt.expression, got, tt.want)
+			}
+		})
+	}
+}
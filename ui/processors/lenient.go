@@ -0,0 +1,202 @@
+package processors
+
+import (
+	"errors"
+	"strings"
+)
+
+// Erreurs du mode permissif
+var (
+	ErrCommentNotAllowed  = errors.New("commentaire non autorisé")
+	ErrUnterminatedString = errors.New("chaîne non terminée")
+	ErrUnterminatedBlock  = errors.New("commentaire /* non terminé")
+)
+
+// NormalizeLenientJSON convertit du JSON « permissif » (JSONC, sous-ensemble de JSON5) en JSON strict:
+// commentaires // et /* */, virgules finales, chaînes entre guillemets simples et clés sans guillemets.
+// Si rejectComments est vrai, un commentaire provoque une erreur au lieu d'être supprimé.
+// Les erreurs sont des *JSONError localisées dans input.
+func NormalizeLenientJSON(input string, rejectComments bool) (string, error) {
+	normalized, _, err := normalizeLenientJSON(input, rejectComments)
+	return normalized, err
+}
+
+// normalizeLenientJSON retourne aussi, pour chaque octet du résultat, sa position dans input
+// afin de pouvoir localiser les erreurs détectées ensuite sur le texte normalisé
+func normalizeLenientJSON(input string, rejectComments bool) (string, []int, error) {
+	var out strings.Builder
+	origin := make([]int, 0, len(input))
+
+	emit := func(s string, from int) {
+		out.WriteString(s)
+		for range len(s) {
+			origin = append(origin, from)
+		}
+	}
+	fail := func(offset int, err error) (string, []int, error) {
+		jsonErr := newJSONError(input, int64(offset), err)
+		switch err {
+		case ErrCommentNotAllowed:
+			jsonErr.Hint = "les commentaires sont refusés: décochez « Refuser les commentaires » pour les supprimer automatiquement"
+		case ErrUnterminatedString:
+			jsonErr.Hint = "guillemet fermant manquant"
+		case ErrUnterminatedBlock:
+			jsonErr.Hint = "fermez le commentaire avec */"
+		}
+		return "", nil, jsonErr
+	}
+
+	// skipComment retourne la fin du commentaire commençant en i, ou i s'il n'y en a pas
+	skipComment := func(i int) (int, error) {
+		if i+1 >= len(input) || input[i] != '/' {
+			return i, nil
+		}
+		switch input[i+1] {
+		case '/':
+			end := strings.IndexByte(input[i:], '\n')
+			if end < 0 {
+				return len(input), nil
+			}
+			return i + end, nil
+		case '*':
+			end := strings.Index(input[i+2:], "*/")
+			if end < 0 {
+				return i, ErrUnterminatedBlock
+			}
+			return i + 2 + end + 2, nil
+		}
+		return i, nil
+	}
+
+	// nextSignificant retourne la position du prochain caractère hors espaces et commentaires
+	nextSignificant := func(i int) int {
+		for i < len(input) {
+			switch input[i] {
+			case ' ', '\t', '\r', '\n':
+				i++
+				continue
+			}
+			end, err := skipComment(i)
+			if err != nil || end == i {
+				return i
+			}
+			i = end
+		}
+		return i
+	}
+
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == '/' && i+1 < len(input) && (input[i+1] == '/' || input[i+1] == '*'):
+			if rejectComments {
+				return fail(i, ErrCommentNotAllowed)
+			}
+			end, err := skipComment(i)
+			if err != nil {
+				return fail(i, err)
+			}
+			// Conserver les retours à la ligne pour que les numéros de ligne restent proches de l'entrée
+			for j := i; j < end; j++ {
+				if input[j] == '\n' {
+					emit("\n", j)
+				}
+			}
+			i = end
+
+		case c == '"':
+			end := i + 1
+			for end < len(input) && input[end] != '"' {
+				if input[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(input) {
+				return fail(i, ErrUnterminatedString)
+			}
+			for j := i; j <= end; j++ {
+				emit(input[j:j+1], j)
+			}
+			i = end + 1
+
+		case c == '\'':
+			emit(`"`, i)
+			j := i + 1
+			for ; j < len(input) && input[j] != '\''; j++ {
+				switch input[j] {
+				case '\\':
+					if j+1 < len(input) && input[j+1] == '\'' {
+						emit("'", j)
+					} else if j+1 < len(input) {
+						emit(input[j:j+2], j)
+					}
+					j++
+				case '"':
+					emit(`\"`, j)
+				default:
+					emit(input[j:j+1], j)
+				}
+			}
+			if j >= len(input) {
+				return fail(i, ErrUnterminatedString)
+			}
+			emit(`"`, j)
+			i = j + 1
+
+		case c == ',':
+			// Virgule finale: ignorée si elle précède directement } ou ]
+			next := nextSignificant(i + 1)
+			if next < len(input) && (input[next] == '}' || input[next] == ']') {
+				i++
+				continue
+			}
+			emit(",", i)
+			i++
+
+		case isIdentifierStart(c):
+			end := i + 1
+			for end < len(input) && (isIdentifierStart(input[end]) || (input[end] >= '0' && input[end] <= '9')) {
+				end++
+			}
+			word := input[i:end]
+			next := nextSignificant(end)
+			if word != "true" && word != "false" && word != "null" && next < len(input) && input[next] == ':' {
+				// Clé sans guillemets
+				emit(`"`, i)
+				for j := i; j < end; j++ {
+					emit(input[j:j+1], j)
+				}
+				emit(`"`, end-1)
+			} else {
+				for j := i; j < end; j++ {
+					emit(input[j:j+1], j)
+				}
+			}
+			i = end
+
+		default:
+			emit(input[i:i+1], i)
+			i++
+		}
+	}
+
+	return out.String(), origin, nil
+}
+
+// relocateJSONError convertit une erreur détectée dans le texte normalisé en erreur localisée
+// dans l'entrée d'origine
+func relocateJSONError(input, normalized string, origin []int, err error) error {
+	var jsonErr *JSONError
+	if !errors.As(err, &jsonErr) {
+		return err
+	}
+
+	offset := len(input)
+	if jsonErr.Offset < int64(len(origin)) {
+		offset = origin[jsonErr.Offset]
+	}
+	relocated := newJSONError(input, int64(offset), jsonErr.Err)
+	relocated.Hint = jsonErrorHint(normalized, jsonErr.Offset, jsonErr.Err)
+	return relocated
+}
@@ -0,0 +1,90 @@
+package processors
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLenientJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string // Résultat minifié
+	}{
+		{"commentaires", "{\n  // ligne\n  \"a\": /* bloc */ 1\n}", `{"a":1}`},
+		{"commentaire multiligne", "[1, /* a\nb */ 2]", `[1,2]`},
+		{"virgules finales", `{"a": [1, 2, ], "b": {"c": 3,}, }`, `{"a":[1,2],"b":{"c":3}}`},
+		{"virgule finale suivie d'un commentaire", "[1, // fin\n]", `[1]`},
+		{"guillemets simples", `{'a': 'l\'« x »', 'b': 'dit "oui"'}`, `{"a":"l'« x »","b":"dit \"oui\""}`},
+		{"clés sans guillemets", `{a: 1, $b_2: true, _c: null}`, `{"a":1,"$b_2":true,"_c":null}`},
+		{"mots-clés en valeur", `{x: [true, false, null]}`, `{"x":[true,false,null]}`},
+		{"commentaire dans une chaîne", `{"url": "http://x/*y*/"}`, `{"url":"http://x/*y*/"}`},
+		{"JSON strict inchangé", `{"a": [1.50, "b"]}`, `{"a":[1.50,"b"]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter := NewFormatter(IndentMinified)
+			formatter.Lenient = true
+			got, err := formatter.FormatJSON(tt.input)
+			if err != nil {
+				t.Fatalf("FormatJSON: %v", err)
+			}
+			if got != tt.want+"\n" {
+				t.Errorf("FormatJSON = %q, attendu %q", got, tt.want+"\n")
+			}
+		})
+	}
+}
+
+func TestLenientJSONErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		rejectComments bool
+		wantErr        error // nil : erreur JSON détectée après normalisation
+		line, column   int
+	}{
+		{"commentaire refusé", "{\"a\": 1 // c\n}", true, ErrCommentNotAllowed, 1, 9},
+		{"bloc refusé", "[\n/* c */ 1]", true, ErrCommentNotAllowed, 2, 1},
+		{"chaîne non terminée", `{"a": "x}`, false, ErrUnterminatedString, 1, 7},
+		{"guillemet simple non terminé", `['x]`, false, ErrUnterminatedString, 1, 2},
+		{"commentaire non terminé", `[1 /* x`, false, ErrUnterminatedBlock, 1, 4},
+		{"erreur relocalisée", `{a: 1 2}`, false, nil, 1, 7},
+		{"erreur relocalisée après un commentaire", "/* c */ {a: tru}", false, nil, 1, 16},
+		{"erreur relocalisée sur une autre ligne", "{\n  // c\n  'a': :\n}", false, nil, 3, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter := NewFormatter(IndentMinified)
+			formatter.Lenient = true
+			formatter.RejectComments = tt.rejectComments
+			_, err := formatter.FormatJSON(tt.input)
+
+			var jsonErr *JSONError
+			if !errors.As(err, &jsonErr) {
+				t.Fatalf("erreur %v (%T), attendu une *JSONError", err, err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("erreur %v, attendu %v", err, tt.wantErr)
+			}
+			if jsonErr.Line != tt.line || jsonErr.Column != tt.column {
+				t.Errorf("position ligne %d, colonne %d, attendu ligne %d, colonne %d", jsonErr.Line, jsonErr.Column, tt.line, tt.column)
+			}
+		})
+	}
+}
+
+func TestNormalizeLenientJSONKeepsLines(t *testing.T) {
+	input := "{\n  /* a\n  b */\n  x: 1, // fin\n}"
+	normalized, err := NormalizeLenientJSON(input, false)
+	if err != nil {
+		t.Fatalf("NormalizeLenientJSON: %v", err)
+	}
+	if got, want := strings.Count(normalized, "\n"), strings.Count(input, "\n"); got != want {
+		t.Errorf("%d ligne(s) après normalisation, attendu %d: %q", got+1, want+1, normalized)
+	}
+	if err := ValidateJSON(normalized); err != nil {
+		t.Errorf("résultat %q invalide: %v", normalized, err)
+	}
+}
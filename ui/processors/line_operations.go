@@ -0,0 +1,276 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// Opérations sur les lignes
+const (
+	LineOpSort         = "Trier"
+	LineOpUnique       = "Dédoublonner"
+	LineOpReverse      = "Inverser l'ordre"
+	LineOpShuffle      = "Mélanger"
+	LineOpKeep         = "Garder les lignes correspondantes"
+	LineOpDrop         = "Supprimer les lignes correspondantes"
+	LineOpHead         = "Premières N lignes"
+	LineOpTail         = "Dernières N lignes"
+	LineOpSqueezeBlank = "Réduire les lignes vides consécutives"
+)
+
+// LineOperations liste les valeurs acceptées pour LineOperationsOptions.Operation
+var LineOperations = []string{
+	LineOpSort, LineOpUnique, LineOpReverse, LineOpShuffle, LineOpKeep, LineOpDrop,
+	LineOpHead, LineOpTail, LineOpSqueezeBlank,
+}
+
+// Ordres de tri des lignes
+const (
+	LineSortLexicographic = "Lexicographique"
+	LineSortNatural       = "Naturel"
+	LineSortNumeric       = "Numérique"
+	LineSortLocale        = "Linguistique (locale)"
+)
+
+// LineSortModes liste les valeurs acceptées pour LineOperationsOptions.SortMode
+var LineSortModes = []string{LineSortLexicographic, LineSortNatural, LineSortNumeric, LineSortLocale}
+
+// leadingNumber nombre en début de ligne pour le tri numérique (comme sort -n, avec exposant)
+var leadingNumber = regexp.MustCompile(`^\s*[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?`)
+
+// sortLines trie les lignes (tri stable). Le tri numérique utilise le nombre en début de
+// ligne, les lignes sans nombre sont placées à la fin dans leur ordre d'origine.
+// Si le contexte est annulé, le tri est abandonné et l'erreur du contexte retournée.
+func sortLines(ctx context.Context, lines []string, mode, locale string, ignoreCase, descending bool) error {
+	var compare func(a, b string) int
+	switch mode {
+	case LineSortNatural:
+		compare = func(a, b string) int {
+			if ignoreCase {
+				a, b = strings.ToLower(a), strings.ToLower(b)
+			}
+			return compareNatural(a, b)
+		}
+	case LineSortNumeric:
+		compare = func(a, b string) int {
+			x, okA := parseLeadingNumber(a)
+			y, okB := parseLeadingNumber(b)
+			switch {
+			case !okA || !okB:
+				return 0 // Placées à la fin ci-dessous
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	case LineSortLocale:
+		tag, err := parseLocale(locale)
+		if err != nil {
+			return err
+		}
+		var options []collate.Option
+		if ignoreCase {
+			options = append(options, collate.IgnoreCase)
+		}
+		collator := collate.New(tag, options...)
+		compare = collator.CompareString
+	default:
+		compare = func(a, b string) int {
+			if ignoreCase {
+				return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+			}
+			return strings.Compare(a, b)
+		}
+	}
+
+	// Après l'annulation, les comparaisons ne font plus rien: le tri se termine rapidement
+	// et son résultat est abandonné
+	compared, stopped := 0, false
+	sort.SliceStable(lines, func(i, j int) bool {
+		if compared++; compared%1024 == 0 && cancelled(ctx) {
+			stopped = true
+		}
+		if stopped {
+			return false
+		}
+		if mode == LineSortNumeric {
+			_, okA := parseLeadingNumber(lines[i])
+			_, okB := parseLeadingNumber(lines[j])
+			if okA != okB {
+				return okA
+			}
+		}
+		if descending {
+			return compare(lines[j], lines[i]) < 0
+		}
+		return compare(lines[i], lines[j]) < 0
+	})
+	return ctx.Err()
+}
+
+func parseLeadingNumber(line string) (float64, bool) {
+	match := leadingNumber.FindString(line)
+	if match == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(match), 64)
+	return f, err == nil
+}
+
+// parseLocale lit une étiquette de langue BCP 47 ("fr", "de-DE"...); vide: ordre Unicode
+// par défaut (DUCET)
+func parseLocale(locale string) (language.Tag, error) {
+	if strings.TrimSpace(locale) == "" {
+		return language.Und, nil
+	}
+	tag, err := language.Parse(strings.TrimSpace(locale))
+	if err != nil {
+		return language.Und, fmt.Errorf("locale invalide %q: %w", locale, err)
+	}
+	return tag, nil
+}
+
+// compareNatural compare deux chaînes en traitant les suites de chiffres comme des nombres
+// ("fichier2" avant "fichier10"). À valeur égale, le nombre avec le moins de zéros en tête
+// est placé en premier.
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		ra, sizeA := utf8.DecodeRuneInString(a)
+		rb, sizeB := utf8.DecodeRuneInString(b)
+		if isASCIIDigit(ra) && isASCIIDigit(rb) {
+			numA, restA := splitDigits(a)
+			numB, restB := splitDigits(b)
+			trimmedA, trimmedB := strings.TrimLeft(numA, "0"), strings.TrimLeft(numB, "0")
+			if len(trimmedA) != len(trimmedB) {
+				return compareInts(len(trimmedA), len(trimmedB))
+			}
+			if c := strings.Compare(trimmedA, trimmedB); c != 0 {
+				return c
+			}
+			if len(numA) != len(numB) {
+				return compareInts(len(numA), len(numB))
+			}
+			a, b = restA, restB
+			continue
+		}
+		if ra != rb {
+			return compareInts(int(ra), int(rb))
+		}
+		a, b = a[sizeA:], b[sizeB:]
+	}
+	return compareInts(len(a), len(b))
+}
+
+func isASCIIDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func splitDigits(s string) (string, string) {
+	end := strings.IndexFunc(s, func(r rune) bool { return !isASCIIDigit(r) })
+	if end < 0 {
+		return s, ""
+	}
+	return s[:end], s[end:]
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// uniqueLines garde la première occurrence de chaque ligne (sans tenir compte de la casse
+// avec ignoreCase). Avec withCounts, chaque ligne est précédée de son nombre d'occurrences,
+// aligné à droite comme avec uniq -c.
+func uniqueLines(ctx context.Context, lines []string, ignoreCase, withCounts bool) ([]string, error) {
+	key := func(line string) string {
+		if ignoreCase {
+			return strings.ToLower(line)
+		}
+		return line
+	}
+
+	counts := make(map[string]int)
+	var unique []string
+	for _, line := range lines {
+		if cancelled(ctx) {
+			return nil, ctx.Err()
+		}
+		k := key(line)
+		if counts[k] == 0 {
+			unique = append(unique, line)
+		}
+		counts[k]++
+	}
+	if !withCounts {
+		return unique, nil
+	}
+
+	width := 0
+	for _, n := range counts {
+		width = max(width, len(strconv.Itoa(n)))
+	}
+	for i, line := range unique {
+		unique[i] = fmt.Sprintf("%*d %s", width, counts[key(line)], line)
+	}
+	return unique, nil
+}
+
+// shuffleLines mélange les lignes; une même graine donne toujours le même ordre
+func shuffleLines(lines []string, seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(lines), func(i, j int) {
+		lines[i], lines[j] = lines[j], lines[i]
+	})
+}
+
+// lineMatcher retourne le test des lignes pour les filtres: sous-chaîne ou expression
+// régulière (RE2), avec ou sans casse
+func lineMatcher(pattern string, isRegex, ignoreCase bool) (func(string) bool, error) {
+	if isRegex {
+		if ignoreCase {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("expression régulière invalide: %w", err)
+		}
+		return re.MatchString, nil
+	}
+	if ignoreCase {
+		lower := strings.ToLower(pattern)
+		return func(line string) bool { return strings.Contains(strings.ToLower(line), lower) }, nil
+	}
+	return func(line string) bool { return strings.Contains(line, pattern) }, nil
+}
+
+// squeezeBlankLines ne garde que la première ligne de chaque suite de lignes vides (ou d'espaces)
+func squeezeBlankLines(lines []string) []string {
+	var result []string
+	previousBlank := false
+	for _, line := range lines {
+		blank := strings.TrimFunc(line, unicode.IsSpace) == ""
+		if blank && previousBlank {
+			continue
+		}
+		result = append(result, line)
+		previousBlank = blank
+	}
+	return result
+}
@@ -0,0 +1,216 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLineOperations(t *testing.T) {
+	tests := []struct {
+		name    string
+		options LineOperationsOptions
+		input   string
+		want    string
+	}{
+		{
+			name:    "tri lexicographique",
+			options: LineOperationsOptions{Operation: LineOpSort},
+			input:   "zèbre\nété\nEva\nabc\n",
+			want:    "Eva\nabc\nzèbre\nété\n",
+		},
+		{
+			name:    "tri linguistique",
+			options: LineOperationsOptions{Operation: LineOpSort, SortMode: LineSortLocale, Locale: "fr"},
+			input:   "zèbre\nété\nEva\nabc\n",
+			want:    "abc\nété\nEva\nzèbre\n",
+		},
+		{
+			name:    "tri naturel sans casse",
+			options: LineOperationsOptions{Operation: LineOpSort, SortMode: LineSortNatural, IgnoreCase: true},
+			input:   "f10\nf02\nf2\nF1",
+			want:    "F1\nf2\nf02\nf10",
+		},
+		{
+			name:    "tri numérique",
+			options: LineOperationsOptions{Operation: LineOpSort, SortMode: LineSortNumeric},
+			input:   "10 a\nx\n2 b\n-1.5e1 c\n3\n",
+			want:    "-1.5e1 c\n2 b\n3\n10 a\nx\n",
+		},
+		{
+			name:    "tri numérique décroissant, lignes sans nombre à la fin",
+			options: LineOperationsOptions{Operation: LineOpSort, SortMode: LineSortNumeric, Descending: true},
+			input:   "y\n10 a\nx\n2 b\n",
+			want:    "10 a\n2 b\ny\nx\n",
+		},
+		{
+			name:    "dédoublonnage",
+			options: LineOperationsOptions{Operation: LineOpUnique},
+			input:   "b\na\nb\nA\n",
+			want:    "b\na\nA\n",
+		},
+		{
+			name:    "dédoublonnage compté sans casse",
+			options: LineOperationsOptions{Operation: LineOpUnique, IgnoreCase: true, Counts: true},
+			input:   "a\nb\n" + strings.Repeat("A\n", 10),
+			want:    "11 a\n 1 b\n",
+		},
+		{
+			name:    "ordre inversé",
+			options: LineOperationsOptions{Operation: LineOpReverse},
+			input:   "1\n2\n3",
+			want:    "3\n2\n1",
+		},
+		{
+			name:    "lignes correspondantes gardées",
+			options: LineOperationsOptions{Operation: LineOpKeep, Pattern: "err", IgnoreCase: true},
+			input:   "ERREUR 1\nok\nerreur 2\n",
+			want:    "ERREUR 1\nerreur 2\n",
+		},
+		{
+			name:    "lignes correspondantes supprimées",
+			options: LineOperationsOptions{Operation: LineOpDrop, Pattern: `^\s*#`, Regex: true},
+			input:   "# a\nb\n  # c\n",
+			want:    "b\n",
+		},
+		{
+			name:    "aucune ligne gardée",
+			options: LineOperationsOptions{Operation: LineOpKeep, Pattern: "z"},
+			input:   "a\nb\n",
+			want:    "",
+		},
+		{
+			name:    "premières lignes",
+			options: LineOperationsOptions{Operation: LineOpHead, Count: 2},
+			input:   "1\n2\n3\n",
+			want:    "1\n2\n",
+		},
+		{
+			name:    "dernières lignes au-delà du nombre de lignes",
+			options: LineOperationsOptions{Operation: LineOpTail, Count: 5},
+			input:   "1\n2\n3",
+			want:    "1\n2\n3",
+		},
+		{
+			name:    "lignes vides consécutives réduites",
+			options: LineOperationsOptions{Operation: LineOpSqueezeBlank},
+			input:   "a\n\n \t\n\nb\n\n",
+			want:    "a\n\nb\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewLineOperationsViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			got, err := vm.Process(tt.input)
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Process = %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareNatural(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"fichier2", "fichier10", -1},
+		{"fichier10", "fichier2", 1},
+		{"a1b2", "a1b2", 0},
+		{"a01", "a1", 1},
+		{"a9", "a009", -1},
+		{"v1.10", "v1.9", 1},
+		{"abc", "ab", 1},
+		{"99999999999999999999", "100000000000000000000", -1},
+		{"é2", "é10", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := compareNatural(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareNatural(%q, %q) = %d, attendu %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineOperationsShuffle(t *testing.T) {
+	input := "1\n2\n3\n4\n5\n6\n7\n8\n"
+	shuffle := func(seed int64) string {
+		vm := NewLineOperationsViewModel()
+		if err := vm.LoadConfiguration(LineOperationsOptions{Operation: LineOpShuffle, Seed: seed}); err != nil {
+			t.Fatal(err)
+		}
+		got, err := vm.Process(input)
+		if err != nil {
+			t.Fatalf("Process: %v", err)
+		}
+		return got
+	}
+
+	first := shuffle(42)
+	if again := shuffle(42); again != first {
+		t.Errorf("même graine, ordres différents: %q et %q", first, again)
+	}
+	lines := strings.Split(strings.TrimSuffix(first, "\n"), "\n")
+	slices.Sort(lines)
+	if sorted := strings.Join(lines, "\n") + "\n"; sorted != input || !strings.HasSuffix(first, "\n") {
+		t.Errorf("le mélange %q n'est pas une permutation de l'entrée", first)
+	}
+}
+
+func TestLineOperationsErrors(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		options LineOperationsOptions
+	}{
+		{"opération inconnue", context.Background(), LineOperationsOptions{Operation: "Compter"}},
+		{"ordre de tri inconnu", context.Background(), LineOperationsOptions{Operation: LineOpSort, SortMode: "Aléatoire"}},
+		{"locale invalide", context.Background(), LineOperationsOptions{Operation: LineOpSort, SortMode: LineSortLocale, Locale: "pas une locale"}},
+		{"motif vide", context.Background(), LineOperationsOptions{Operation: LineOpKeep}},
+		{"expression régulière invalide", context.Background(), LineOperationsOptions{Operation: LineOpDrop, Pattern: "(", Regex: true}},
+		{"nombre de lignes négatif", context.Background(), LineOperationsOptions{Operation: LineOpHead, Count: -1}},
+		{"dédoublonnage annulé", cancelled, LineOperationsOptions{Operation: LineOpUnique}},
+		{"filtre annulé", cancelled, LineOperationsOptions{Operation: LineOpKeep, Pattern: "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewLineOperationsViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			_, err := vm.ProcessContext(tt.ctx, "b\na\n")
+			if err == nil {
+				t.Fatal("erreur attendue")
+			}
+			if tt.ctx == cancelled && !errors.Is(err, context.Canceled) {
+				t.Errorf("erreur %v, attendu %v", err, context.Canceled)
+			}
+		})
+	}
+}
+
+// Le tri est abandonné si le contexte est annulé pendant les comparaisons
+func TestSortLinesCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	lines := make([]string, 5000)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", i%7) + string(rune('a'+i%26))
+	}
+	if err := sortLines(ctx, lines, LineSortNatural, "", false, false); !errors.Is(err, context.Canceled) {
+		t.Errorf("erreur %v, attendu %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,441 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// LineOperationsUI implémente Processor pour les opérations sur les lignes
+type LineOperationsUI struct {
+	viewModel *LineOperationsViewModel
+}
+
+func NewLineOperationsUI() Processor {
+	return &LineOperationsUI{
+		viewModel: NewLineOperationsViewModel(),
+	}
+}
+
+func (ui *LineOperationsUI) Name() string {
+	return "Opérations sur les lignes"
+}
+
+func (ui *LineOperationsUI) Description() string {
+	return "Trie, dédoublonne, inverse, mélange ou filtre les lignes d'un texte"
+}
+
+func (ui *LineOperationsUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *LineOperationsUI) CreateConfigurationUI() fyne.CanvasObject {
+	vm := ui.viewModel
+
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("Une valeur par ligne...")
+	input.Wrapping = fyne.TextWrapOff
+	input.SetMinRowsVisible(8)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapOff
+	output.Disable()
+
+	status := widget.NewLabel("")
+
+	operationSelect := widget.NewSelect(LineOperations, nil)
+	operationSelect.SetSelected(vm.operation)
+
+	sortModeSelect := widget.NewSelect(LineSortModes, nil)
+	sortModeSelect.SetSelected(vm.sortMode)
+
+	localeEntry := widget.NewEntry()
+	localeEntry.SetPlaceHolder("fr, de-DE, sv... (vide: ordre Unicode)")
+	localeEntry.SetText(vm.locale)
+
+	descendingCheck := widget.NewCheck("Décroissant", nil)
+	descendingCheck.SetChecked(vm.descending)
+
+	ignoreCaseCheck := widget.NewCheck("Ignorer la casse", nil)
+	ignoreCaseCheck.SetChecked(vm.ignoreCase)
+
+	countsCheck := widget.NewCheck("Afficher le nombre d'occurrences", nil)
+	countsCheck.SetChecked(vm.counts)
+
+	seedEntry := widget.NewEntry()
+	seedEntry.SetText(strconv.FormatInt(vm.seed, 10))
+
+	patternEntry := widget.NewEntry()
+	patternEntry.SetPlaceHolder("Texte ou expression régulière")
+	patternEntry.SetText(vm.pattern)
+
+	regexCheck := widget.NewCheck("Expression régulière", nil)
+	regexCheck.SetChecked(vm.regex)
+
+	countEntry := widget.NewEntry()
+	countEntry.SetPlaceHolder("N")
+	countEntry.SetText(strconv.Itoa(vm.count))
+
+	processBtn := widget.NewButton("Appliquer", func() {
+		result, err := vm.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			status.SetText("")
+			return
+		}
+		output.SetText(result)
+		status.SetText(fmt.Sprintf("%d ligne(s)", vm.lastCount))
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := vm.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	rerun := func() {
+		if input.Text != "" {
+			processBtn.OnTapped()
+		}
+	}
+
+	newSeedBtn := widget.NewButton("Nouvelle graine", func() {
+		seedEntry.SetText(strconv.FormatInt(rand.Int63n(1_000_000), 10))
+		rerun()
+	})
+
+	// Chaque opération n'utilise que certains paramètres
+	sortRow := container.NewHBox(widget.NewLabel("Ordre:"), sortModeSelect, descendingCheck)
+	localeRow := container.NewBorder(nil, nil, widget.NewLabel("Locale:"), nil, localeEntry)
+	seedRow := container.NewBorder(nil, nil, widget.NewLabel("Graine:"), newSeedBtn, seedEntry)
+	patternRow := container.NewBorder(nil, nil, widget.NewLabel("Motif:"), regexCheck, patternEntry)
+	countRow := container.NewBorder(nil, nil, widget.NewLabel("Nombre de lignes:"), nil, countEntry)
+	updateOperation := func() {
+		show := func(object fyne.CanvasObject, visible bool) {
+			if visible {
+				object.Show()
+			} else {
+				object.Hide()
+			}
+		}
+		show(sortRow, vm.operation == LineOpSort)
+		show(localeRow, vm.operation == LineOpSort && vm.sortMode == LineSortLocale)
+		show(ignoreCaseCheck, slices.Contains([]string{LineOpSort, LineOpUnique, LineOpKeep, LineOpDrop}, vm.operation))
+		show(countsCheck, vm.operation == LineOpUnique)
+		show(seedRow, vm.operation == LineOpShuffle)
+		show(patternRow, vm.operation == LineOpKeep || vm.operation == LineOpDrop)
+		show(countRow, vm.operation == LineOpHead || vm.operation == LineOpTail)
+	}
+	updateOperation()
+
+	operationSelect.OnChanged = func(s string) {
+		vm.operation = s
+		updateOperation()
+		rerun()
+	}
+	sortModeSelect.OnChanged = func(s string) {
+		vm.sortMode = s
+		updateOperation()
+		rerun()
+	}
+	localeEntry.OnChanged = func(s string) {
+		vm.locale = s
+	}
+	descendingCheck.OnChanged = func(checked bool) {
+		vm.descending = checked
+		rerun()
+	}
+	ignoreCaseCheck.OnChanged = func(checked bool) {
+		vm.ignoreCase = checked
+		rerun()
+	}
+	countsCheck.OnChanged = func(checked bool) {
+		vm.counts = checked
+		rerun()
+	}
+	seedEntry.OnChanged = func(s string) {
+		vm.seed, _ = strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	}
+	patternEntry.OnChanged = func(s string) {
+		vm.pattern = s
+	}
+	regexCheck.OnChanged = func(checked bool) {
+		vm.regex = checked
+		rerun()
+	}
+	countEntry.OnChanged = func(s string) {
+		vm.count, _ = parseCount(s)
+	}
+	for _, entry := range []*widget.Entry{localeEntry, seedEntry, patternEntry, countEntry} {
+		entry.OnSubmitted = func(string) { rerun() }
+	}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée Texte:"),
+		input,
+		container.NewHBox(
+			widget.NewLabel("Opération:"),
+			operationSelect,
+			ignoreCaseCheck,
+			countsCheck,
+		),
+		sortRow,
+		localeRow,
+		seedRow,
+		patternRow,
+		countRow,
+		container.NewHBox(
+			processBtn,
+			copyBtn,
+			status,
+		),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// LineOperationsOptions configuration du ViewModel des opérations sur les lignes. Seuls les
+// champs utiles à l'opération choisie sont utilisés.
+type LineOperationsOptions struct {
+	Operation  string // Une des valeurs de LineOperations
+	SortMode   string // Une des valeurs de LineSortModes
+	Locale     string // Étiquette BCP 47 pour le tri linguistique
+	Descending bool
+	IgnoreCase bool  // Tri, dédoublonnage et filtres
+	Counts     bool  // Dédoublonnage: préfixer chaque ligne par son nombre d'occurrences
+	Seed       int64 // Mélange: même graine, même ordre
+	Pattern    string
+	Regex      bool // Le motif est une expression régulière (sinon une sous-chaîne)
+	Count      int  // N des opérations premières/dernières lignes (10 si nul)
+}
+
+// LineOperationsViewModel implémente ViewModel pour les opérations sur les lignes. Le
+// retour à la ligne final de l'entrée est conservé.
+type LineOperationsViewModel struct {
+	operation  string
+	sortMode   string
+	locale     string
+	descending bool
+	ignoreCase bool
+	counts     bool
+	seed       int64
+	pattern    string
+	regex      bool
+	count      int
+	lastResult string
+	lastCount  int
+}
+
+func NewLineOperationsViewModel() *LineOperationsViewModel {
+	return &LineOperationsViewModel{
+		operation: LineOpSort,
+		sortMode:  LineSortLexicographic,
+		count:     10,
+	}
+}
+
+func (vm *LineOperationsViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext applique l'opération en s'interrompant si le contexte est annulé
+func (vm *LineOperationsViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	if err := vm.Validate(); err != nil {
+		return "", err
+	}
+	if input == "" {
+		vm.lastResult, vm.lastCount = "", 0
+		return "", nil
+	}
+
+	trailingNewline := strings.HasSuffix(input, "\n")
+	lines := strings.Split(strings.TrimSuffix(input, "\n"), "\n")
+
+	switch vm.operation {
+	case LineOpSort:
+		if err := sortLines(ctx, lines, vm.sortMode, vm.locale, vm.ignoreCase, vm.descending); err != nil {
+			return "", err
+		}
+	case LineOpUnique:
+		var err error
+		if lines, err = uniqueLines(ctx, lines, vm.ignoreCase, vm.counts); err != nil {
+			return "", err
+		}
+	case LineOpReverse:
+		slices.Reverse(lines)
+	case LineOpShuffle:
+		shuffleLines(lines, vm.seed)
+	case LineOpKeep, LineOpDrop:
+		match, err := lineMatcher(vm.pattern, vm.regex, vm.ignoreCase)
+		if err != nil {
+			return "", err
+		}
+		keep := vm.operation == LineOpKeep
+		kept := lines[:0]
+		for _, line := range lines {
+			if cancelled(ctx) {
+				return "", ctx.Err()
+			}
+			if match(line) == keep {
+				kept = append(kept, line)
+			}
+		}
+		lines = kept
+	case LineOpHead:
+		lines = lines[:min(vm.count, len(lines))]
+	case LineOpTail:
+		lines = lines[len(lines)-min(vm.count, len(lines)):]
+	case LineOpSqueezeBlank:
+		lines = squeezeBlankLines(lines)
+	}
+
+	result := strings.Join(lines, "\n")
+	if trailingNewline && len(lines) > 0 {
+		result += "\n"
+	}
+	vm.lastResult, vm.lastCount = result, len(lines)
+	return result, nil
+}
+
+func (vm *LineOperationsViewModel) GetConfiguration() interface{} {
+	return LineOperationsOptions{
+		Operation:  vm.operation,
+		SortMode:   vm.sortMode,
+		Locale:     vm.locale,
+		Descending: vm.descending,
+		IgnoreCase: vm.ignoreCase,
+		Counts:     vm.counts,
+		Seed:       vm.seed,
+		Pattern:    vm.pattern,
+		Regex:      vm.regex,
+		Count:      vm.count,
+	}
+}
+
+func (vm *LineOperationsViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(LineOperationsOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.operation = cfg.Operation
+	if vm.operation == "" {
+		vm.operation = LineOpSort
+	}
+	vm.sortMode = cfg.SortMode
+	if vm.sortMode == "" {
+		vm.sortMode = LineSortLexicographic
+	}
+	vm.locale = cfg.Locale
+	vm.descending = cfg.Descending
+	vm.ignoreCase = cfg.IgnoreCase
+	vm.counts = cfg.Counts
+	vm.seed = cfg.Seed
+	vm.pattern = cfg.Pattern
+	vm.regex = cfg.Regex
+	vm.count = cfg.Count
+	if vm.count == 0 {
+		vm.count = 10
+	}
+	return nil
+}
+
+func (vm *LineOperationsViewModel) Validate() error {
+	return vm.GetConfiguration().(LineOperationsOptions).Validate()
+}
+
+// Validate vérifie l'opération et ses paramètres (valeurs vides acceptées pour l'opération
+// et l'ordre de tri)
+func (o LineOperationsOptions) Validate() error {
+	if o.Operation != "" && !slices.Contains(LineOperations, o.Operation) {
+		return fmt.Errorf("opération invalide: %s", o.Operation)
+	}
+	switch o.Operation {
+	case LineOpSort, "":
+		if o.SortMode != "" && !slices.Contains(LineSortModes, o.SortMode) {
+			return fmt.Errorf("ordre de tri invalide: %s", o.SortMode)
+		}
+		if o.SortMode == LineSortLocale {
+			if _, err := parseLocale(o.Locale); err != nil {
+				return err
+			}
+		}
+	case LineOpKeep, LineOpDrop:
+		if o.Pattern == "" {
+			return fmt.Errorf("le filtre des lignes demande un motif")
+		}
+		if _, err := lineMatcher(o.Pattern, o.Regex, o.IgnoreCase); err != nil {
+			return err
+		}
+	case LineOpHead, LineOpTail:
+		if o.Count < 0 {
+			return fmt.Errorf("le nombre de lignes ne peut pas être négatif")
+		}
+	}
+	return nil
+}
+
+func (vm *LineOperationsViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// LineOperationsTool identifiant de l'outil "Opérations sur les lignes" dans les pipelines sérialisés
+const LineOperationsTool ToolType = "line_operations"
+
+func init() {
+	Register(Registration{
+		Type:        LineOperationsTool,
+		Name:        "Opérations sur les lignes",
+		Description: "Trie, dédoublonne, inverse, mélange et filtre des lignes",
+		New:         NewLineOperationsUI,
+		Codec:       NewConfigCodec(LineOperationsConfig.Options, func(o LineOperationsOptions) LineOperationsConfig { return LineOperationsConfig(o) }),
+	})
+}
+
+// LineOperationsConfig configuration pour les opérations sur les lignes
+type LineOperationsConfig struct {
+	Operation  string `json:",omitempty"` // "Trier" par défaut
+	SortMode   string `json:",omitempty"` // "Lexicographique" (défaut), "Naturel", "Numérique" ou "Linguistique (locale)"
+	Locale     string `json:",omitempty"`
+	Descending bool   `json:",omitempty"`
+	IgnoreCase bool   `json:",omitempty"`
+	Counts     bool   `json:",omitempty"`
+	Seed       int64  `json:",omitempty"`
+	Pattern    string `json:",omitempty"`
+	Regex      bool   `json:",omitempty"`
+	Count      int    `json:",omitempty"` // 10 par défaut
+}
+
+func (c LineOperationsConfig) GetType() ToolType {
+	return LineOperationsTool
+}
+
+func (c LineOperationsConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c LineOperationsConfig) GetDisplayName() string {
+	operation := c.Operation
+	if operation == "" {
+		operation = LineOpSort
+	}
+	return fmt.Sprintf("Lignes: %s", operation)
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c LineOperationsConfig) Options() LineOperationsOptions {
+	return LineOperationsOptions(c)
+}
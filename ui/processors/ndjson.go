@@ -0,0 +1,422 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Modes du processeur NDJSON
+const (
+	NDJSONModeValidate  = "Valider"
+	NDJSONModePretty    = "Formater chaque ligne"
+	NDJSONModeToArray   = "NDJSON → tableau JSON"
+	NDJSONModeFromArray = "Tableau JSON → NDJSON"
+)
+
+// NDJSONModes liste les modes acceptés par NDJSONOptions.Mode
+var NDJSONModes = []string{NDJSONModeValidate, NDJSONModePretty, NDJSONModeToArray, NDJSONModeFromArray}
+
+// NDJSONError regroupe les erreurs de toutes les lignes invalides d'un document NDJSON.
+// Les JSONError sont localisées dans le document complet (ligne, colonne, offset).
+type NDJSONError struct {
+	Errors []*JSONError
+}
+
+func (e *NDJSONError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	return fmt.Sprintf("%d lignes invalides, première erreur %v", len(e.Errors), e.Errors[0])
+}
+
+// Pretty liste les erreurs des 20 premières lignes invalides
+func (e *NDJSONError) Pretty() string {
+	const maxShown = 20
+	parts := []string{fmt.Sprintf("%d ligne(s) invalide(s)", len(e.Errors))}
+	for i, lineErr := range e.Errors {
+		if i == maxShown {
+			parts = append(parts, fmt.Sprintf("... et %d autre(s)", len(e.Errors)-maxShown))
+			break
+		}
+		parts = append(parts, lineErr.Pretty())
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func (e *NDJSONError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// ndjsonLine ligne non vide d'un document NDJSON
+type ndjsonLine struct {
+	Offset int    // Position du début de ligne dans le document
+	Text   string // Contenu sans retour à la ligne
+}
+
+// splitNDJSON découpe le document en lignes non vides
+func splitNDJSON(input string) []ndjsonLine {
+	var lines []ndjsonLine
+	offset := 0
+	for _, text := range strings.Split(input, "\n") {
+		trimmed := strings.TrimRight(text, "\r")
+		if strings.TrimSpace(trimmed) != "" {
+			lines = append(lines, ndjsonLine{Offset: offset, Text: trimmed})
+		}
+		offset += len(text) + 1
+	}
+	return lines
+}
+
+// ValidateNDJSON valide chaque ligne indépendamment et retourne une *NDJSONError listant
+// toutes les lignes invalides
+func ValidateNDJSON(input string) error {
+	return validateNDJSON(context.Background(), input)
+}
+
+// validateNDJSON implémente ValidateNDJSON en vérifiant l'annulation du contexte à chaque ligne
+func validateNDJSON(ctx context.Context, input string) error {
+	var errs []*JSONError
+	for _, line := range splitNDJSON(input) {
+		if cancelled(ctx) {
+			return ctx.Err()
+		}
+		if err := ValidateJSON(line.Text); err != nil {
+			errs = append(errs, ndjsonLineError(input, line, err))
+		}
+	}
+	if len(errs) > 0 {
+		return &NDJSONError{Errors: errs}
+	}
+	return nil
+}
+
+// ndjsonLineError relocalise l'erreur d'une ligne dans le document complet
+func ndjsonLineError(input string, line ndjsonLine, err error) *JSONError {
+	local, ok := err.(*JSONError)
+	if !ok {
+		return newJSONError(input, int64(line.Offset), err)
+	}
+	jsonErr := newJSONError(input, int64(line.Offset)+local.Offset, local.Err)
+	jsonErr.Hint = local.Hint
+	return jsonErr
+}
+
+// NDJSONUI implémente Processor pour les documents JSON délimités par des retours à la ligne
+type NDJSONUI struct {
+	viewModel *NDJSONViewModel
+}
+
+func NewNDJSONUI() Processor {
+	return &NDJSONUI{
+		viewModel: NewNDJSONViewModel(),
+	}
+}
+
+func (ui *NDJSONUI) Name() string {
+	return "NDJSON / JSON Lines"
+}
+
+func (ui *NDJSONUI) Description() string {
+	return "Valide, formate et convertit du JSON délimité par des retours à la ligne"
+}
+
+func (ui *NDJSONUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *NDJSONUI) CreateConfigurationUI() fyne.CanvasObject {
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("Un document JSON par ligne...")
+	input.Wrapping = fyne.TextWrapOff
+	input.Resize(fyne.NewSize(0, 120))
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapOff
+	output.Disable()
+
+	status := widget.NewLabel("")
+
+	indentSelect := widget.NewSelect(JSONIndentTypes, func(s string) {
+		ui.viewModel.indentType = s
+	})
+	indentSelect.SetSelected(ui.viewModel.indentType)
+
+	modeSelect := widget.NewSelect(NDJSONModes, nil)
+	modeSelect.SetSelected(ui.viewModel.mode)
+
+	processBtn := widget.NewButton("Traiter", func() {
+		result, err := ui.viewModel.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			status.SetText("")
+			highlightJSONError(input, err)
+			return
+		}
+		output.SetText(result)
+		if ui.viewModel.mode == NDJSONModeFromArray {
+			status.SetText(fmt.Sprintf("%d ligne(s) produite(s)", len(splitNDJSON(result))))
+		} else {
+			status.SetText(fmt.Sprintf("%d ligne(s) valide(s)", len(splitNDJSON(input.Text))))
+		}
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := ui.viewModel.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	updateIndent := func() {
+		if ui.viewModel.mode == NDJSONModePretty || ui.viewModel.mode == NDJSONModeToArray {
+			indentSelect.Enable()
+		} else {
+			indentSelect.Disable()
+		}
+	}
+	updateIndent()
+
+	modeSelect.OnChanged = func(s string) {
+		ui.viewModel.mode = s
+		updateIndent()
+		if input.Text != "" {
+			processBtn.OnTapped()
+		}
+	}
+
+	indentSelect.OnChanged = func(s string) {
+		ui.viewModel.indentType = s
+		if input.Text != "" {
+			processBtn.OnTapped()
+		}
+	}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée NDJSON:"),
+		input,
+		container.NewHBox(
+			widget.NewLabel("Mode:"),
+			modeSelect,
+			widget.NewLabel("Indentation:"),
+			indentSelect,
+			processBtn,
+			copyBtn,
+		),
+		container.NewHBox(
+			widget.NewLabel("Résultat:"),
+			status,
+		),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// NDJSONOptions configuration du ViewModel NDJSON
+type NDJSONOptions struct {
+	Mode       string // Une des valeurs de NDJSONModes
+	IndentType string // Formatage et conversion en tableau (voir JSONIndentTypes)
+}
+
+// NDJSONViewModel implémente ViewModel pour le processeur NDJSON
+type NDJSONViewModel struct {
+	mode       string
+	indentType string
+	lastResult string
+}
+
+func NewNDJSONViewModel() *NDJSONViewModel {
+	return &NDJSONViewModel{
+		mode:       NDJSONModeValidate,
+		indentType: "2 espaces",
+	}
+}
+
+func (vm *NDJSONViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext traite le document NDJSON en s'interrompant si le contexte est annulé (à
+// chaque ligne ou élément du tableau)
+func (vm *NDJSONViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		vm.lastResult = ""
+		return "", nil
+	}
+
+	var result string
+	var err error
+	switch vm.mode {
+	case NDJSONModeValidate:
+		// Le document est transmis tel quel s'il est valide
+		err = validateNDJSON(ctx, input)
+		result = input
+	case NDJSONModePretty:
+		result, err = vm.prettyPrint(ctx, input)
+	case NDJSONModeToArray:
+		result, err = vm.toArray(ctx, input)
+	case NDJSONModeFromArray:
+		result, err = fromJSONArray(ctx, input)
+	default:
+		err = fmt.Errorf("mode NDJSON inconnu: %s", vm.mode)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	vm.lastResult = result
+	return result, nil
+}
+
+// prettyPrint formate chaque ligne; les documents sont séparés par une ligne vide
+func (vm *NDJSONViewModel) prettyPrint(ctx context.Context, input string) (string, error) {
+	if err := validateNDJSON(ctx, input); err != nil {
+		return "", err
+	}
+
+	formatter := NewFormatter(vm.indentType)
+	var docs []string
+	for _, line := range splitNDJSON(input) {
+		if cancelled(ctx) {
+			return "", ctx.Err()
+		}
+		formatted, err := formatter.FormatJSON(line.Text)
+		if err != nil {
+			return "", ndjsonLineError(input, line, err)
+		}
+		docs = append(docs, strings.TrimSuffix(formatted, "\n"))
+	}
+
+	separator := "\n\n"
+	if vm.indentType == IndentMinified || vm.indentType == IndentCanonical {
+		separator = "\n"
+	}
+	return strings.Join(docs, separator) + "\n", nil
+}
+
+// toArray regroupe les lignes dans un tableau JSON en conservant leurs littéraux
+func (vm *NDJSONViewModel) toArray(ctx context.Context, input string) (string, error) {
+	if err := validateNDJSON(ctx, input); err != nil {
+		return "", err
+	}
+
+	var texts []string
+	for _, line := range splitNDJSON(input) {
+		texts = append(texts, line.Text)
+	}
+	formatter := NewFormatter(vm.indentType)
+	formatter.EscapeHTML = false
+	return formatter.FormatJSON("[" + strings.Join(texts, ",\n") + "]")
+}
+
+// fromJSONArray écrit chaque élément d'un tableau JSON sur sa propre ligne
+func fromJSONArray(ctx context.Context, input string) (string, error) {
+	tree, err := parseJSONTree(input)
+	if err != nil {
+		return "", err
+	}
+	if tree.Kind != jsonArray {
+		return "", fmt.Errorf("l'entrée doit être un tableau JSON")
+	}
+
+	var out strings.Builder
+	for _, item := range tree.Items {
+		if cancelled(ctx) {
+			return "", ctx.Err()
+		}
+		item.Write(&out, "", false)
+		out.WriteString("\n")
+	}
+	return out.String(), nil
+}
+
+func (vm *NDJSONViewModel) GetConfiguration() interface{} {
+	return NDJSONOptions{
+		Mode:       vm.mode,
+		IndentType: vm.indentType,
+	}
+}
+
+func (vm *NDJSONViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(NDJSONOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.mode = cfg.Mode
+	vm.indentType = cfg.IndentType
+	if vm.indentType == "" {
+		vm.indentType = "2 espaces"
+	}
+	return nil
+}
+
+func (vm *NDJSONViewModel) Validate() error {
+	return vm.GetConfiguration().(NDJSONOptions).Validate()
+}
+
+// Validate vérifie le mode et, s'il est utilisé, le type d'indentation
+func (o NDJSONOptions) Validate() error {
+	if !slices.Contains(NDJSONModes, o.Mode) {
+		return fmt.Errorf("mode NDJSON invalide: %s", o.Mode)
+	}
+	usesIndent := o.Mode == NDJSONModePretty || o.Mode == NDJSONModeToArray
+	if usesIndent && o.IndentType != "" && !slices.Contains(JSONIndentTypes, o.IndentType) {
+		return fmt.Errorf("type d'indentation invalide: %s", o.IndentType)
+	}
+	return nil
+}
+
+func (vm *NDJSONViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// NDJSONTool identifiant de l'outil "NDJSON" dans les pipelines sérialisés
+const NDJSONTool ToolType = "ndjson"
+
+func init() {
+	Register(Registration{
+		Type:        NDJSONTool,
+		Name:        "NDJSON",
+		Description: "Valide et convertit du JSON ligne par ligne (JSON Lines)",
+		New:         NewNDJSONUI,
+		Codec:       NewConfigCodec(NDJSONConfig.Options, func(o NDJSONOptions) NDJSONConfig { return NDJSONConfig(o) }),
+	})
+}
+
+// NDJSONConfig configuration pour le processeur NDJSON
+type NDJSONConfig struct {
+	Mode       string // "Valider", "Formater chaque ligne", "NDJSON → tableau JSON", "Tableau JSON → NDJSON"
+	IndentType string `json:",omitempty"` // Formatage et conversion en tableau
+}
+
+func (c NDJSONConfig) GetType() ToolType {
+	return NDJSONTool
+}
+
+func (c NDJSONConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c NDJSONConfig) GetDisplayName() string {
+	return fmt.Sprintf("NDJSON (%s)", c.Mode)
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c NDJSONConfig) Options() NDJSONOptions {
+	return NDJSONOptions{Mode: c.Mode, IndentType: c.IndentType}
+}
@@ -0,0 +1,110 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNDJSONModes(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   string
+		indent string
+		input  string
+		want   string
+	}{
+		{"validation transmise telle quelle", NDJSONModeValidate, "", "{\"a\":1}\n\n[2]\r\n", "{\"a\":1}\n\n[2]\r\n"},
+		{"formatage minifié", NDJSONModePretty, IndentMinified, "{ \"a\" : 1 }\n[ 2 ]\n", "{\"a\":1}\n[2]\n"},
+		{"formatage indenté", NDJSONModePretty, "2 espaces", "{\"a\":1}\n{\"b\":2}", "{\n  \"a\": 1\n}\n\n{\n  \"b\": 2\n}\n"},
+		{"vers un tableau", NDJSONModeToArray, IndentMinified, "{\"id\":12345678901234567890}\n\n\"<b>\"\n", "[{\"id\":12345678901234567890},\"<b>\"]\n"},
+		{"depuis un tableau", NDJSONModeFromArray, "", "[{\"a\": 1.50}, \"x\", [ ]]", "{\"a\":1.50}\n\"x\"\n[]\n"},
+		{"depuis un tableau vide", NDJSONModeFromArray, "", "[]", ""},
+		{"entrée vide", NDJSONModeToArray, "", " \n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewNDJSONViewModel()
+			if err := vm.LoadConfiguration(NDJSONOptions{Mode: tt.mode, IndentType: tt.indent}); err != nil {
+				t.Fatal(err)
+			}
+			got, err := vm.Process(tt.input)
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Process = %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateNDJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantLines []int // Lignes invalides signalées (dans le document complet)
+		wantCols  []int
+	}{
+		{"document valide", "{\"a\":1}\n\n[1,2]\n\"x\"", nil, nil},
+		{"une ligne invalide", "{\"a\":1}\n{\"a\" 2}\n", []int{2}, []int{6}},
+		{"toutes les lignes invalides signalées", "{\n[1,]\n{\"ok\":true}\n'x'", []int{1, 2, 4}, []int{2, 4, 1}},
+		{"fins de ligne CRLF", "1\r\n2\r\n[\r\n", []int{3}, []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNDJSON(tt.input)
+			if tt.wantLines == nil {
+				if err != nil {
+					t.Fatalf("erreur inattendue: %v", err)
+				}
+				return
+			}
+			var ndjsonErr *NDJSONError
+			if !errors.As(err, &ndjsonErr) {
+				t.Fatalf("erreur %v (%T), attendu une *NDJSONError", err, err)
+			}
+			if len(ndjsonErr.Errors) != len(tt.wantLines) {
+				t.Fatalf("%d erreur(s), attendu %d: %v", len(ndjsonErr.Errors), len(tt.wantLines), err)
+			}
+			for i, lineErr := range ndjsonErr.Errors {
+				if lineErr.Line != tt.wantLines[i] || lineErr.Column != tt.wantCols[i] {
+					t.Errorf("erreur %d: ligne %d, colonne %d, attendu ligne %d, colonne %d",
+						i, lineErr.Line, lineErr.Column, tt.wantLines[i], tt.wantCols[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNDJSONErrors(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		mode    string
+		input   string
+		wantErr error // nil : toute erreur
+	}{
+		{"ligne invalide en formatage", context.Background(), NDJSONModePretty, "{}\n{", nil},
+		{"ligne invalide vers un tableau", context.Background(), NDJSONModeToArray, "{}\nx", nil},
+		{"objet au lieu d'un tableau", context.Background(), NDJSONModeFromArray, `{"a": 1}`, nil},
+		{"mode inconnu", context.Background(), "inconnu", "{}", nil},
+		{"contexte annulé", cancelled, NDJSONModeValidate, "{}\n{}", context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewNDJSONViewModel()
+			vm.mode = tt.mode
+			_, err := vm.ProcessContext(tt.ctx, tt.input)
+			if err == nil {
+				t.Fatal("erreur attendue")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("erreur %v, attendu %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package processors
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// PlaceholderProcessor remplace une étape de pipeline dont le type d'outil n'est pas connu
+// de cette version. L'étape est conservée (et réécrite telle quelle à l'export) mais ne peut pas s'exécuter.
+type PlaceholderProcessor struct {
+	viewModel *PlaceholderViewModel
+}
+
+func NewPlaceholderProcessor(toolType ToolType) Processor {
+	return &PlaceholderProcessor{
+		viewModel: &PlaceholderViewModel{toolType: toolType},
+	}
+}
+
+func (p *PlaceholderProcessor) Name() string {
+	return fmt.Sprintf("Outil inconnu (%s)", p.viewModel.toolType)
+}
+
+func (p *PlaceholderProcessor) Description() string {
+	return "Étape créée avec une version plus récente de l'application"
+}
+
+func (p *PlaceholderProcessor) ViewModel() ViewModel {
+	return p.viewModel
+}
+
+func (p *PlaceholderProcessor) CreateConfigurationUI() fyne.CanvasObject {
+	message := widget.NewLabel(fmt.Sprintf(
+		"Le type d'outil %q n'est pas disponible dans cette version.\n"+
+			"L'étape est désactivée mais sa configuration est conservée lors de l'export.",
+		p.viewModel.toolType))
+	message.Wrapping = fyne.TextWrapWord
+	return container.NewVBox(message)
+}
+
+// PlaceholderViewModel implémente ViewModel pour les étapes inconnues
+type PlaceholderViewModel struct {
+	toolType ToolType
+}
+
+func (vm *PlaceholderViewModel) Process(input string) (string, error) {
+	return "", fmt.Errorf("type d'outil inconnu: %s", vm.toolType)
+}
+
+func (vm *PlaceholderViewModel) GetConfiguration() interface{} {
+	return struct{ Type ToolType }{Type: vm.toolType}
+}
+
+func (vm *PlaceholderViewModel) LoadConfiguration(config interface{}) error {
+	return fmt.Errorf("configuration non modifiable pour un outil inconnu")
+}
+
+func (vm *PlaceholderViewModel) Validate() error {
+	return nil
+}
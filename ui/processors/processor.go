@@ -1,6 +1,8 @@
 package processors
 
 import (
+	"context"
+
 	"fyne.io/fyne/v2"
 )
 
@@ -19,3 +21,40 @@ type ViewModel interface {
 	LoadConfiguration(config interface{}) error
 	Validate() error
 }
+
+// ContextProcessor est implémenté par les ViewModel capables d'interrompre un traitement en cours
+// lorsque le contexte est annulé
+type ContextProcessor interface {
+	ProcessContext(ctx context.Context, input string) (string, error)
+}
+
+// ProcessWithContext exécute vm.Process en respectant l'annulation du contexte.
+// Les ViewModel implémentant ContextProcessor, dont tous les processeurs intégrés, vérifient
+// l'annulation pendant leur traitement et s'interrompent. Les autres (processeurs ajoutés par
+// un package externe) vont au bout de leur traitement: le ViewModel n'est jamais laissé à une
+// goroutine encore en cours, et l'annulation n'est signalée qu'à la fin de l'étape.
+func ProcessWithContext(ctx context.Context, vm ViewModel, input string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if cp, ok := vm.(ContextProcessor); ok {
+		return cp.ProcessContext(ctx, input)
+	}
+
+	output, err := vm.Process(input)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return "", ctxErr
+	}
+	return output, err
+}
+
+// cancelled indique sans bloquer si le contexte est annulé; appelé dans les boucles des
+// traitements longs, dont le coût reste négligeable avec context.Background()
+func cancelled(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
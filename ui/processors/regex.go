@@ -0,0 +1,253 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dlclark/regexp2"
+)
+
+// Moteurs d'expressions régulières
+const (
+	RegexEngineRE2  = "RE2 (Go)"
+	RegexEnginePerl = "Perl (regexp2)"
+)
+
+// RegexEngines liste les valeurs acceptées pour RegexReplaceOptions.Engine
+var RegexEngines = []string{RegexEngineRE2, RegexEnginePerl}
+
+// regexMatchTimeout limite la durée d'une recherche avec regexp2, dont le temps d'exécution
+// n'est pas borné (retours arrière)
+const regexMatchTimeout = 2 * time.Second
+
+// textRegex expression compilée avec l'un des deux moteurs. Les correspondances sont
+// retournées comme avec regexp.FindAllStringSubmatchIndex: paires de positions en octets
+// pour chaque groupe (-1 pour un groupe sans correspondance).
+type textRegex struct {
+	re2     *regexp.Regexp
+	perl    *regexp2.Regexp
+	numbers map[int]int    // Numéro de groupe → position dans les paires
+	names   map[string]int // Nom de groupe → position dans les paires
+}
+
+// compileTextRegex compile le motif avec les options i (casse), m (^ et $ en début et fin de
+// ligne) et s (. reconnaît aussi \n)
+func compileTextRegex(pattern, engine string, ignoreCase, multiline, dotAll bool) (*textRegex, error) {
+	if pattern == "" {
+		return nil, errors.New("expression régulière vide")
+	}
+
+	r := &textRegex{numbers: make(map[int]int), names: make(map[string]int)}
+	if engine == RegexEnginePerl {
+		var options regexp2.RegexOptions
+		if ignoreCase {
+			options |= regexp2.IgnoreCase
+		}
+		if multiline {
+			options |= regexp2.Multiline
+		}
+		if dotAll {
+			options |= regexp2.Singleline
+		}
+		re, err := regexp2.Compile(pattern, options)
+		if err != nil {
+			return nil, fmt.Errorf("expression régulière invalide: %w", err)
+		}
+		re.MatchTimeout = regexMatchTimeout
+		r.perl = re
+		names := re.GetGroupNames()
+		for i, number := range re.GetGroupNumbers() {
+			r.numbers[number] = i
+			r.names[names[i]] = i
+		}
+		return r, nil
+	}
+
+	var flags string
+	if ignoreCase {
+		flags += "i"
+	}
+	if multiline {
+		flags += "m"
+	}
+	if dotAll {
+		flags += "s"
+	}
+	if flags != "" {
+		pattern = "(?" + flags + ")" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("expression régulière invalide: %w", err)
+	}
+	r.re2 = re
+	for i, name := range re.SubexpNames() {
+		r.numbers[i] = i
+		if name != "" {
+			r.names[name] = i
+		}
+	}
+	return r, nil
+}
+
+// findAll retourne toutes les correspondances (sans chevauchement) dans s. La recherche
+// regexp2 s'interrompt entre deux correspondances si le contexte est annulé; celle de RE2 est
+// en temps linéaire.
+func (r *textRegex) findAll(ctx context.Context, s string) ([][]int, error) {
+	if r.re2 != nil {
+		return r.re2.FindAllStringSubmatchIndex(s, -1), nil
+	}
+
+	// regexp2 compte les positions en caractères: conversion en octets
+	offsets := make([]int, 0, len(s)+1)
+	for i := range s {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(s))
+
+	var matches [][]int
+	m, err := r.perl.FindStringMatch(s)
+	for ; m != nil && err == nil; m, err = r.perl.FindNextMatch(m) {
+		if cancelled(ctx) {
+			return nil, ctx.Err()
+		}
+		groups := m.Groups()
+		match := make([]int, 2*len(groups))
+		for i, g := range groups {
+			if len(g.Captures) == 0 {
+				match[2*i], match[2*i+1] = -1, -1
+				continue
+			}
+			match[2*i], match[2*i+1] = offsets[g.Index], offsets[g.Index+g.Length]
+		}
+		matches = append(matches, match)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("recherche interrompue après %v (trop de retours arrière): %w", regexMatchTimeout, err)
+	}
+	return matches, nil
+}
+
+// replacementPart morceau d'un modèle de remplacement: texte littéral ou référence à un groupe
+type replacementPart struct {
+	literal string
+	group   int // Position du groupe dans les paires, -1 pour un littéral
+}
+
+// parseReplacement analyse un modèle de remplacement, avec la même syntaxe pour les deux
+// moteurs: $0 ou $& (correspondance entière), $1, ${1}, ${nom}, $$ ($ littéral). Les chiffres
+// qui suivent $ font tous partie du numéro ($10 est le groupe 10).
+func (r *textRegex) parseReplacement(template string) ([]replacementPart, error) {
+	var parts []replacementPart
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			parts = append(parts, replacementPart{literal: literal.String(), group: -1})
+			literal.Reset()
+		}
+	}
+	reference := func(ref string) error {
+		index, ok := r.names[ref]
+		if number, err := strconv.Atoi(ref); err == nil {
+			index, ok = r.numbers[number]
+		}
+		if !ok {
+			return fmt.Errorf("remplacement: groupe de capture inconnu ${%s}", ref)
+		}
+		flush()
+		parts = append(parts, replacementPart{group: index})
+		return nil
+	}
+
+	for i := 0; i < len(template); i++ {
+		if template[i] != '$' || i+1 == len(template) {
+			literal.WriteByte(template[i])
+			continue
+		}
+		next := template[i+1]
+		switch {
+		case next == '$':
+			literal.WriteByte('$')
+			i++
+		case next == '&':
+			if err := reference("0"); err != nil {
+				return nil, err
+			}
+			i++
+		case next >= '0' && next <= '9':
+			end := i + 1
+			for end < len(template) && template[end] >= '0' && template[end] <= '9' {
+				end++
+			}
+			if err := reference(template[i+1 : end]); err != nil {
+				return nil, err
+			}
+			i = end - 1
+		case next == '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("remplacement: accolade non fermée après $ (position %d)", i+1)
+			}
+			if err := reference(template[i+2 : i+end]); err != nil {
+				return nil, err
+			}
+			i += end
+		default:
+			literal.WriteByte('$')
+		}
+	}
+	flush()
+	return parts, nil
+}
+
+// expandMatches remplace dans s les correspondances (positions en octets dans s) par le modèle
+func expandMatches(s string, matches [][]int, parts []replacementPart) string {
+	if len(matches) == 0 {
+		return s
+	}
+
+	var out strings.Builder
+	last := 0
+	for _, match := range matches {
+		out.WriteString(s[last:match[0]])
+		for _, part := range parts {
+			if part.group < 0 {
+				out.WriteString(part.literal)
+			} else if start := match[2*part.group]; start >= 0 {
+				out.WriteString(s[start:match[2*part.group+1]])
+			}
+		}
+		last = match[1]
+	}
+	out.WriteString(s[last:])
+	return out.String()
+}
+
+// eachLine applique fn à chaque ligne (sans le \r d'une fin de ligne Windows) avec la position
+// de son début dans le texte, et reconstruit le texte avec les lignes retournées. Le parcours
+// s'arrête si le contexte est annulé.
+func eachLine(ctx context.Context, s string, fn func(line string, offset int) (string, error)) (string, error) {
+	lines := strings.Split(s, "\n")
+	offset := 0
+	for i, line := range lines {
+		if cancelled(ctx) {
+			return "", ctx.Err()
+		}
+		cr := strings.HasSuffix(line, "\r")
+		result, err := fn(strings.TrimSuffix(line, "\r"), offset)
+		if err != nil {
+			return "", err
+		}
+		offset += len(line) + 1
+		if cr {
+			result += "\r"
+		}
+		lines[i] = result
+	}
+	return strings.Join(lines, "\n"), nil
+}
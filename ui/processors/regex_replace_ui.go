@@ -0,0 +1,403 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// maxHighlightedMatches limite le nombre de correspondances surlignées dans l'aperçu
+const maxHighlightedMatches = 1000
+
+// regexPreviewDelay pause de la saisie après laquelle l'aperçu est recalculé
+const regexPreviewDelay = 200 * time.Millisecond
+
+// RegexReplaceUI implémente Processor pour le rechercher/remplacer par expression régulière
+type RegexReplaceUI struct {
+	viewModel *RegexReplaceViewModel
+}
+
+func NewRegexReplaceUI() Processor {
+	return &RegexReplaceUI{
+		viewModel: NewRegexReplaceViewModel(),
+	}
+}
+
+func (ui *RegexReplaceUI) Name() string {
+	return "Remplacement regex"
+}
+
+func (ui *RegexReplaceUI) Description() string {
+	return "Recherche et remplace avec une expression régulière ($1, ${nom})"
+}
+
+func (ui *RegexReplaceUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *RegexReplaceUI) CreateConfigurationUI() fyne.CanvasObject {
+	vm := ui.viewModel
+
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("Entrez votre texte ici...")
+	input.Wrapping = fyne.TextWrapOff
+	input.SetMinRowsVisible(6)
+
+	patternEntry := widget.NewEntry()
+	patternEntry.SetPlaceHolder(`(?P<annee>\d{4})-(\d{2})-(\d{2})`)
+	patternEntry.SetText(vm.pattern)
+
+	replacementEntry := widget.NewEntry()
+	replacementEntry.SetPlaceHolder("$3/$2/${annee}")
+	replacementEntry.SetText(vm.replacement)
+
+	engineSelect := widget.NewSelect(RegexEngines, nil)
+	engineSelect.SetSelected(vm.engine)
+
+	ignoreCaseCheck := widget.NewCheck("Ignorer la casse (i)", nil)
+	ignoreCaseCheck.SetChecked(vm.ignoreCase)
+
+	multilineCheck := widget.NewCheck("Multiligne (m)", nil)
+	multilineCheck.SetChecked(vm.multiline)
+
+	dotAllCheck := widget.NewCheck(". inclut \\n (s)", nil)
+	dotAllCheck.SetChecked(vm.dotAll)
+
+	perLineCheck := widget.NewCheck("Ligne par ligne", nil)
+	perLineCheck.SetChecked(vm.perLine)
+
+	status := widget.NewLabel("")
+
+	preview := widget.NewRichText()
+	preview.Wrapping = fyne.TextWrapOff
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapOff
+	output.Disable()
+
+	// Mise à jour en direct: nombre de correspondances, aperçu surligné et résultat. La recherche
+	// est lancée hors du thread de l'interface après une pause de la saisie; une recherche
+	// rendue obsolète par une modification est annulée et son résultat ignoré.
+	var debounce *time.Timer
+	cancelSearch := func() {}
+	generation := 0
+	update := func() {
+		if debounce != nil {
+			debounce.Stop()
+		}
+		cancelSearch()
+		generation++
+		current := generation
+		options := vm.GetConfiguration().(RegexReplaceOptions)
+		text := input.Text
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelSearch = cancel
+
+		debounce = time.AfterFunc(regexPreviewDelay, func() {
+			matches, result, err := options.replace(ctx, text)
+			fyne.Do(func() {
+				if current != generation {
+					return
+				}
+				if err != nil {
+					status.SetText(err.Error())
+					preview.Segments = regexHighlightSegments(text, nil)
+					preview.Refresh()
+					output.SetText("")
+					return
+				}
+				vm.lastResult, vm.lastCount = result, len(matches)
+				status.SetText(fmt.Sprintf("%d correspondance(s)", len(matches)))
+				preview.Segments = regexHighlightSegments(text, matches)
+				preview.Refresh()
+				output.SetText(result)
+			})
+		})
+	}
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := vm.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	input.OnChanged = func(string) {
+		update()
+	}
+	patternEntry.OnChanged = func(s string) {
+		vm.pattern = s
+		update()
+	}
+	replacementEntry.OnChanged = func(s string) {
+		vm.replacement = s
+		update()
+	}
+	engineSelect.OnChanged = func(s string) {
+		vm.engine = s
+		update()
+	}
+	ignoreCaseCheck.OnChanged = func(checked bool) {
+		vm.ignoreCase = checked
+		update()
+	}
+	multilineCheck.OnChanged = func(checked bool) {
+		vm.multiline = checked
+		update()
+	}
+	dotAllCheck.OnChanged = func(checked bool) {
+		vm.dotAll = checked
+		update()
+	}
+	perLineCheck.OnChanged = func(checked bool) {
+		vm.perLine = checked
+		update()
+	}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée:"),
+		input,
+		container.NewBorder(nil, nil, widget.NewLabel("Expression:"), nil, patternEntry),
+		container.NewBorder(nil, nil, widget.NewLabel("Remplacement:"), nil, replacementEntry),
+		container.NewHBox(
+			widget.NewLabel("Moteur:"),
+			engineSelect,
+			ignoreCaseCheck,
+			multilineCheck,
+			dotAllCheck,
+			perLineCheck,
+		),
+		container.NewHBox(
+			widget.NewLabel("Correspondances:"),
+			status,
+		),
+	)
+
+	results := container.NewVSplit(
+		container.NewScroll(preview),
+		container.NewBorder(
+			container.NewHBox(widget.NewLabel("Résultat:"), copyBtn),
+			nil,
+			nil,
+			nil,
+			container.NewScroll(output),
+		),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		results,
+	)
+}
+
+// regexHighlightSegments affiche le texte avec les correspondances en couleur
+func regexHighlightSegments(text string, matches [][]int) []widget.RichTextSegment {
+	plain := widget.RichTextStyle{Inline: true, TextStyle: fyne.TextStyle{Monospace: true}}
+	highlighted := widget.RichTextStyle{
+		Inline:    true,
+		ColorName: theme.ColorNamePrimary,
+		TextStyle: fyne.TextStyle{Monospace: true, Bold: true},
+	}
+
+	var segments []widget.RichTextSegment
+	last := 0
+	for _, match := range matches[:min(len(matches), maxHighlightedMatches)] {
+		if match[0] == match[1] {
+			continue // Correspondance vide: rien à surligner
+		}
+		if match[0] > last {
+			segments = append(segments, &widget.TextSegment{Text: text[last:match[0]], Style: plain})
+		}
+		segments = append(segments, &widget.TextSegment{Text: text[match[0]:match[1]], Style: highlighted})
+		last = match[1]
+	}
+	if last < len(text) {
+		segments = append(segments, &widget.TextSegment{Text: text[last:], Style: plain})
+	}
+	return segments
+}
+
+// RegexReplaceOptions configuration du ViewModel de remplacement par expression régulière
+type RegexReplaceOptions struct {
+	Pattern     string
+	Replacement string // $0 ou $&, $1, ${1}, ${nom}; $$ pour un $ littéral
+	Engine      string // Une des valeurs de RegexEngines
+	IgnoreCase  bool
+	Multiline   bool // ^ et $ reconnaissent les débuts et fins de ligne
+	DotAll      bool // . reconnaît aussi les retours à la ligne
+	PerLine     bool // Appliquer l'expression à chaque ligne séparément
+}
+
+// RegexReplaceViewModel implémente ViewModel pour le remplacement par expression régulière
+type RegexReplaceViewModel struct {
+	pattern     string
+	replacement string
+	engine      string
+	ignoreCase  bool
+	multiline   bool
+	dotAll      bool
+	perLine     bool
+	lastResult  string
+	lastCount   int
+}
+
+func NewRegexReplaceViewModel() *RegexReplaceViewModel {
+	return &RegexReplaceViewModel{
+		engine: RegexEngineRE2,
+	}
+}
+
+// replace compile l'expression une seule fois, puis retourne les correspondances (positions en
+// octets dans input, groupe 0 en tête) et le texte remplacé. Ne dépend que des options: peut
+// être appelé hors du thread de l'interface sur une copie de la configuration.
+func (o RegexReplaceOptions) replace(ctx context.Context, input string) ([][]int, string, error) {
+	re, err := compileTextRegex(o.Pattern, o.Engine, o.IgnoreCase, o.Multiline, o.DotAll)
+	if err != nil {
+		return nil, "", err
+	}
+	parts, err := re.parseReplacement(o.Replacement)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var matches [][]int
+	if o.PerLine {
+		// Positions ramenées au texte complet: remplacer ligne par ligne revient alors à
+		// remplacer dans le texte complet
+		_, err = eachLine(ctx, input, func(line string, offset int) (string, error) {
+			found, err := re.findAll(ctx, line)
+			for _, match := range found {
+				for i := range match {
+					if match[i] >= 0 {
+						match[i] += offset
+					}
+				}
+				matches = append(matches, match)
+			}
+			return line, err
+		})
+	} else {
+		matches, err = re.findAll(ctx, input)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return matches, expandMatches(input, matches, parts), nil
+}
+
+func (vm *RegexReplaceViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext remplace les correspondances en s'interrompant si le contexte est annulé
+func (vm *RegexReplaceViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	matches, result, err := vm.GetConfiguration().(RegexReplaceOptions).replace(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	vm.lastResult, vm.lastCount = result, len(matches)
+	return result, nil
+}
+
+func (vm *RegexReplaceViewModel) GetConfiguration() interface{} {
+	return RegexReplaceOptions{
+		Pattern:     vm.pattern,
+		Replacement: vm.replacement,
+		Engine:      vm.engine,
+		IgnoreCase:  vm.ignoreCase,
+		Multiline:   vm.multiline,
+		DotAll:      vm.dotAll,
+		PerLine:     vm.perLine,
+	}
+}
+
+func (vm *RegexReplaceViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(RegexReplaceOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.pattern = cfg.Pattern
+	vm.replacement = cfg.Replacement
+	vm.engine = cfg.Engine
+	if vm.engine == "" {
+		vm.engine = RegexEngineRE2
+	}
+	vm.ignoreCase = cfg.IgnoreCase
+	vm.multiline = cfg.Multiline
+	vm.dotAll = cfg.DotAll
+	vm.perLine = cfg.PerLine
+	return nil
+}
+
+func (vm *RegexReplaceViewModel) Validate() error {
+	return vm.GetConfiguration().(RegexReplaceOptions).Validate()
+}
+
+// Validate vérifie le moteur, compile l'expression et contrôle les références du
+// remplacement
+func (o RegexReplaceOptions) Validate() error {
+	if o.Engine != "" && !slices.Contains(RegexEngines, o.Engine) {
+		return fmt.Errorf("moteur d'expressions régulières invalide: %s", o.Engine)
+	}
+	re, err := compileTextRegex(o.Pattern, o.Engine, o.IgnoreCase, o.Multiline, o.DotAll)
+	if err != nil {
+		return err
+	}
+	_, err = re.parseReplacement(o.Replacement)
+	return err
+}
+
+func (vm *RegexReplaceViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// RegexReplaceTool identifiant de l'outil "Remplacement regex" dans les pipelines sérialisés
+const RegexReplaceTool ToolType = "regex_replace"
+
+func init() {
+	Register(Registration{
+		Type:        RegexReplaceTool,
+		Name:        "Remplacement regex",
+		Description: "Recherche et remplace avec une expression régulière et des groupes de capture",
+		New:         NewRegexReplaceUI,
+		Codec:       NewConfigCodec(RegexReplaceConfig.Options, func(o RegexReplaceOptions) RegexReplaceConfig { return RegexReplaceConfig(o) }),
+	})
+}
+
+// RegexReplaceConfig configuration pour le remplacement par expression régulière
+type RegexReplaceConfig struct {
+	Pattern     string
+	Replacement string // $0 ou $&, $1, ${1}, ${nom}; $$ pour un $ littéral
+	Engine      string `json:",omitempty"` // "RE2 (Go)" (défaut) ou "Perl (regexp2)"
+	IgnoreCase  bool   `json:",omitempty"`
+	Multiline   bool   `json:",omitempty"`
+	DotAll      bool   `json:",omitempty"`
+	PerLine     bool   `json:",omitempty"`
+}
+
+func (c RegexReplaceConfig) GetType() ToolType {
+	return RegexReplaceTool
+}
+
+func (c RegexReplaceConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c RegexReplaceConfig) GetDisplayName() string {
+	return fmt.Sprintf("Remplacement regex (%s → %s)", c.Pattern, c.Replacement)
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c RegexReplaceConfig) Options() RegexReplaceOptions {
+	return RegexReplaceOptions(c)
+}
@@ -0,0 +1,175 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegexReplace(t *testing.T) {
+	tests := []struct {
+		name    string
+		options RegexReplaceOptions
+		engines []string // Moteurs testés (les deux si vide)
+		input   string
+		want    string
+	}{
+		{
+			name:    "groupes numérotés",
+			options: RegexReplaceOptions{Pattern: `(\w+) (\w+)`, Replacement: "$2 $1"},
+			input:   "un deux, trois quatre",
+			want:    "deux un, quatre trois",
+		},
+		{
+			name:    "correspondance entière",
+			options: RegexReplaceOptions{Pattern: `\d+`, Replacement: "<$0|$&>"},
+			input:   "a12b3",
+			want:    "a<12|12>b<3|3>",
+		},
+		{
+			name:    "accolades avant un chiffre",
+			options: RegexReplaceOptions{Pattern: `(\d)`, Replacement: "${1}0"},
+			input:   "x7",
+			want:    "x70",
+		},
+		{
+			name:    "dollar littéral",
+			options: RegexReplaceOptions{Pattern: `(\d+)`, Replacement: "$$$1 $x $"},
+			input:   "5",
+			want:    "$5 $x $",
+		},
+		{
+			name:    "groupe nommé RE2",
+			options: RegexReplaceOptions{Pattern: `(?P<mot>\w+)@`, Replacement: "[${mot}]"},
+			engines: []string{RegexEngineRE2},
+			input:   "a@ b@",
+			want:    "[a] [b]",
+		},
+		{
+			name:    "groupe nommé Perl",
+			options: RegexReplaceOptions{Pattern: `(?<mot>\w+)@`, Replacement: "[${mot}]"},
+			engines: []string{RegexEnginePerl},
+			input:   "a@ b@",
+			want:    "[a] [b]",
+		},
+		{
+			name:    "assertion avant Perl",
+			options: RegexReplaceOptions{Pattern: `\d(?=px)`, Replacement: "N"},
+			engines: []string{RegexEnginePerl},
+			input:   "1px 2em",
+			want:    "Npx 2em",
+		},
+		{
+			name:    "groupe sans correspondance",
+			options: RegexReplaceOptions{Pattern: `a(x)?b`, Replacement: "[$1]"},
+			input:   "ab axb",
+			want:    "[] [x]",
+		},
+		{
+			name:    "positions après des caractères multi-octets",
+			options: RegexReplaceOptions{Pattern: `\d`, Replacement: "#"},
+			input:   "été1 ç2",
+			want:    "été# ç#",
+		},
+		{
+			name:    "casse ignorée",
+			options: RegexReplaceOptions{Pattern: `abc`, Replacement: "x", IgnoreCase: true},
+			input:   "ABC aBc",
+			want:    "x x",
+		},
+		{
+			name:    "début de ligne en multiligne",
+			options: RegexReplaceOptions{Pattern: `^`, Replacement: "> ", Multiline: true},
+			input:   "a\nb",
+			want:    "> a\n> b",
+		},
+		{
+			name:    "point et retours à la ligne",
+			options: RegexReplaceOptions{Pattern: `<.*>`, Replacement: "_", DotAll: true},
+			input:   "<a\nb>",
+			want:    "_",
+		},
+		{
+			name:    "ligne par ligne avec fins de ligne Windows",
+			options: RegexReplaceOptions{Pattern: `(\w)$`, Replacement: "[$1]", PerLine: true},
+			input:   "ab\r\ncd\r\n",
+			want:    "a[b]\r\nc[d]\r\n",
+		},
+	}
+	for _, tt := range tests {
+		engines := tt.engines
+		if engines == nil {
+			engines = RegexEngines
+		}
+		for _, engine := range engines {
+			t.Run(tt.name+"/"+engine, func(t *testing.T) {
+				options := tt.options
+				options.Engine = engine
+				vm := NewRegexReplaceViewModel()
+				if err := vm.LoadConfiguration(options); err != nil {
+					t.Fatal(err)
+				}
+				if err := vm.Validate(); err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				got, err := vm.Process(tt.input)
+				if err != nil {
+					t.Fatalf("Process: %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("Process = %q, attendu %q", got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestRegexReplaceErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		options RegexReplaceOptions
+		wantErr string // Extrait attendu du message d'erreur
+	}{
+		{"expression vide", RegexReplaceOptions{}, "expression régulière vide"},
+		{"expression invalide", RegexReplaceOptions{Pattern: `(`}, "expression régulière invalide"},
+		{"assertion avant en RE2", RegexReplaceOptions{Pattern: `a(?=b)`}, "expression régulière invalide"},
+		{"groupe numéroté inconnu", RegexReplaceOptions{Pattern: `(a)`, Replacement: "$2"}, "${2}"},
+		{"$10 est le groupe 10", RegexReplaceOptions{Pattern: `(a)`, Replacement: "$10"}, "${10}"},
+		{"groupe nommé inconnu", RegexReplaceOptions{Pattern: `(?P<a>x)`, Replacement: "${b}"}, "${b}"},
+		{"accolade non fermée", RegexReplaceOptions{Pattern: `a`, Replacement: "x${1"}, "accolade non fermée"},
+		{"moteur inconnu", RegexReplaceOptions{Pattern: `a`, Engine: "PCRE"}, "moteur"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("erreur %v, attendu un message contenant %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegexReplaceCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		options RegexReplaceOptions
+	}{
+		{"recherche regexp2", RegexReplaceOptions{Pattern: `a`, Engine: RegexEnginePerl}},
+		{"ligne par ligne", RegexReplaceOptions{Pattern: `a`, Engine: RegexEngineRE2, PerLine: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewRegexReplaceViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := vm.ProcessContext(ctx, "aaa\naaa"); !errors.Is(err, context.Canceled) {
+				t.Errorf("erreur %v, attendu %v", err, context.Canceled)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package processors
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ToolType identifie un type de processeur dans les pipelines sérialisés
+type ToolType string
+
+// ToolConfig interface commune pour toutes les configurations d'outils
+type ToolConfig interface {
+	GetType() ToolType
+	Validate() error
+	GetDisplayName() string
+}
+
+// BaseDirConfig implémenté par les configurations qui référencent des fichiers. Au chargement
+// d'un pipeline, WithBaseDir reçoit le répertoire du fichier pipeline: les chemins relatifs de
+// la configuration sont résolus par rapport à lui plutôt qu'au répertoire courant.
+type BaseDirConfig interface {
+	ToolConfig
+	WithBaseDir(dir string) ToolConfig
+}
+
+// ConfigCodec convertit la configuration d'un outil entre sa forme de pipeline (ToolConfig, JSON)
+// et la forme attendue par le ViewModel du processeur
+type ConfigCodec struct {
+	// Decode construit la configuration à partir du JSON d'une étape de pipeline
+	Decode func(data json.RawMessage) (ToolConfig, error)
+	// ToViewModel retourne la valeur à passer à ViewModel.LoadConfiguration
+	ToViewModel func(config ToolConfig) (interface{}, error)
+	// FromViewModel construit la configuration à partir de ViewModel.GetConfiguration
+	FromViewModel func(vmConfig interface{}) (ToolConfig, error)
+}
+
+// Registration décrit un processeur disponible dans la grille, le Pipeline Builder et les pipelines sérialisés
+type Registration struct {
+	Type        ToolType
+	Name        string // Nom affiché (grille, sélecteur du Pipeline Builder)
+	Description string
+	Hidden      bool // Non proposé dans la grille ni le sélecteur (ex: processeurs personnalisés, listés à part)
+	New         func() Processor
+	Codec       ConfigCodec
+}
+
+// NewConfigCodec construit le codec d'un outil dont la configuration de pipeline C se convertit
+// en options O du ViewModel avec toOptions, et inversement avec fromOptions
+func NewConfigCodec[C ToolConfig, O any](toOptions func(C) O, fromOptions func(O) C) ConfigCodec {
+	return ConfigCodec{
+		Decode: decodeConfig[C],
+		ToViewModel: func(config ToolConfig) (interface{}, error) {
+			cfg, err := configAs[C](config)
+			if err != nil {
+				return nil, err
+			}
+			return toOptions(cfg), nil
+		},
+		FromViewModel: func(vmConfig interface{}) (ToolConfig, error) {
+			opts, ok := vmConfig.(O)
+			if !ok {
+				var zero C
+				return nil, fmt.Errorf("configuration invalide pour %s", zero.GetType())
+			}
+			return fromOptions(opts), nil
+		},
+	}
+}
+
+// decodeConfig décode la configuration JSON d'une étape vers son type concret
+func decodeConfig[C ToolConfig](data json.RawMessage) (ToolConfig, error) {
+	var cfg C
+	if len(data) > 0 && string(data) != "null" {
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// configAs retourne la configuration sous son type concret (valeur ou pointeur)
+func configAs[C ToolConfig](config ToolConfig) (C, error) {
+	if cfg, ok := config.(C); ok {
+		return cfg, nil
+	}
+	if cfg, ok := interface{}(config).(*C); ok && cfg != nil {
+		return *cfg, nil
+	}
+	var zero C
+	return zero, fmt.Errorf("configuration invalide pour %s", zero.GetType())
+}
+
+var (
+	registryMu    sync.RWMutex
+	registry      = map[ToolType]Registration{}
+	registryOrder []ToolType
+)
+
+// Register enregistre un processeur. Destiné à être appelé depuis une fonction init(), à côté
+// du processeur, ce qui permet à un package externe d'ajouter des processeurs par simple import:
+//
+//	func init() {
+//		processors.Register(processors.Registration{
+//			Type:  "mon_outil",
+//			Name:  "Mon outil",
+//			New:   NewMonOutilUI,
+//			Codec: processors.NewConfigCodec(MonOutilConfig.Options, NewMonOutilConfig),
+//		})
+//	}
+//
+// Panique si le type est vide, déjà enregistré, ou si la fabrique ou le codec sont incomplets.
+func Register(reg Registration) {
+	if reg.Type == "" {
+		panic("processors: enregistrement sans type d'outil")
+	}
+	if reg.New == nil || reg.Codec.Decode == nil || reg.Codec.ToViewModel == nil || reg.Codec.FromViewModel == nil {
+		panic(fmt.Sprintf("processors: enregistrement incomplet pour %q", reg.Type))
+	}
+	if reg.Name == "" {
+		reg.Name = string(reg.Type)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[reg.Type]; exists {
+		panic(fmt.Sprintf("processors: type d'outil %q déjà enregistré", reg.Type))
+	}
+	registry[reg.Type] = reg
+	registryOrder = append(registryOrder, reg.Type)
+}
+
+// Lookup retourne l'enregistrement associé à un type d'outil
+func Lookup(toolType ToolType) (Registration, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	reg, ok := registry[toolType]
+	return reg, ok
+}
+
+// LookupByName retourne l'enregistrement associé à un nom affiché
+func LookupByName(name string) (Registration, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, t := range registryOrder {
+		if registry[t].Name == name {
+			return registry[t], true
+		}
+	}
+	return Registration{}, false
+}
+
+// Registered retourne les processeurs visibles, triés par nom: l'ordre des fonctions init()
+// dépend des noms de fichiers et des imports, il n'est pas significatif
+func Registered() []Registration {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	var regs []Registration
+	for _, t := range registryOrder {
+		if reg := registry[t]; !reg.Hidden {
+			regs = append(regs, reg)
+		}
+	}
+	slices.SortStableFunc(regs, func(a, b Registration) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return regs
+}
+
+// NewConfiguredProcessor crée un processeur et lui applique la configuration donnée
+func NewConfiguredProcessor(config ToolConfig) (Processor, error) {
+	reg, ok := Lookup(config.GetType())
+	if !ok {
+		return nil, fmt.Errorf("type d'outil inconnu: %s", config.GetType())
+	}
+	vmConfig, err := reg.Codec.ToViewModel(config)
+	if err != nil {
+		return nil, err
+	}
+	processor := reg.New()
+	if err := processor.ViewModel().LoadConfiguration(vmConfig); err != nil {
+		return nil, err
+	}
+	return processor, nil
+}
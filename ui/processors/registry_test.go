@@ -0,0 +1,116 @@
+package processors
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// unregisteredConfig configuration d'un type d'outil absent du registre
+type unregisteredConfig struct{}
+
+func (unregisteredConfig) GetType() ToolType      { return "outil_absent" }
+func (unregisteredConfig) Validate() error        { return nil }
+func (unregisteredConfig) GetDisplayName() string { return "Outil absent" }
+
+func TestRegistryRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ToolConfig
+	}{
+		{"formateur JSON", JSONFormatterConfig{IndentType: "4 espaces", SortKeys: true, Lenient: true}},
+		{"découpeur", TextSplitterConfig{Mode: TextSplitLines, Delimiter: "\n", ChunkSize: 3, TrimParts: true}},
+		{"CSV", CSVConfig{Separator: ";", Header: true, Columns: "nom,2", FilterOp: CSVFilterEquals, SortColumn: "nom", Output: CSVOutputTSV}},
+		{"TOML", TOMLConfig{Mode: TOMLModeToJSON, IndentType: "4 espaces"}},
+		{"processeur personnalisé", CustomProcessorConfig{Name: "maj", Script: "input.toUpperCase()", TimeoutMs: 500}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg, ok := Lookup(tt.config.GetType())
+			if !ok {
+				t.Fatalf("type %q non enregistré", tt.config.GetType())
+			}
+
+			// Configuration de pipeline → ViewModel → configuration de pipeline
+			processor, err := NewConfiguredProcessor(tt.config)
+			if err != nil {
+				t.Fatalf("NewConfiguredProcessor: %v", err)
+			}
+			back, err := reg.Codec.FromViewModel(processor.ViewModel().GetConfiguration())
+			if err != nil {
+				t.Fatalf("FromViewModel: %v", err)
+			}
+			if !reflect.DeepEqual(back, tt.config) {
+				t.Errorf("configuration relue depuis le ViewModel:\n%#v\nattendu\n%#v", back, tt.config)
+			}
+
+			// Configuration de pipeline → JSON → configuration de pipeline
+			data, err := json.Marshal(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := reg.Codec.Decode(data)
+			if err != nil {
+				t.Fatalf("Decode(%s): %v", data, err)
+			}
+			if !reflect.DeepEqual(decoded, tt.config) {
+				t.Errorf("configuration décodée de %s:\n%#v\nattendu\n%#v", data, decoded, tt.config)
+			}
+		})
+	}
+}
+
+// Chaque processeur enregistré accepte la configuration produite par son propre ViewModel
+func TestRegisteredDefaultConfigurations(t *testing.T) {
+	for _, reg := range Registered() {
+		t.Run(reg.Name, func(t *testing.T) {
+			config, err := reg.Codec.FromViewModel(reg.New().ViewModel().GetConfiguration())
+			if err != nil {
+				t.Fatalf("FromViewModel: %v", err)
+			}
+			if config.GetType() != reg.Type {
+				t.Errorf("type %q, attendu %q", config.GetType(), reg.Type)
+			}
+			if _, err := NewConfiguredProcessor(config); err != nil {
+				t.Errorf("NewConfiguredProcessor: %v", err)
+			}
+			if _, err := reg.Codec.Decode(nil); err != nil {
+				t.Errorf("Decode d'une configuration absente: %v", err)
+			}
+		})
+	}
+}
+
+func TestRegistryErrors(t *testing.T) {
+	if _, err := NewConfiguredProcessor(unregisteredConfig{}); err == nil {
+		t.Error("NewConfiguredProcessor d'un type absent: erreur attendue")
+	}
+
+	reg, _ := Lookup(JSONFormatterTool)
+	if _, err := reg.Codec.ToViewModel(TOMLConfig{}); err == nil {
+		t.Error("ToViewModel d'une configuration d'un autre outil: erreur attendue")
+	}
+	if _, err := reg.Codec.FromViewModel(TOMLOptions{}); err == nil {
+		t.Error("FromViewModel d'options d'un autre outil: erreur attendue")
+	}
+
+	tests := []struct {
+		name string
+		reg  Registration
+	}{
+		{"type vide", Registration{New: reg.New, Codec: reg.Codec}},
+		{"fabrique absente", Registration{Type: "outil_test", Codec: reg.Codec}},
+		{"codec incomplet", Registration{Type: "outil_test", New: reg.New}},
+		{"type déjà enregistré", Registration{Type: JSONFormatterTool, New: reg.New, Codec: reg.Codec}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Register: panique attendue")
+				}
+			}()
+			Register(tt.reg)
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Conversions entre données tabulaires (CSV) et JSON. Un tableau d'objets JSON devient une
+// ligne par objet; les objets imbriqués sont aplatis en colonnes nommées par leur chemin
+// pointé ("adresse.ville", "tags.0") et reconstruits à partir de ces noms dans l'autre sens.
+
+// Traitement des cellules vides lors de la conversion CSV → JSON
+const (
+	CSVEmptyNull   = "null"
+	CSVEmptyString = "Chaîne vide"
+	CSVEmptyOmit   = "Clé omise"
+)
+
+// CSVEmptyCells liste les valeurs acceptées pour CSVToJSONOptions.EmptyCells
+var CSVEmptyCells = []string{CSVEmptyNull, CSVEmptyString, CSVEmptyOmit}
+
+// Alignement des colonnes d'un tableau Markdown
+const (
+	MarkdownAlignAuto   = "Automatique"
+	MarkdownAlignLeft   = "Gauche"
+	MarkdownAlignCenter = "Centré"
+	MarkdownAlignRight  = "Droite"
+)
+
+// MarkdownAlignments liste les valeurs acceptées pour MarkdownTableOptions.Alignment
+var MarkdownAlignments = []string{MarkdownAlignAuto, MarkdownAlignLeft, MarkdownAlignCenter, MarkdownAlignRight}
+
+// Format de l'entrée du tableau Markdown
+const (
+	MarkdownSourceAuto = "Automatique"
+	MarkdownSourceCSV  = "CSV"
+	MarkdownSourceJSON = "JSON"
+)
+
+// MarkdownSources liste les valeurs acceptées pour MarkdownTableOptions.Source
+var MarkdownSources = []string{MarkdownSourceAuto, MarkdownSourceCSV, MarkdownSourceJSON}
+
+// jsonToTable convertit un tableau d'objets (ou un objet seul) en table avec en-tête. Les
+// colonnes apparaissent dans l'ordre de leur première occurrence; une clé absente d'un objet
+// donne une cellule vide. Avec arraysAsJSON, les tableaux sont écrits en JSON compact dans
+// une seule cellule au lieu d'une colonne par élément. L'annulation du contexte est vérifiée
+// à chaque objet.
+func jsonToTable(ctx context.Context, tree *jsonNode, arraysAsJSON bool) (*csvTable, error) {
+	objects := []*jsonNode{tree}
+	if tree.Kind == jsonArray {
+		objects = tree.Items
+	}
+
+	table := &csvTable{header: []string{}}
+	columns := make(map[string]int)
+	for i, object := range objects {
+		if cancelled(ctx) {
+			return nil, ctx.Err()
+		}
+		if object.Kind != jsonObject {
+			if tree.Kind == jsonArray {
+				return nil, fmt.Errorf("élément [%d]: objet attendu, %s trouvé", i, jsonTypeName(object))
+			}
+			return nil, fmt.Errorf("tableau d'objets attendu, %s trouvé", jsonTypeName(object))
+		}
+
+		var row []string
+		flattenJSON(object, "", arraysAsJSON, func(key, value string) {
+			column, ok := columns[key]
+			if !ok {
+				column = len(table.header)
+				columns[key] = column
+				table.header = append(table.header, key)
+			}
+			for len(row) <= column {
+				row = append(row, "")
+			}
+			row[column] = value
+		})
+		table.rows = append(table.rows, row)
+	}
+	return table, nil
+}
+
+// flattenJSON appelle set pour chaque valeur feuille avec son chemin pointé. Les objets et
+// tableaux vides sont écrits "{}" et "[]", null donne une cellule vide.
+func flattenJSON(n *jsonNode, path string, arraysAsJSON bool, set func(key, value string)) {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	switch {
+	case n.Kind == jsonObject && len(n.Members) > 0:
+		for _, m := range n.Members {
+			flattenJSON(m.Value, join(m.Key), arraysAsJSON, set)
+		}
+	case n.Kind == jsonArray && len(n.Items) > 0 && !arraysAsJSON:
+		for i, item := range n.Items {
+			flattenJSON(item, join(strconv.Itoa(i)), arraysAsJSON, set)
+		}
+	case n.Kind == jsonNull:
+		set(path, "")
+	default:
+		set(path, n.String())
+	}
+}
+
+// tableToJSON convertit une table avec en-tête en tableau d'objets. Avec nested, les noms de
+// colonnes pointés sont reconstruits en objets imbriqués (et en tableaux quand les clés sont
+// 0, 1, 2...). Avec inferTypes, les nombres, booléens, null et les cellules contenant un
+// objet ou un tableau JSON sont convertis; les autres cellules restent des chaînes.
+// L'annulation du contexte est vérifiée à chaque ligne.
+func tableToJSON(ctx context.Context, table *csvTable, nested, inferTypes bool, emptyCells string) (*jsonNode, error) {
+	if err := checkColumnNames(table.header, nested); err != nil {
+		return nil, err
+	}
+
+	result := newJSONArray()
+	for i, row := range table.rows {
+		if cancelled(ctx) {
+			return nil, ctx.Err()
+		}
+		if len(row) > len(table.header) && strings.Join(row[len(table.header):], "") != "" {
+			return nil, fmt.Errorf("ligne de données %d: %d cellules pour %d colonnes", i+1, len(row), len(table.header))
+		}
+
+		object := newJSONObject()
+		created := make(map[*jsonNode]bool)
+		for c, name := range table.header {
+			value := cell(row, c)
+			var node *jsonNode
+			switch {
+			case value == "" && emptyCells == CSVEmptyOmit:
+				continue
+			case value == "" && emptyCells == CSVEmptyString:
+				node = newJSONString("")
+			case value == "" && inferTypes:
+				node = newJSONNull()
+			case inferTypes:
+				node = inferCellType(value)
+			default:
+				node = newJSONString(value)
+			}
+
+			if nested {
+				setJSONPath(object, strings.Split(name, "."), node, created)
+			} else {
+				object.Set(name, node)
+			}
+		}
+		if nested {
+			indexedObjectsToArrays(object, created)
+		}
+		result.Items = append(result.Items, object)
+	}
+	return result, nil
+}
+
+// checkColumnNames refuse les noms vides ou en double et, avec nested, les colonnes en
+// conflit ("a" et "a.b" ne peuvent pas coexister)
+func checkColumnNames(header []string, nested bool) error {
+	names := make(map[string]bool)
+	for i, name := range header {
+		if name == "" {
+			return fmt.Errorf("colonne %d: nom vide dans l'en-tête", i+1)
+		}
+		if names[name] {
+			return fmt.Errorf("colonne %q en double dans l'en-tête", name)
+		}
+		names[name] = true
+	}
+	if !nested {
+		return nil
+	}
+
+	for _, name := range header {
+		segments := strings.Split(name, ".")
+		for i, segment := range segments {
+			if segment == "" {
+				return fmt.Errorf("colonne %q: segment vide dans le chemin pointé", name)
+			}
+			if prefix := strings.Join(segments[:i], "."); i > 0 && names[prefix] {
+				return fmt.Errorf("colonnes %q et %q en conflit: %q ne peut pas être à la fois une valeur et un objet", prefix, name, prefix)
+			}
+		}
+	}
+	return nil
+}
+
+// inferCellType convertit une cellule en valeur JSON typée
+func inferCellType(value string) *jsonNode {
+	switch value {
+	case "true", "false":
+		return newJSONBool(value == "true")
+	case "null":
+		return newJSONNull()
+	}
+	if jsonNumberLiteral.MatchString(value) {
+		return newJSONNumber(value)
+	}
+	if strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[") {
+		if node, err := parseJSONTree(value); err == nil {
+			return node
+		}
+	}
+	return newJSONString(value)
+}
+
+// setJSONPath place une valeur dans des objets imbriqués, créés si nécessaire et notés dans
+// created
+func setJSONPath(object *jsonNode, path []string, value *jsonNode, created map[*jsonNode]bool) {
+	for _, key := range path[:len(path)-1] {
+		child, ok := object.Get(key)
+		if !ok {
+			child = newJSONObject()
+			created[child] = true
+			object.Set(key, child)
+		}
+		object = child
+	}
+	object.Set(path[len(path)-1], value)
+}
+
+// indexedObjectsToArrays remplace récursivement les objets créés par setJSONPath dont les
+// clés sont exactement 0..n-1 (dans n'importe quel ordre) par des tableaux. Les valeurs lues
+// dans les cellules ne sont pas modifiées.
+func indexedObjectsToArrays(n *jsonNode, created map[*jsonNode]bool) *jsonNode {
+	if n.Kind != jsonObject {
+		return n
+	}
+	for i, m := range n.Members {
+		if created[m.Value] {
+			n.Members[i].Value = indexedObjectsToArrays(m.Value, created)
+		}
+	}
+	if !created[n] {
+		return n
+	}
+
+	items := make([]*jsonNode, len(n.Members))
+	for _, m := range n.Members {
+		index, err := strconv.Atoi(m.Key)
+		if err != nil || index < 0 || index >= len(items) || items[index] != nil || strconv.Itoa(index) != m.Key {
+			return n
+		}
+		items[index] = m.Value
+	}
+	return newJSONArray(items...)
+}
+
+// writeMarkdown écrit la table au format GitHub (GFM). Les barres verticales sont échappées
+// et les retours à la ligne remplacés par <br>. En alignement automatique, les colonnes
+// numériques sont alignées à droite. Sans compact, les colonnes sont complétées par des
+// espaces pour rester lisibles dans le texte source.
+func (t *csvTable) writeMarkdown(alignment string, compact bool) string {
+	width := t.width()
+	if width == 0 {
+		return ""
+	}
+
+	escape := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+	var records [][]string
+	if t.header == nil {
+		// Le format GFM impose une ligne d'en-tête: les colonnes sont numérotées
+		header := make([]string, width)
+		for c := range width {
+			header[c] = strconv.Itoa(c + 1)
+		}
+		records = append(records, header)
+	}
+	for _, record := range t.records() {
+		escaped := make([]string, width)
+		for c, v := range record {
+			escaped[c] = escape.Replace(v)
+		}
+		records = append(records, escaped)
+	}
+
+	aligns := make([]string, width)
+	widths := make([]int, width)
+	for c := range width {
+		aligns[c] = alignment
+		if alignment == MarkdownAlignAuto || alignment == "" {
+			aligns[c] = MarkdownAlignLeft
+			if t.numericColumn(c) {
+				aligns[c] = MarkdownAlignRight
+			}
+		}
+		widths[c] = 3
+		for _, record := range records {
+			widths[c] = max(widths[c], utf8.RuneCountInString(record[c]))
+		}
+	}
+
+	var out strings.Builder
+	writeRow := func(cells []string) {
+		out.WriteString("|")
+		for _, v := range cells {
+			out.WriteString(" " + v + " |")
+		}
+		out.WriteString("\n")
+	}
+	pad := func(v string, c int) string {
+		if compact {
+			return v
+		}
+		padding := widths[c] - utf8.RuneCountInString(v)
+		switch aligns[c] {
+		case MarkdownAlignRight:
+			return strings.Repeat(" ", padding) + v
+		case MarkdownAlignCenter:
+			return strings.Repeat(" ", padding/2) + v + strings.Repeat(" ", padding-padding/2)
+		}
+		return v + strings.Repeat(" ", padding)
+	}
+
+	for i, record := range records {
+		cells := make([]string, width)
+		for c, v := range record {
+			cells[c] = pad(v, c)
+		}
+		writeRow(cells)
+
+		if i == 0 {
+			for c := range width {
+				dashes := 3
+				if !compact {
+					dashes = widths[c]
+				}
+				switch aligns[c] {
+				case MarkdownAlignLeft:
+					cells[c] = ":" + strings.Repeat("-", dashes-1)
+				case MarkdownAlignCenter:
+					cells[c] = ":" + strings.Repeat("-", dashes-2) + ":"
+				case MarkdownAlignRight:
+					cells[c] = strings.Repeat("-", dashes-1) + ":"
+				}
+			}
+			writeRow(cells)
+		}
+	}
+	return out.String()
+}
+
+// numericColumn indique si toutes les cellules non vides de la colonne sont des nombres
+// (faux pour une colonne sans valeur)
+func (t *csvTable) numericColumn(column int) bool {
+	found := false
+	for _, row := range t.rows {
+		if v := cell(row, column); strings.TrimSpace(v) != "" {
+			if _, ok := parseCSVNumber(v); !ok {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
@@ -0,0 +1,248 @@
+package processors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSVToJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		options CSVToJSONOptions
+		input   string
+		want    string
+	}{
+		{
+			name:  "types déduits",
+			input: "n,s,b,v,o\n42,texte,true,,\"[1,2]\"\n",
+			want:  `[{"n":42,"s":"texte","b":true,"v":null,"o":[1,2]}]`,
+		},
+		{
+			name:    "chaînes uniquement",
+			options: CSVToJSONOptions{StringsOnly: true},
+			input:   "n,b\n42,true\n",
+			want:    `[{"n":"42","b":"true"}]`,
+		},
+		{
+			name:  "objets et tableaux imbriqués",
+			input: "id,adresse.ville,tags.0,tags.1\n1,Paris,a,b\n",
+			want:  `[{"id":1,"adresse":{"ville":"Paris"},"tags":["a","b"]}]`,
+		},
+		{
+			name:  "indices incomplets conservés en objet",
+			input: "t.0,t.2\na,b\n",
+			want:  `[{"t":{"0":"a","2":"b"}}]`,
+		},
+		{
+			name:    "clés pointées conservées",
+			options: CSVToJSONOptions{KeepDottedKeys: true},
+			input:   "a.b\n1\n",
+			want:    `[{"a.b":1}]`,
+		},
+		{
+			name:    "cellules vides omises",
+			options: CSVToJSONOptions{EmptyCells: CSVEmptyOmit},
+			input:   "a,b\n1,\n",
+			want:    `[{"a":1}]`,
+		},
+		{
+			name:    "cellules vides en chaîne",
+			options: CSVToJSONOptions{EmptyCells: CSVEmptyString},
+			input:   "a,b\n1\n",
+			want:    `[{"a":1,"b":""}]`,
+		},
+		{
+			name:    "séparateur point-virgule",
+			options: CSVToJSONOptions{Separator: ";"},
+			input:   "a;b\n1,5;x\n",
+			want:    `[{"a":"1,5","b":"x"}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := tt.options
+			options.IndentType = IndentMinified
+			vm := NewCSVToJSONViewModel()
+			if err := vm.LoadConfiguration(options); err != nil {
+				t.Fatal(err)
+			}
+			got, err := vm.Process(tt.input)
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if got != tt.want+"\n" {
+				t.Errorf("Process = %q, attendu %q", got, tt.want+"\n")
+			}
+		})
+	}
+}
+
+func TestCSVToJSONErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		options CSVToJSONOptions
+		input   string
+		wantErr string // Extrait attendu du message d'erreur
+	}{
+		{"nom de colonne vide", CSVToJSONOptions{}, "a,\n1,2\n", "nom vide"},
+		{"colonne en double", CSVToJSONOptions{}, "a,a\n1,2\n", "en double"},
+		{"colonnes en conflit", CSVToJSONOptions{}, "a,a.b\n1,2\n", "en conflit"},
+		{"segment vide", CSVToJSONOptions{}, "a..b\n1\n", "segment vide"},
+		{"cellules en trop", CSVToJSONOptions{}, "a\n1,2\n", "ligne de données 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewCSVToJSONViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			_, err := vm.Process(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("erreur %v, attendu un message contenant %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJSONToCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		options JSONToCSVOptions
+		input   string
+		want    string
+		wantErr string // Extrait attendu du message d'erreur ("" : succès attendu)
+	}{
+		{
+			name:  "colonnes dans l'ordre d'apparition",
+			input: `[{"a": 1, "b": "x"}, {"c": true, "a": 2}]`,
+			want:  "a,b,c\n1,x,\n2,,true\n",
+		},
+		{
+			name:  "objets aplatis",
+			input: `{"id": 1, "adresse": {"ville": "Paris"}, "tags": ["a", "b"], "vide": {}, "n": null}`,
+			want:  "id,adresse.ville,tags.0,tags.1,vide,n\n1,Paris,a,b,{},\n",
+		},
+		{
+			name:    "tableaux en JSON",
+			options: JSONToCSVOptions{ArraysAsJSON: true, Separator: ";"},
+			input:   `[{"tags": ["a", "b"]}]`,
+			want:    "tags\n\"[\"\"a\"\",\"\"b\"\"]\"\n",
+		},
+		{
+			name:    "élément non objet",
+			input:   `[{"a": 1}, 2]`,
+			wantErr: "élément [1]",
+		},
+		{
+			name:    "racine scalaire",
+			input:   `"x"`,
+			wantErr: "tableau d'objets attendu",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewJSONToCSVViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			got, err := vm.Process(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("erreur %v, attendu un message contenant %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Process =\n%s\nattendu\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// Un tableau d'objets converti en CSV puis relu redonne le même document
+func TestTabularRoundTrip(t *testing.T) {
+	inputs := []string{
+		`[{"id":1,"adresse":{"ville":"Paris","pays":"FR"},"tags":["a","b"]}]`,
+		`[{"a":true,"b":null},{"a":false,"b":1.5}]`,
+	}
+	for _, input := range inputs {
+		csv, err := NewJSONToCSVViewModel().Process(input)
+		if err != nil {
+			t.Fatalf("JSON → CSV: %v", err)
+		}
+		vm := NewCSVToJSONViewModel()
+		if err := vm.LoadConfiguration(CSVToJSONOptions{IndentType: IndentMinified}); err != nil {
+			t.Fatal(err)
+		}
+		got, err := vm.Process(csv)
+		if err != nil {
+			t.Fatalf("CSV → JSON: %v", err)
+		}
+		if got != input+"\n" {
+			t.Errorf("aller-retour de %s: %s (CSV intermédiaire %q)", input, got, csv)
+		}
+	}
+}
+
+func TestMarkdownTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		options MarkdownTableOptions
+		input   string
+		want    string
+	}{
+		{
+			name:  "alignement automatique",
+			input: "nom,âge\nAlice,30\nBob,9\n",
+			want:  "| nom   | âge |\n| :---- | --: |\n| Alice |  30 |\n| Bob   |   9 |\n",
+		},
+		{
+			name:    "compact et centré",
+			options: MarkdownTableOptions{Alignment: MarkdownAlignCenter, Compact: true},
+			input:   "a,b\n1,2\n",
+			want:    "| a | b |\n| :-: | :-: |\n| 1 | 2 |\n",
+		},
+		{
+			name:    "barres verticales et retours à la ligne échappés",
+			options: MarkdownTableOptions{Compact: true},
+			input:   "a\n\"x|y\nz\"\n",
+			want:    "| a |\n| :-- |\n| x\\|y<br>z |\n",
+		},
+		{
+			name:    "entrée JSON détectée",
+			options: MarkdownTableOptions{Compact: true},
+			input:   `[{"a": 1, "t": [1, 2]}]`,
+			want:    "| a | t |\n| --: | :-- |\n| 1 | [1,2] |\n",
+		},
+		{
+			name:    "CSV forcé",
+			options: MarkdownTableOptions{Source: MarkdownSourceCSV, Compact: true},
+			input:   "[a]\nx\n",
+			want:    "| [a] |\n| :-- |\n| x |\n",
+		},
+		{
+			name:    "lignes irrégulières complétées",
+			options: MarkdownTableOptions{Alignment: MarkdownAlignLeft},
+			input:   "a,b\n1\n",
+			want:    "| a   | b   |\n| :-- | :-- |\n| 1   |     |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewMarkdownTableViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			got, err := vm.Process(tt.input)
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Process =\n%s\nattendu\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,792 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// CSVToJSONUI implémente Processor pour la conversion CSV → JSON
+type CSVToJSONUI struct {
+	viewModel *CSVToJSONViewModel
+}
+
+func NewCSVToJSONUI() Processor {
+	return &CSVToJSONUI{
+		viewModel: NewCSVToJSONViewModel(),
+	}
+}
+
+func (ui *CSVToJSONUI) Name() string {
+	return "CSV → JSON"
+}
+
+func (ui *CSVToJSONUI) Description() string {
+	return "Convertit des données CSV en tableau d'objets JSON (colonnes pointées → objets imbriqués)"
+}
+
+func (ui *CSVToJSONUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *CSVToJSONUI) CreateConfigurationUI() fyne.CanvasObject {
+	vm := ui.viewModel
+
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("nom,adresse.ville,tags.0\nDupont,Paris,admin")
+	input.Wrapping = fyne.TextWrapOff
+	input.SetMinRowsVisible(8)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapOff
+	output.Disable()
+
+	status := widget.NewLabel("")
+
+	indentSelect := widget.NewSelect(JSONOutputIndentTypes, nil)
+	indentSelect.SetSelected(vm.indentType)
+
+	nestedCheck := widget.NewCheck("Colonnes pointées → objets imbriqués", nil)
+	nestedCheck.SetChecked(!vm.keepDottedKeys)
+
+	typesCheck := widget.NewCheck("Détecter nombres et booléens", nil)
+	typesCheck.SetChecked(!vm.stringsOnly)
+
+	emptySelect := widget.NewSelect(CSVEmptyCells, nil)
+	emptySelect.SetSelected(vm.emptyCells)
+
+	convertBtn := widget.NewButton("Convertir", func() {
+		result, err := vm.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			status.SetText("")
+			highlightJSONError(input, err)
+			return
+		}
+		output.SetText(result)
+		status.SetText(fmt.Sprintf("%d objet(s)", vm.lastCount))
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := vm.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	rerun := func() {
+		if input.Text != "" {
+			convertBtn.OnTapped()
+		}
+	}
+
+	separatorSelect := newCSVSeparatorSelect(vm.separator, func(separator string) {
+		vm.separator = separator
+		rerun()
+	})
+	indentSelect.OnChanged = func(s string) {
+		vm.indentType = s
+		rerun()
+	}
+	nestedCheck.OnChanged = func(checked bool) {
+		vm.keepDottedKeys = !checked
+		rerun()
+	}
+	typesCheck.OnChanged = func(checked bool) {
+		vm.stringsOnly = !checked
+		rerun()
+	}
+	emptySelect.OnChanged = func(s string) {
+		vm.emptyCells = s
+		rerun()
+	}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée CSV (la première ligne contient les noms des colonnes):"),
+		input,
+		container.NewHBox(
+			widget.NewLabel("Séparateur:"),
+			separatorSelect,
+			nestedCheck,
+			typesCheck,
+		),
+		container.NewHBox(
+			widget.NewLabel("Cellules vides:"),
+			emptySelect,
+			widget.NewLabel("Indentation:"),
+			indentSelect,
+			convertBtn,
+			copyBtn,
+		),
+		container.NewHBox(
+			widget.NewLabel("Résultat JSON:"),
+			status,
+		),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// CSVToJSONOptions configuration du ViewModel de conversion CSV → JSON. Les valeurs nulles
+// correspondent au comportement par défaut (objets imbriqués, types détectés).
+type CSVToJSONOptions struct {
+	Separator      string // Un caractère, "," par défaut
+	IndentType     string // Une des valeurs de JSONOutputIndentTypes
+	KeepDottedKeys bool   // Garder "a.b" comme nom de clé au lieu de créer des objets imbriqués
+	StringsOnly    bool   // Toutes les cellules deviennent des chaînes
+	EmptyCells     string // Une des valeurs de CSVEmptyCells
+}
+
+// CSVToJSONViewModel implémente ViewModel pour la conversion CSV → JSON
+type CSVToJSONViewModel struct {
+	separator      string
+	indentType     string
+	keepDottedKeys bool
+	stringsOnly    bool
+	emptyCells     string
+	lastResult     string
+	lastCount      int
+}
+
+func NewCSVToJSONViewModel() *CSVToJSONViewModel {
+	return &CSVToJSONViewModel{
+		separator:  ",",
+		indentType: "2 espaces",
+		emptyCells: CSVEmptyNull,
+	}
+}
+
+func (vm *CSVToJSONViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext convertit le tableau CSV en s'interrompant si le contexte est annulé
+func (vm *CSVToJSONViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		vm.lastResult, vm.lastCount = "", 0
+		return "", nil
+	}
+
+	separator, _ := utf8.DecodeRuneInString(vm.separator)
+	table, err := parseCSV(ctx, input, separator, false, true, true)
+	if err != nil {
+		return "", err
+	}
+	tree, err := tableToJSON(ctx, table, !vm.keepDottedKeys, !vm.stringsOnly, vm.emptyCells)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	tree.Write(&out, NewFormatter(vm.indentType).indent(), false)
+	out.WriteString("\n")
+
+	vm.lastResult, vm.lastCount = out.String(), len(tree.Items)
+	return vm.lastResult, nil
+}
+
+func (vm *CSVToJSONViewModel) GetConfiguration() interface{} {
+	return CSVToJSONOptions{
+		Separator:      vm.separator,
+		IndentType:     vm.indentType,
+		KeepDottedKeys: vm.keepDottedKeys,
+		StringsOnly:    vm.stringsOnly,
+		EmptyCells:     vm.emptyCells,
+	}
+}
+
+func (vm *CSVToJSONViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(CSVToJSONOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.separator = cfg.Separator
+	if vm.separator == "" {
+		vm.separator = ","
+	}
+	vm.indentType = cfg.IndentType
+	if vm.indentType == "" {
+		vm.indentType = "2 espaces"
+	}
+	vm.keepDottedKeys = cfg.KeepDottedKeys
+	vm.stringsOnly = cfg.StringsOnly
+	vm.emptyCells = cfg.EmptyCells
+	if vm.emptyCells == "" {
+		vm.emptyCells = CSVEmptyNull
+	}
+	return nil
+}
+
+func (vm *CSVToJSONViewModel) Validate() error {
+	return vm.GetConfiguration().(CSVToJSONOptions).Validate()
+}
+
+// Validate vérifie le séparateur, l'indentation et le traitement des cellules vides
+// (valeurs vides acceptées)
+func (o CSVToJSONOptions) Validate() error {
+	if err := validateCSVSeparator(o.Separator); err != nil {
+		return err
+	}
+	if o.EmptyCells != "" && !slices.Contains(CSVEmptyCells, o.EmptyCells) {
+		return fmt.Errorf("traitement des cellules vides invalide: %s", o.EmptyCells)
+	}
+	return validateOutputIndent(o.IndentType)
+}
+
+func (vm *CSVToJSONViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// JSONToCSVUI implémente Processor pour la conversion JSON → CSV
+type JSONToCSVUI struct {
+	viewModel *JSONToCSVViewModel
+}
+
+func NewJSONToCSVUI() Processor {
+	return &JSONToCSVUI{
+		viewModel: NewJSONToCSVViewModel(),
+	}
+}
+
+func (ui *JSONToCSVUI) Name() string {
+	return "JSON → CSV"
+}
+
+func (ui *JSONToCSVUI) Description() string {
+	return "Convertit un tableau d'objets JSON en CSV (objets imbriqués → colonnes pointées)"
+}
+
+func (ui *JSONToCSVUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *JSONToCSVUI) CreateConfigurationUI() fyne.CanvasObject {
+	vm := ui.viewModel
+
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder(`[{"nom": "Dupont", "adresse": {"ville": "Paris"}}]`)
+	input.Wrapping = fyne.TextWrapOff
+	input.SetMinRowsVisible(8)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapOff
+	output.Disable()
+
+	status := widget.NewLabel("")
+
+	arraysCheck := widget.NewCheck("Tableaux en JSON dans une cellule", nil)
+	arraysCheck.SetChecked(vm.arraysAsJSON)
+
+	convertBtn := widget.NewButton("Convertir", func() {
+		result, err := vm.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			status.SetText("")
+			highlightJSONError(input, err)
+			return
+		}
+		output.SetText(result)
+		status.SetText(fmt.Sprintf("%d ligne(s)", vm.lastCount))
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := vm.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	rerun := func() {
+		if input.Text != "" {
+			convertBtn.OnTapped()
+		}
+	}
+
+	separatorSelect := newCSVSeparatorSelect(vm.separator, func(separator string) {
+		vm.separator = separator
+		rerun()
+	})
+	arraysCheck.OnChanged = func(checked bool) {
+		vm.arraysAsJSON = checked
+		rerun()
+	}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée JSON (tableau d'objets):"),
+		input,
+		container.NewHBox(
+			widget.NewLabel("Séparateur:"),
+			separatorSelect,
+			arraysCheck,
+			convertBtn,
+			copyBtn,
+		),
+		container.NewHBox(
+			widget.NewLabel("Résultat CSV:"),
+			status,
+		),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// JSONToCSVOptions configuration du ViewModel de conversion JSON → CSV
+type JSONToCSVOptions struct {
+	Separator    string // Un caractère, "," par défaut
+	ArraysAsJSON bool   // Écrire les tableaux en JSON compact au lieu d'une colonne par élément
+}
+
+// JSONToCSVViewModel implémente ViewModel pour la conversion JSON → CSV
+type JSONToCSVViewModel struct {
+	separator    string
+	arraysAsJSON bool
+	lastResult   string
+	lastCount    int
+}
+
+func NewJSONToCSVViewModel() *JSONToCSVViewModel {
+	return &JSONToCSVViewModel{
+		separator: ",",
+	}
+}
+
+func (vm *JSONToCSVViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext convertit le document JSON en s'interrompant si le contexte est annulé
+func (vm *JSONToCSVViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		vm.lastResult, vm.lastCount = "", 0
+		return "", nil
+	}
+
+	tree, err := parseJSONTree(input)
+	if err != nil {
+		return "", err
+	}
+	table, err := jsonToTable(ctx, tree, vm.arraysAsJSON)
+	if err != nil {
+		return "", err
+	}
+
+	result := ""
+	if table.width() > 0 {
+		separator, _ := utf8.DecodeRuneInString(vm.separator)
+		if result, err = table.writeDelimited(separator); err != nil {
+			return "", err
+		}
+	}
+
+	vm.lastResult, vm.lastCount = result, len(table.rows)
+	return result, nil
+}
+
+func (vm *JSONToCSVViewModel) GetConfiguration() interface{} {
+	return JSONToCSVOptions{
+		Separator:    vm.separator,
+		ArraysAsJSON: vm.arraysAsJSON,
+	}
+}
+
+func (vm *JSONToCSVViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(JSONToCSVOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.separator = cfg.Separator
+	if vm.separator == "" {
+		vm.separator = ","
+	}
+	vm.arraysAsJSON = cfg.ArraysAsJSON
+	return nil
+}
+
+func (vm *JSONToCSVViewModel) Validate() error {
+	return vm.GetConfiguration().(JSONToCSVOptions).Validate()
+}
+
+// Validate vérifie le séparateur (valeur vide acceptée)
+func (o JSONToCSVOptions) Validate() error {
+	return validateCSVSeparator(o.Separator)
+}
+
+func (vm *JSONToCSVViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// MarkdownTableUI implémente Processor pour la génération de tableaux Markdown
+type MarkdownTableUI struct {
+	viewModel *MarkdownTableViewModel
+}
+
+func NewMarkdownTableUI() Processor {
+	return &MarkdownTableUI{
+		viewModel: NewMarkdownTableViewModel(),
+	}
+}
+
+func (ui *MarkdownTableUI) Name() string {
+	return "Tableau Markdown"
+}
+
+func (ui *MarkdownTableUI) Description() string {
+	return "Génère un tableau Markdown (GitHub) à partir de données CSV ou d'un tableau d'objets JSON"
+}
+
+func (ui *MarkdownTableUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *MarkdownTableUI) CreateConfigurationUI() fyne.CanvasObject {
+	vm := ui.viewModel
+
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("CSV avec ligne d'en-tête ou tableau d'objets JSON")
+	input.Wrapping = fyne.TextWrapOff
+	input.SetMinRowsVisible(8)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapOff
+	output.TextStyle = fyne.TextStyle{Monospace: true}
+	output.Disable()
+
+	status := widget.NewLabel("")
+
+	sourceSelect := widget.NewSelect(MarkdownSources, nil)
+	sourceSelect.SetSelected(vm.source)
+
+	alignSelect := widget.NewSelect(MarkdownAlignments, nil)
+	alignSelect.SetSelected(vm.alignment)
+
+	compactCheck := widget.NewCheck("Compact (sans alignement du texte)", nil)
+	compactCheck.SetChecked(vm.compact)
+
+	generateBtn := widget.NewButton("Générer", func() {
+		result, err := vm.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			status.SetText("")
+			highlightJSONError(input, err)
+			return
+		}
+		output.SetText(result)
+		status.SetText(fmt.Sprintf("%d ligne(s)", vm.lastCount))
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := vm.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	rerun := func() {
+		if input.Text != "" {
+			generateBtn.OnTapped()
+		}
+	}
+
+	separatorSelect := newCSVSeparatorSelect(vm.separator, func(separator string) {
+		vm.separator = separator
+		rerun()
+	})
+	sourceSelect.OnChanged = func(s string) {
+		vm.source = s
+		rerun()
+	}
+	alignSelect.OnChanged = func(s string) {
+		vm.alignment = s
+		rerun()
+	}
+	compactCheck.OnChanged = func(checked bool) {
+		vm.compact = checked
+		rerun()
+	}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée CSV ou JSON:"),
+		input,
+		container.NewHBox(
+			widget.NewLabel("Format:"),
+			sourceSelect,
+			widget.NewLabel("Séparateur CSV:"),
+			separatorSelect,
+		),
+		container.NewHBox(
+			widget.NewLabel("Alignement:"),
+			alignSelect,
+			compactCheck,
+			generateBtn,
+			copyBtn,
+		),
+		container.NewHBox(
+			widget.NewLabel("Tableau Markdown:"),
+			status,
+		),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// MarkdownTableOptions configuration du ViewModel de tableau Markdown
+type MarkdownTableOptions struct {
+	Source    string // Une des valeurs de MarkdownSources
+	Separator string // Séparateur de l'entrée CSV, "," par défaut
+	Alignment string // Une des valeurs de MarkdownAlignments
+	Compact   bool   // Ne pas compléter les cellules par des espaces
+}
+
+// MarkdownTableViewModel implémente ViewModel pour la génération de tableaux Markdown. En
+// détection automatique, une entrée commençant par [ ou { est lue comme du JSON.
+type MarkdownTableViewModel struct {
+	source     string
+	separator  string
+	alignment  string
+	compact    bool
+	lastResult string
+	lastCount  int
+}
+
+func NewMarkdownTableViewModel() *MarkdownTableViewModel {
+	return &MarkdownTableViewModel{
+		source:    MarkdownSourceAuto,
+		separator: ",",
+		alignment: MarkdownAlignAuto,
+	}
+}
+
+func (vm *MarkdownTableViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext construit le tableau Markdown en s'interrompant si le contexte est annulé
+// (à chaque ligne ou objet lu, puis avant l'écriture du tableau)
+func (vm *MarkdownTableViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		vm.lastResult, vm.lastCount = "", 0
+		return "", nil
+	}
+
+	source := vm.source
+	if source == MarkdownSourceAuto {
+		source = MarkdownSourceCSV
+		if strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{") {
+			source = MarkdownSourceJSON
+		}
+	}
+
+	var table *csvTable
+	if source == MarkdownSourceJSON {
+		tree, err := parseJSONTree(input)
+		if err != nil {
+			return "", err
+		}
+		if table, err = jsonToTable(ctx, tree, true); err != nil {
+			return "", err
+		}
+	} else {
+		separator, _ := utf8.DecodeRuneInString(vm.separator)
+		var err error
+		if table, err = parseCSV(ctx, input, separator, false, true, true); err != nil {
+			return "", err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	vm.lastResult, vm.lastCount = table.writeMarkdown(vm.alignment, vm.compact), len(table.rows)
+	return vm.lastResult, nil
+}
+
+func (vm *MarkdownTableViewModel) GetConfiguration() interface{} {
+	return MarkdownTableOptions{
+		Source:    vm.source,
+		Separator: vm.separator,
+		Alignment: vm.alignment,
+		Compact:   vm.compact,
+	}
+}
+
+func (vm *MarkdownTableViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(MarkdownTableOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.source = cfg.Source
+	if vm.source == "" {
+		vm.source = MarkdownSourceAuto
+	}
+	vm.separator = cfg.Separator
+	if vm.separator == "" {
+		vm.separator = ","
+	}
+	vm.alignment = cfg.Alignment
+	if vm.alignment == "" {
+		vm.alignment = MarkdownAlignAuto
+	}
+	vm.compact = cfg.Compact
+	return nil
+}
+
+func (vm *MarkdownTableViewModel) Validate() error {
+	return vm.GetConfiguration().(MarkdownTableOptions).Validate()
+}
+
+// Validate vérifie le format d'entrée, le séparateur et l'alignement (valeurs vides acceptées)
+func (o MarkdownTableOptions) Validate() error {
+	if o.Source != "" && !slices.Contains(MarkdownSources, o.Source) {
+		return fmt.Errorf("format d'entrée invalide: %s", o.Source)
+	}
+	if o.Alignment != "" && !slices.Contains(MarkdownAlignments, o.Alignment) {
+		return fmt.Errorf("alignement invalide: %s", o.Alignment)
+	}
+	return validateCSVSeparator(o.Separator)
+}
+
+func (vm *MarkdownTableViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// CSVToJSONTool identifiant de l'outil "CSV → JSON" dans les pipelines sérialisés
+const CSVToJSONTool ToolType = "csv_to_json"
+
+func init() {
+	Register(Registration{
+		Type:        CSVToJSONTool,
+		Name:        "CSV → JSON",
+		Description: "Convertit des données CSV en tableau d'objets JSON",
+		New:         NewCSVToJSONUI,
+		Codec:       NewConfigCodec(CSVToJSONConfig.Options, func(o CSVToJSONOptions) CSVToJSONConfig { return CSVToJSONConfig(o) }),
+	})
+}
+
+// CSVToJSONConfig configuration pour la conversion CSV → JSON
+type CSVToJSONConfig struct {
+	Separator      string `json:",omitempty"` // "," par défaut
+	IndentType     string `json:",omitempty"`
+	KeepDottedKeys bool   `json:",omitempty"` // Ne pas reconstruire les objets imbriqués
+	StringsOnly    bool   `json:",omitempty"` // Ne pas détecter nombres, booléens et null
+	EmptyCells     string `json:",omitempty"` // "null" (défaut), "Chaîne vide" ou "Clé omise"
+}
+
+func (c CSVToJSONConfig) GetType() ToolType {
+	return CSVToJSONTool
+}
+
+func (c CSVToJSONConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c CSVToJSONConfig) GetDisplayName() string {
+	return "CSV → JSON"
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c CSVToJSONConfig) Options() CSVToJSONOptions {
+	return CSVToJSONOptions(c)
+}
+
+// JSONToCSVTool identifiant de l'outil "JSON → CSV" dans les pipelines sérialisés
+const JSONToCSVTool ToolType = "json_to_csv"
+
+func init() {
+	Register(Registration{
+		Type:        JSONToCSVTool,
+		Name:        "JSON → CSV",
+		Description: "Convertit un tableau d'objets JSON en CSV",
+		New:         NewJSONToCSVUI,
+		Codec:       NewConfigCodec(JSONToCSVConfig.Options, func(o JSONToCSVOptions) JSONToCSVConfig { return JSONToCSVConfig(o) }),
+	})
+}
+
+// JSONToCSVConfig configuration pour la conversion JSON → CSV
+type JSONToCSVConfig struct {
+	Separator    string `json:",omitempty"` // "," par défaut
+	ArraysAsJSON bool   `json:",omitempty"`
+}
+
+func (c JSONToCSVConfig) GetType() ToolType {
+	return JSONToCSVTool
+}
+
+func (c JSONToCSVConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c JSONToCSVConfig) GetDisplayName() string {
+	return "JSON → CSV"
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c JSONToCSVConfig) Options() JSONToCSVOptions {
+	return JSONToCSVOptions(c)
+}
+
+// MarkdownTableTool identifiant de l'outil "Tableau Markdown" dans les pipelines sérialisés
+const MarkdownTableTool ToolType = "markdown_table"
+
+func init() {
+	Register(Registration{
+		Type:        MarkdownTableTool,
+		Name:        "Tableau Markdown",
+		Description: "Génère un tableau Markdown à partir de CSV ou de JSON",
+		New:         NewMarkdownTableUI,
+		Codec:       NewConfigCodec(MarkdownTableConfig.Options, func(o MarkdownTableOptions) MarkdownTableConfig { return MarkdownTableConfig(o) }),
+	})
+}
+
+// MarkdownTableConfig configuration pour la génération de tableaux Markdown
+type MarkdownTableConfig struct {
+	Source    string `json:",omitempty"` // "Automatique" (défaut), "CSV" ou "JSON"
+	Separator string `json:",omitempty"` // Séparateur de l'entrée CSV, "," par défaut
+	Alignment string `json:",omitempty"` // "Automatique" (défaut), "Gauche", "Centré" ou "Droite"
+	Compact   bool   `json:",omitempty"`
+}
+
+func (c MarkdownTableConfig) GetType() ToolType {
+	return MarkdownTableTool
+}
+
+func (c MarkdownTableConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c MarkdownTableConfig) GetDisplayName() string {
+	alignment := c.Alignment
+	if alignment == "" {
+		alignment = MarkdownAlignAuto
+	}
+	return fmt.Sprintf("Tableau Markdown (alignement: %s)", alignment)
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c MarkdownTableConfig) Options() MarkdownTableOptions {
+	return MarkdownTableOptions(c)
+}
@@ -1,7 +1,10 @@
 package processors
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 
 	"fyne.io/fyne/v2"
@@ -9,6 +12,17 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+// Guillemets ajoutés autour de chaque élément joint
+const (
+	TextJoinQuoteNone  = "Aucun"
+	TextJoinQuoteSQL   = "SQL"
+	TextJoinQuoteShell = "Shell"
+	TextJoinQuoteJSON  = "JSON"
+)
+
+// TextJoinQuotes liste les valeurs acceptées pour TextJoinerOptions.Quote
+var TextJoinQuotes = []string{TextJoinQuoteNone, TextJoinQuoteSQL, TextJoinQuoteShell, TextJoinQuoteJSON}
+
 // TextJoinerUI implémente Processor pour la jointure de texte
 type TextJoinerUI struct {
 	viewModel *TextJoinerViewModel
@@ -25,7 +39,7 @@ func (ui *TextJoinerUI) Name() string {
 }
 
 func (ui *TextJoinerUI) Description() string {
-	return "Assemble des lignes de texte avec un délimiteur"
+	return "Assemble des lignes de texte avec un délimiteur, entre guillemets SQL, shell ou JSON si besoin"
 }
 
 func (ui *TextJoinerUI) ViewModel() ViewModel {
@@ -33,6 +47,8 @@ func (ui *TextJoinerUI) ViewModel() ViewModel {
 }
 
 func (ui *TextJoinerUI) CreateConfigurationUI() fyne.CanvasObject {
+	vm := ui.viewModel
+
 	input := widget.NewMultiLineEntry()
 	input.SetPlaceHolder("Entrez le texte à assembler...")
 	input.Wrapping = fyne.TextWrapWord
@@ -44,9 +60,35 @@ func (ui *TextJoinerUI) CreateConfigurationUI() fyne.CanvasObject {
 
 	delimiterEntry := widget.NewEntry()
 	delimiterEntry.SetPlaceHolder("Délimiteur (ex: , )")
+	delimiterEntry.SetText(vm.delimiter)
+
+	quoteSelect := widget.NewSelect(TextJoinQuotes, nil)
+	quoteSelect.SetSelected(vm.quote)
+
+	itemPrefixEntry := widget.NewEntry()
+	itemPrefixEntry.SetPlaceHolder("Avant chaque élément")
+	itemPrefixEntry.SetText(vm.itemPrefix)
+
+	itemSuffixEntry := widget.NewEntry()
+	itemSuffixEntry.SetPlaceHolder("Après chaque élément")
+	itemSuffixEntry.SetText(vm.itemSuffix)
+
+	prefixEntry := widget.NewEntry()
+	prefixEntry.SetPlaceHolder("Au début, ex: (")
+	prefixEntry.SetText(vm.prefix)
+
+	suffixEntry := widget.NewEntry()
+	suffixEntry.SetPlaceHolder("À la fin, ex: )")
+	suffixEntry.SetText(vm.suffix)
+
+	keepEmptyCheck := widget.NewCheck("Garder les lignes vides", nil)
+	keepEmptyCheck.SetChecked(vm.keepEmpty)
+
+	noTrimCheck := widget.NewCheck("Garder les espaces (indentation)", nil)
+	noTrimCheck.SetChecked(vm.noTrim)
 
 	processBtn := widget.NewButton("Assembler", func() {
-		result, err := ui.viewModel.Process(input.Text)
+		result, err := vm.Process(input.Text)
 		if err != nil {
 			output.SetText(PrettyValidationError(err))
 		} else {
@@ -55,14 +97,47 @@ func (ui *TextJoinerUI) CreateConfigurationUI() fyne.CanvasObject {
 	})
 
 	copyBtn := widget.NewButton("Copier", func() {
-		if result, _ := ui.viewModel.GetLastResult(); result != "" {
+		if result, _ := vm.GetLastResult(); result != "" {
 			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
 			clipboard.SetContent(result)
 		}
 	})
 
+	rerun := func() {
+		if input.Text != "" {
+			processBtn.OnTapped()
+		}
+	}
+
 	delimiterEntry.OnChanged = func(s string) {
-		ui.viewModel.delimiter = s
+		vm.delimiter = s
+	}
+	itemPrefixEntry.OnChanged = func(s string) {
+		vm.itemPrefix = s
+	}
+	itemSuffixEntry.OnChanged = func(s string) {
+		vm.itemSuffix = s
+	}
+	prefixEntry.OnChanged = func(s string) {
+		vm.prefix = s
+	}
+	suffixEntry.OnChanged = func(s string) {
+		vm.suffix = s
+	}
+	for _, entry := range []*widget.Entry{delimiterEntry, itemPrefixEntry, itemSuffixEntry, prefixEntry, suffixEntry} {
+		entry.OnSubmitted = func(string) { rerun() }
+	}
+	quoteSelect.OnChanged = func(s string) {
+		vm.quote = s
+		rerun()
+	}
+	keepEmptyCheck.OnChanged = func(checked bool) {
+		vm.keepEmpty = checked
+		rerun()
+	}
+	noTrimCheck.OnChanged = func(checked bool) {
+		vm.noTrim = checked
+		rerun()
 	}
 
 	topSection := container.NewVBox(
@@ -71,9 +146,19 @@ func (ui *TextJoinerUI) CreateConfigurationUI() fyne.CanvasObject {
 		container.NewHBox(
 			widget.NewLabel("Délimiteur:"),
 			delimiterEntry,
+			widget.NewLabel("Guillemets:"),
+			quoteSelect,
 			processBtn,
 			copyBtn,
 		),
+		container.NewGridWithColumns(3,
+			widget.NewLabel("Autour de chaque élément:"), itemPrefixEntry, itemSuffixEntry,
+			widget.NewLabel("Autour du résultat:"), prefixEntry, suffixEntry,
+		),
+		container.NewHBox(
+			keepEmptyCheck,
+			noTrimCheck,
+		),
 		widget.NewLabel("Résultat assemblé:"),
 	)
 
@@ -86,52 +171,192 @@ func (ui *TextJoinerUI) CreateConfigurationUI() fyne.CanvasObject {
 	)
 }
 
+// TextJoinerOptions configuration du ViewModel de jointure. Les valeurs nulles correspondent
+// au comportement historique: lignes rognées, lignes vides ignorées, sans guillemets.
+type TextJoinerOptions struct {
+	Delimiter  string
+	KeepEmpty  bool   // Garder les lignes vides (ou ne contenant que des espaces)
+	NoTrim     bool   // Ne pas supprimer les espaces en début et fin de ligne
+	Quote      string // Une des valeurs de TextJoinQuotes, vide: sans guillemets
+	ItemPrefix string // Ajouté avant chaque élément (après les guillemets)
+	ItemSuffix string
+	Prefix     string // Ajouté au début du résultat
+	Suffix     string
+}
+
 // TextJoinerViewModel implémente ViewModel pour la jointure
 type TextJoinerViewModel struct {
 	delimiter  string
+	keepEmpty  bool
+	noTrim     bool
+	quote      string
+	itemPrefix string
+	itemSuffix string
+	prefix     string
+	suffix     string
 	lastResult string
 }
 
 func NewTextJoinerViewModel() *TextJoinerViewModel {
 	return &TextJoinerViewModel{
 		delimiter: " ",
+		quote:     TextJoinQuoteNone,
 	}
 }
 
 func (vm *TextJoinerViewModel) Process(input string) (string, error) {
-	lines := strings.Split(input, "\n")
-	// Filtrer les lignes vides
-	var nonEmptyLines []string
-	for _, line := range lines {
-		if trimmed := strings.TrimSpace(line); trimmed != "" {
-			nonEmptyLines = append(nonEmptyLines, trimmed)
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext assemble les lignes en s'interrompant si le contexte est annulé
+func (vm *TextJoinerViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	if err := vm.Validate(); err != nil {
+		return "", err
+	}
+
+	// Le retour à la ligne final termine la dernière ligne, il n'ajoute pas de ligne vide
+	var items []string
+	for _, line := range strings.Split(strings.TrimSuffix(input, "\n"), "\n") {
+		if cancelled(ctx) {
+			return "", ctx.Err()
+		}
+		if vm.noTrim {
+			line = strings.TrimSuffix(line, "\r")
+		} else {
+			line = strings.TrimSpace(line)
+		}
+		if !vm.keepEmpty && strings.TrimSpace(line) == "" {
+			continue
 		}
+		items = append(items, vm.itemPrefix+quoteJoinItem(line, vm.quote)+vm.itemSuffix)
 	}
-	vm.lastResult = strings.Join(nonEmptyLines, vm.delimiter)
+	vm.lastResult = vm.prefix + strings.Join(items, vm.delimiter) + vm.suffix
 	return vm.lastResult, nil
 }
 
 func (vm *TextJoinerViewModel) GetConfiguration() interface{} {
-	return struct {
-		Delimiter string
-	}{
-		Delimiter: vm.delimiter,
+	return TextJoinerOptions{
+		Delimiter:  vm.delimiter,
+		KeepEmpty:  vm.keepEmpty,
+		NoTrim:     vm.noTrim,
+		Quote:      vm.quote,
+		ItemPrefix: vm.itemPrefix,
+		ItemSuffix: vm.itemSuffix,
+		Prefix:     vm.prefix,
+		Suffix:     vm.suffix,
 	}
 }
 
+// LoadConfiguration accepte TextJoinerOptions ou l'ancienne configuration
+// struct{ Delimiter string }
 func (vm *TextJoinerViewModel) LoadConfiguration(config interface{}) error {
-	cfg, ok := config.(struct{ Delimiter string })
-	if !ok {
+	var cfg TextJoinerOptions
+	switch c := config.(type) {
+	case TextJoinerOptions:
+		cfg = c
+	case struct{ Delimiter string }:
+		cfg = TextJoinerOptions{Delimiter: c.Delimiter}
+	default:
 		return fmt.Errorf("configuration invalide")
 	}
 	vm.delimiter = cfg.Delimiter
+	vm.keepEmpty = cfg.KeepEmpty
+	vm.noTrim = cfg.NoTrim
+	vm.quote = cfg.Quote
+	if vm.quote == "" {
+		vm.quote = TextJoinQuoteNone
+	}
+	vm.itemPrefix = cfg.ItemPrefix
+	vm.itemSuffix = cfg.ItemSuffix
+	vm.prefix = cfg.Prefix
+	vm.suffix = cfg.Suffix
 	return nil
 }
 
 func (vm *TextJoinerViewModel) Validate() error {
-	return nil // Pas de validation nécessaire
+	return vm.GetConfiguration().(TextJoinerOptions).Validate()
+}
+
+// Validate vérifie le type de guillemets (valeur vide acceptée)
+func (o TextJoinerOptions) Validate() error {
+	if o.Quote != "" && !slices.Contains(TextJoinQuotes, o.Quote) {
+		return fmt.Errorf("type de guillemets invalide: %s", o.Quote)
+	}
+	return nil
 }
 
 func (vm *TextJoinerViewModel) GetLastResult() (string, error) {
 	return vm.lastResult, nil
 }
+
+// shellSafe caractères qui n'ont pas besoin de guillemets dans un shell POSIX
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// quoteJoinItem entoure un élément de guillemets selon la syntaxe demandée: SQL (apostrophes
+// doublées), shell POSIX (apostrophe fermée, échappée puis rouverte; sans guillemets si
+// inutiles, comme shlex.quote) ou chaîne JSON
+func quoteJoinItem(item, quote string) string {
+	switch quote {
+	case TextJoinQuoteSQL:
+		return "'" + strings.ReplaceAll(item, "'", "''") + "'"
+	case TextJoinQuoteShell:
+		if shellSafe.MatchString(item) {
+			return item
+		}
+		return "'" + strings.ReplaceAll(item, "'", `'\''`) + "'"
+	case TextJoinQuoteJSON:
+		return encodeJSONString(newJSONString(item), false)
+	}
+	return item
+}
+
+// TextJoinerTool identifiant de l'outil "Text Joiner" dans les pipelines sérialisés
+const TextJoinerTool ToolType = "text_joiner"
+
+func init() {
+	Register(Registration{
+		Type:        TextJoinerTool,
+		Name:        "Text Joiner",
+		Description: "Joint du texte avec un délimiteur",
+		New:         NewTextJoinerUI,
+		Codec:       NewConfigCodec(TextJoinerConfig.Options, func(o TextJoinerOptions) TextJoinerConfig { return TextJoinerConfig(o) }),
+	})
+}
+
+// TextJoinerConfig configuration pour le joigneur de texte. Sans les champs facultatifs,
+// les lignes sont rognées et les lignes vides ignorées, comme avant leur ajout.
+type TextJoinerConfig struct {
+	Delimiter  string
+	KeepEmpty  bool   `json:",omitempty"`
+	NoTrim     bool   `json:",omitempty"`
+	Quote      string `json:",omitempty"` // "Aucun" (défaut), "SQL", "Shell" ou "JSON"
+	ItemPrefix string `json:",omitempty"`
+	ItemSuffix string `json:",omitempty"`
+	Prefix     string `json:",omitempty"`
+	Suffix     string `json:",omitempty"`
+}
+
+func (c TextJoinerConfig) GetType() ToolType {
+	return TextJoinerTool
+}
+
+func (c TextJoinerConfig) Validate() error {
+	// Le délimiteur peut être vide
+	return c.Options().Validate()
+}
+
+func (c TextJoinerConfig) GetDisplayName() string {
+	delimiter := c.Delimiter
+	if delimiter == "" {
+		delimiter = "(vide)"
+	}
+	if c.Quote != "" && c.Quote != TextJoinQuoteNone {
+		return fmt.Sprintf("Text Joiner (Délimiteur: %s, guillemets %s)", delimiter, c.Quote)
+	}
+	return fmt.Sprintf("Text Joiner (Délimiteur: %s)", delimiter)
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c TextJoinerConfig) Options() TextJoinerOptions {
+	return TextJoinerOptions(c)
+}
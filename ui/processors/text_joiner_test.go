@@ -0,0 +1,124 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTextJoiner(t *testing.T) {
+	tests := []struct {
+		name    string
+		options TextJoinerOptions
+		input   string
+		want    string
+	}{
+		{
+			name:    "lignes nettoyées et vides ignorées",
+			options: TextJoinerOptions{Delimiter: ", "},
+			input:   "  a \n\n \t\nb\r\n",
+			want:    "a, b",
+		},
+		{
+			name:    "retour à la ligne final sans élément vide",
+			options: TextJoinerOptions{Delimiter: "|", KeepEmpty: true},
+			input:   "a\n\nb\n",
+			want:    "a||b",
+		},
+		{
+			name:    "espaces conservés",
+			options: TextJoinerOptions{Delimiter: "|", NoTrim: true},
+			input:   " a \r\n b\n",
+			want:    " a | b",
+		},
+		{
+			name:    "guillemets SQL",
+			options: TextJoinerOptions{Delimiter: ", ", Quote: TextJoinQuoteSQL, Prefix: "IN (", Suffix: ")"},
+			input:   "a\nl'été\n",
+			want:    "IN ('a', 'l''été')",
+		},
+		{
+			name:    "guillemets shell",
+			options: TextJoinerOptions{Delimiter: " ", Quote: TextJoinQuoteShell, KeepEmpty: true},
+			input:   "fichier.txt\nmon fichier\nl'a\n\n",
+			want:    "fichier.txt 'mon fichier' 'l'\\''a' ''",
+		},
+		{
+			name:    "guillemets JSON",
+			options: TextJoinerOptions{Delimiter: ",", Quote: TextJoinQuoteJSON, Prefix: "[", Suffix: "]"},
+			input:   "a\"b\nc\\d\n<é>\n",
+			want:    `["a\"b","c\\d","<é>"]`,
+		},
+		{
+			name:    "préfixe et suffixe de chaque élément",
+			options: TextJoinerOptions{Delimiter: "\n", Quote: TextJoinQuoteSQL, ItemPrefix: "- ", ItemSuffix: ";"},
+			input:   "a\nb",
+			want:    "- 'a';\n- 'b';",
+		},
+		{
+			name:    "entrée vide",
+			options: TextJoinerOptions{Delimiter: ",", Prefix: "[", Suffix: "]"},
+			input:   "",
+			want:    "[]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewTextJoinerViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			got, err := vm.Process(tt.input)
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Process = %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Les pipelines enregistrés avant l'ajout des options ne contiennent que le délimiteur
+func TestTextJoinerLegacyConfiguration(t *testing.T) {
+	vm := NewTextJoinerViewModel()
+	if err := vm.LoadConfiguration(struct{ Delimiter string }{";"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := vm.Process("a\n b \n")
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if want := "a;b"; got != want {
+		t.Errorf("Process = %q, attendu %q", got, want)
+	}
+}
+
+func TestTextJoinerErrors(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		options TextJoinerOptions
+	}{
+		{"guillemets inconnus", context.Background(), TextJoinerOptions{Quote: "Python"}},
+		{"contexte annulé", cancelled, TextJoinerOptions{Delimiter: ","}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewTextJoinerViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			_, err := vm.ProcessContext(tt.ctx, "a\nb")
+			if err == nil {
+				t.Fatal("erreur attendue")
+			}
+			if tt.ctx == cancelled && !errors.Is(err, context.Canceled) {
+				t.Errorf("erreur %v, attendu %v", err, context.Canceled)
+			}
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package processors
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"fyne.io/fyne/v2"
@@ -9,6 +13,21 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+// Modes de découpage du Text Splitter
+const (
+	TextSplitDelimiter  = "Délimiteur"
+	TextSplitRegex      = "Expression régulière"
+	TextSplitChars      = "Tous les N caractères"
+	TextSplitLines      = "Toutes les N lignes"
+	TextSplitParagraphs = "Paragraphes"
+)
+
+// TextSplitModes liste les valeurs acceptées pour TextSplitterOptions.Mode
+var TextSplitModes = []string{TextSplitDelimiter, TextSplitRegex, TextSplitChars, TextSplitLines, TextSplitParagraphs}
+
+// paragraphSeparator lignes vides (éventuellement avec des espaces) entre deux paragraphes
+var paragraphSeparator = regexp.MustCompile(`\r?\n(?:[ \t]*\r?\n)+`)
+
 // TextSplitterUI implémente Processor pour le découpage de texte
 type TextSplitterUI struct {
 	viewModel *TextSplitterViewModel
@@ -25,7 +44,7 @@ func (ui *TextSplitterUI) Name() string {
 }
 
 func (ui *TextSplitterUI) Description() string {
-	return "Découpe le texte selon un délimiteur personnalisable"
+	return "Découpe le texte selon un délimiteur, une expression régulière, une taille fixe ou en paragraphes"
 }
 
 func (ui *TextSplitterUI) ViewModel() ViewModel {
@@ -33,6 +52,8 @@ func (ui *TextSplitterUI) ViewModel() ViewModel {
 }
 
 func (ui *TextSplitterUI) CreateConfigurationUI() fyne.CanvasObject {
+	vm := ui.viewModel
+
 	input := widget.NewMultiLineEntry()
 	input.SetPlaceHolder("Entrez le texte à découper...")
 	input.Wrapping = fyne.TextWrapWord
@@ -42,39 +63,132 @@ func (ui *TextSplitterUI) CreateConfigurationUI() fyne.CanvasObject {
 	output.Wrapping = fyne.TextWrapWord
 	output.Disable()
 
+	status := widget.NewLabel("")
+
+	modeSelect := widget.NewSelect(TextSplitModes, nil)
+	modeSelect.SetSelected(vm.mode)
+
 	delimiterEntry := widget.NewEntry()
 	delimiterEntry.SetPlaceHolder("Délimiteur (laisser vide pour \\n)")
+	if vm.delimiter != "\n" {
+		delimiterEntry.SetText(vm.delimiter)
+	}
+
+	chunkEntry := widget.NewEntry()
+	chunkEntry.SetPlaceHolder("N")
+	if vm.chunkSize > 0 {
+		chunkEntry.SetText(strconv.Itoa(vm.chunkSize))
+	}
+
+	maxSplitsEntry := widget.NewEntry()
+	maxSplitsEntry.SetPlaceHolder("Illimité")
+	if vm.maxSplits > 0 {
+		maxSplitsEntry.SetText(strconv.Itoa(vm.maxSplits))
+	}
+
+	keepCheck := widget.NewCheck("Garder le délimiteur", nil)
+	keepCheck.SetChecked(vm.keepDelimiter)
+
+	trimCheck := widget.NewCheck("Supprimer les espaces autour", nil)
+	trimCheck.SetChecked(vm.trimParts)
+
+	dropEmptyCheck := widget.NewCheck("Ignorer les parties vides", nil)
+	dropEmptyCheck.SetChecked(vm.dropEmpty)
 
 	processBtn := widget.NewButton("Découper", func() {
-		result, err := ui.viewModel.Process(input.Text)
+		result, err := vm.Process(input.Text)
 		if err != nil {
 			output.SetText(PrettyValidationError(err))
+			status.SetText("")
 		} else {
 			output.SetText(result)
+			status.SetText(fmt.Sprintf("%d partie(s)", vm.lastCount))
 		}
 	})
 
 	copyBtn := widget.NewButton("Copier", func() {
-		if result, _ := ui.viewModel.GetLastResult(); result != "" {
+		if result, _ := vm.GetLastResult(); result != "" {
 			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
 			clipboard.SetContent(result)
 		}
 	})
 
+	// Le délimiteur ne sert qu'aux deux premiers modes, la taille aux découpages fixes
+	updateMode := func() {
+		switch vm.mode {
+		case TextSplitDelimiter, TextSplitRegex:
+			delimiterEntry.Enable()
+			keepCheck.Enable()
+			chunkEntry.Disable()
+		case TextSplitChars, TextSplitLines:
+			delimiterEntry.Disable()
+			keepCheck.Disable()
+			chunkEntry.Enable()
+		default:
+			delimiterEntry.Disable()
+			keepCheck.Disable()
+			chunkEntry.Disable()
+		}
+	}
+	updateMode()
+
+	rerun := func() {
+		if input.Text != "" {
+			processBtn.OnTapped()
+		}
+	}
+
+	modeSelect.OnChanged = func(s string) {
+		vm.mode = s
+		updateMode()
+		rerun()
+	}
 	delimiterEntry.OnChanged = func(s string) {
-		ui.viewModel.delimiter = s
+		vm.delimiter = s
+	}
+	chunkEntry.OnChanged = func(s string) {
+		vm.chunkSize, _ = parseCount(s)
+	}
+	maxSplitsEntry.OnChanged = func(s string) {
+		vm.maxSplits, _ = parseCount(s)
+	}
+	for _, entry := range []*widget.Entry{delimiterEntry, chunkEntry, maxSplitsEntry} {
+		entry.OnSubmitted = func(string) { rerun() }
+	}
+	keepCheck.OnChanged = func(checked bool) {
+		vm.keepDelimiter = checked
+		rerun()
+	}
+	trimCheck.OnChanged = func(checked bool) {
+		vm.trimParts = checked
+		rerun()
+	}
+	dropEmptyCheck.OnChanged = func(checked bool) {
+		vm.dropEmpty = checked
+		rerun()
 	}
 
 	topSection := container.NewVBox(
 		widget.NewLabel("Entrée Texte:"),
 		input,
 		container.NewHBox(
-			widget.NewLabel("Délimiteur:"),
-			delimiterEntry,
-			processBtn,
-			copyBtn,
+			widget.NewLabel("Mode:"),
+			modeSelect,
+			widget.NewLabel("N:"),
+			chunkEntry,
+			widget.NewLabel("Découpes max.:"),
+			maxSplitsEntry,
+		),
+		container.NewBorder(nil, nil, widget.NewLabel("Délimiteur:"), container.NewHBox(processBtn, copyBtn), delimiterEntry),
+		container.NewHBox(
+			keepCheck,
+			trimCheck,
+			dropEmptyCheck,
+		),
+		container.NewHBox(
+			widget.NewLabel("Résultat découpé:"),
+			status,
 		),
-		widget.NewLabel("Résultat découpé:"),
 	)
 
 	return container.NewBorder(
@@ -86,49 +200,313 @@ func (ui *TextSplitterUI) CreateConfigurationUI() fyne.CanvasObject {
 	)
 }
 
-// TextSplitterViewModel implémente ViewModel pour le découpage
+// parseCount lit un nombre positif saisi par l'utilisateur (vide: 0)
+func parseCount(text string) (int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil || n < 0 {
+		return -1, fmt.Errorf("nombre invalide: %q", text)
+	}
+	return n, nil
+}
+
+// TextSplitterOptions configuration du ViewModel du découpeur. Les valeurs nulles
+// correspondent au découpage historique (délimiteur littéral, \n par défaut).
+type TextSplitterOptions struct {
+	Mode          string // Une des valeurs de TextSplitModes
+	Delimiter     string // Délimiteur littéral ou expression régulière (RE2)
+	ChunkSize     int    // N des modes "Tous les N caractères" et "Toutes les N lignes"
+	MaxSplits     int    // Nombre maximal de découpes (0: illimité), la dernière partie contient le reste
+	KeepDelimiter bool   // Garder le délimiteur à la fin de chaque partie
+	TrimParts     bool
+	DropEmpty     bool
+}
+
+// TextSplitterViewModel implémente ViewModel pour le découpage. Les parties sont écrites une
+// par ligne; en modes lignes et paragraphes, elles sont séparées par une ligne vide et le
+// résultat se termine par un retour à la ligne si le texte d'origine en avait un, comme avec le
+// délimiteur \n. Dans ces modes et avec le délimiteur \n, les fins de ligne \r\n sont lues
+// comme des \n.
 type TextSplitterViewModel struct {
-	delimiter  string
-	lastResult string
+	mode          string
+	delimiter     string
+	chunkSize     int
+	maxSplits     int
+	keepDelimiter bool
+	trimParts     bool
+	dropEmpty     bool
+	lastResult    string
+	lastCount     int
 }
 
 func NewTextSplitterViewModel() *TextSplitterViewModel {
 	return &TextSplitterViewModel{
+		mode:      TextSplitDelimiter,
 		delimiter: "\n",
 	}
 }
 
 func (vm *TextSplitterViewModel) Process(input string) (string, error) {
-	delim := vm.delimiter
-	if delim == "" {
-		delim = "\n"
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext découpe le texte en s'interrompant si le contexte est annulé
+func (vm *TextSplitterViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	if err := vm.Validate(); err != nil {
+		return "", err
+	}
+
+	parts, err := vm.split(ctx, input)
+	if err != nil {
+		return "", err
 	}
 
-	parts := strings.Split(input, delim)
-	return strings.Join(parts, "\n"), nil
+	var kept []string
+	for _, part := range parts {
+		if vm.trimParts {
+			part = strings.TrimSpace(part)
+		}
+		if vm.dropEmpty && strings.TrimSpace(part) == "" {
+			continue
+		}
+		kept = append(kept, part)
+	}
+
+	separator := "\n"
+	if vm.mode == TextSplitLines || vm.mode == TextSplitParagraphs {
+		separator = "\n\n"
+	}
+	result := strings.Join(kept, separator)
+	if separator == "\n\n" && len(kept) > 0 && strings.HasSuffix(input, "\n") {
+		result += "\n"
+	}
+	vm.lastResult, vm.lastCount = result, len(kept)
+	return vm.lastResult, nil
+}
+
+// split découpe le texte selon le mode. Chaque mode produit la liste des délimiteurs (paires
+// de positions en octets, éventuellement vides) et le texte est coupé sur ces positions.
+// L'annulation du contexte est vérifiée à chaque délimiteur trouvé.
+func (vm *TextSplitterViewModel) split(ctx context.Context, input string) ([]string, error) {
+	limit := -1
+	if vm.maxSplits > 0 {
+		limit = vm.maxSplits
+	}
+
+	var spans [][]int
+	keep := vm.keepDelimiter
+	switch vm.mode {
+	case TextSplitRegex:
+		re, err := regexp.Compile(vm.delimiter)
+		if err != nil {
+			return nil, fmt.Errorf("expression régulière invalide: %w", err)
+		}
+		for _, span := range re.FindAllStringIndex(input, -1) {
+			if cancelled(ctx) {
+				return nil, ctx.Err()
+			}
+			// Comme regexp.Split: pas de découpe sur une correspondance vide en bordure
+			if span[0] == span[1] && (span[0] == 0 || span[0] == len(input)) {
+				continue
+			}
+			spans = append(spans, span)
+		}
+	case TextSplitChars:
+		count := 0
+		for i := range input {
+			if count > 0 && count%vm.chunkSize == 0 {
+				if cancelled(ctx) {
+					return nil, ctx.Err()
+				}
+				spans = append(spans, []int{i, i})
+			}
+			count++
+		}
+	case TextSplitLines:
+		input = strings.TrimSuffix(strings.ReplaceAll(input, "\r\n", "\n"), "\n")
+		count := 0
+		for offset := 0; ; {
+			if cancelled(ctx) {
+				return nil, ctx.Err()
+			}
+			i := strings.IndexByte(input[offset:], '\n')
+			if i < 0 {
+				break
+			}
+			offset += i + 1
+			if count++; count%vm.chunkSize == 0 {
+				spans = append(spans, []int{offset - 1, offset})
+			}
+		}
+		keep = false
+	case TextSplitParagraphs:
+		input = strings.Trim(strings.ReplaceAll(input, "\r\n", "\n"), "\n")
+		spans = paragraphSeparator.FindAllStringIndex(input, -1)
+		keep = false
+		if cancelled(ctx) {
+			return nil, ctx.Err()
+		}
+	default:
+		delimiter := vm.delimiter
+		if delimiter == "" {
+			delimiter = "\n"
+		}
+		if delimiter == "\n" {
+			input = strings.ReplaceAll(input, "\r\n", "\n")
+		}
+		for offset := 0; ; {
+			if cancelled(ctx) {
+				return nil, ctx.Err()
+			}
+			i := strings.Index(input[offset:], delimiter)
+			if i < 0 {
+				break
+			}
+			spans = append(spans, []int{offset + i, offset + i + len(delimiter)})
+			offset += i + len(delimiter)
+		}
+	}
+
+	if limit >= 0 && len(spans) > limit {
+		spans = spans[:limit]
+	}
+	parts := make([]string, 0, len(spans)+1)
+	last := 0
+	for _, span := range spans {
+		end := span[0]
+		if keep {
+			end = span[1]
+		}
+		parts = append(parts, input[last:end])
+		last = span[1]
+	}
+	return append(parts, input[last:]), nil
 }
 
 func (vm *TextSplitterViewModel) GetConfiguration() interface{} {
-	return struct {
-		Delimiter string
-	}{
-		Delimiter: vm.delimiter,
+	return TextSplitterOptions{
+		Mode:          vm.mode,
+		Delimiter:     vm.delimiter,
+		ChunkSize:     vm.chunkSize,
+		MaxSplits:     vm.maxSplits,
+		KeepDelimiter: vm.keepDelimiter,
+		TrimParts:     vm.trimParts,
+		DropEmpty:     vm.dropEmpty,
 	}
 }
 
+// LoadConfiguration accepte TextSplitterOptions ou l'ancienne configuration
+// struct{ Delimiter string }
 func (vm *TextSplitterViewModel) LoadConfiguration(config interface{}) error {
-	cfg, ok := config.(struct{ Delimiter string })
-	if !ok {
+	var cfg TextSplitterOptions
+	switch c := config.(type) {
+	case TextSplitterOptions:
+		cfg = c
+	case struct{ Delimiter string }:
+		cfg = TextSplitterOptions{Delimiter: c.Delimiter}
+	default:
 		return fmt.Errorf("configuration invalide")
 	}
+	vm.mode = cfg.Mode
+	if vm.mode == "" {
+		vm.mode = TextSplitDelimiter
+	}
 	vm.delimiter = cfg.Delimiter
+	vm.chunkSize = cfg.ChunkSize
+	vm.maxSplits = cfg.MaxSplits
+	vm.keepDelimiter = cfg.KeepDelimiter
+	vm.trimParts = cfg.TrimParts
+	vm.dropEmpty = cfg.DropEmpty
 	return nil
 }
 
 func (vm *TextSplitterViewModel) Validate() error {
-	return nil // Pas de validation nécessaire
+	return vm.GetConfiguration().(TextSplitterOptions).Validate()
+}
+
+// Validate vérifie le mode, l'expression régulière et les tailles (valeurs vides acceptées)
+func (o TextSplitterOptions) Validate() error {
+	if o.Mode != "" && !slices.Contains(TextSplitModes, o.Mode) {
+		return fmt.Errorf("mode de découpage invalide: %s", o.Mode)
+	}
+	if o.MaxSplits < 0 {
+		return fmt.Errorf("le nombre maximal de découpes ne peut pas être négatif")
+	}
+	switch o.Mode {
+	case TextSplitRegex:
+		if o.Delimiter == "" {
+			return fmt.Errorf("expression régulière vide")
+		}
+		if _, err := regexp.Compile(o.Delimiter); err != nil {
+			return fmt.Errorf("expression régulière invalide: %w", err)
+		}
+	case TextSplitChars, TextSplitLines:
+		if o.ChunkSize <= 0 {
+			return fmt.Errorf("le mode %q demande une taille N supérieure à 0", o.Mode)
+		}
+	}
+	return nil
 }
 
 func (vm *TextSplitterViewModel) GetLastResult() (string, error) {
 	return vm.lastResult, nil
 }
+
+// TextSplitterTool identifiant de l'outil "Text Splitter" dans les pipelines sérialisés
+const TextSplitterTool ToolType = "text_splitter"
+
+func init() {
+	Register(Registration{
+		Type:        TextSplitterTool,
+		Name:        "Text Splitter",
+		Description: "Divise du texte selon un délimiteur",
+		New:         NewTextSplitterUI,
+		Codec:       NewConfigCodec(TextSplitterConfig.Options, func(o TextSplitterOptions) TextSplitterConfig { return TextSplitterConfig(o) }),
+	})
+}
+
+// TextSplitterConfig configuration pour le diviseur de texte. Les champs ajoutés après
+// Delimiter sont facultatifs: une configuration enregistrée sans eux garde le découpage
+// sur un délimiteur littéral.
+type TextSplitterConfig struct {
+	Mode          string `json:",omitempty"` // "Délimiteur" (défaut), "Expression régulière", "Tous les N caractères", "Toutes les N lignes" ou "Paragraphes"
+	Delimiter     string
+	ChunkSize     int  `json:",omitempty"`
+	MaxSplits     int  `json:",omitempty"` // 0: illimité
+	KeepDelimiter bool `json:",omitempty"`
+	TrimParts     bool `json:",omitempty"`
+	DropEmpty     bool `json:",omitempty"`
+}
+
+func (c TextSplitterConfig) GetType() ToolType {
+	return TextSplitterTool
+}
+
+func (c TextSplitterConfig) Validate() error {
+	// Le délimiteur peut être vide (utilise \n par défaut)
+	return c.Options().Validate()
+}
+
+func (c TextSplitterConfig) GetDisplayName() string {
+	switch c.Mode {
+	case TextSplitRegex:
+		return fmt.Sprintf("Text Splitter (Expression régulière: %s)", c.Delimiter)
+	case TextSplitChars, TextSplitLines:
+		return fmt.Sprintf("Text Splitter (%s, N = %d)", c.Mode, c.ChunkSize)
+	case TextSplitParagraphs:
+		return "Text Splitter (Paragraphes)"
+	}
+	delimiter := c.Delimiter
+	if delimiter == "" {
+		delimiter = "\\n (par défaut)"
+	}
+	return fmt.Sprintf("Text Splitter (Délimiteur: %s)", delimiter)
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c TextSplitterConfig) Options() TextSplitterOptions {
+	return TextSplitterOptions(c)
+}
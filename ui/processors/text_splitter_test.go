@@ -0,0 +1,158 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTextSplitter(t *testing.T) {
+	tests := []struct {
+		name    string
+		options TextSplitterOptions
+		input   string
+		want    string
+	}{
+		{
+			name:    "délimiteur littéral",
+			options: TextSplitterOptions{Delimiter: ","},
+			input:   "a,b,c",
+			want:    "a\nb\nc",
+		},
+		{
+			name:    "délimiteur conservé",
+			options: TextSplitterOptions{Delimiter: ", ", KeepDelimiter: true},
+			input:   "a, b",
+			want:    "a, \nb",
+		},
+		{
+			name:    "nombre maximal de découpes",
+			options: TextSplitterOptions{Delimiter: ",", MaxSplits: 1},
+			input:   "a,b,c",
+			want:    "a\nb,c",
+		},
+		{
+			name:    "parties nettoyées et vides supprimées",
+			options: TextSplitterOptions{Delimiter: ",", TrimParts: true, DropEmpty: true},
+			input:   " a , ,b",
+			want:    "a\nb",
+		},
+		{
+			name:    "retour à la ligne et fins de ligne Windows",
+			options: TextSplitterOptions{Delimiter: "\n"},
+			input:   "a\r\nb\r\n",
+			want:    "a\nb\n",
+		},
+		{
+			name:    "expression régulière",
+			options: TextSplitterOptions{Mode: TextSplitRegex, Delimiter: `\s*;\s*`},
+			input:   "a ; b;c",
+			want:    "a\nb\nc",
+		},
+		{
+			name:    "correspondances vides en bordure ignorées",
+			options: TextSplitterOptions{Mode: TextSplitRegex, Delimiter: `x*`},
+			input:   "ab",
+			want:    "a\nb",
+		},
+		{
+			name:    "expression régulière conservée",
+			options: TextSplitterOptions{Mode: TextSplitRegex, Delimiter: `[.!?]`, KeepDelimiter: true, TrimParts: true, DropEmpty: true},
+			input:   "Oui. Non! Peut-être?",
+			want:    "Oui.\nNon!\nPeut-être?",
+		},
+		{
+			name:    "tous les N caractères",
+			options: TextSplitterOptions{Mode: TextSplitChars, ChunkSize: 3},
+			input:   "éàüxyz12",
+			want:    "éàü\nxyz\n12",
+		},
+		{
+			name:    "toutes les N lignes",
+			options: TextSplitterOptions{Mode: TextSplitLines, ChunkSize: 2},
+			input:   "1\n2\n3\n4\n5\n",
+			want:    "1\n2\n\n3\n4\n\n5\n",
+		},
+		{
+			name:    "lignes Windows sans retour final",
+			options: TextSplitterOptions{Mode: TextSplitLines, ChunkSize: 2},
+			input:   "1\r\n2\r\n3",
+			want:    "1\n2\n\n3",
+		},
+		{
+			name:    "paragraphes",
+			options: TextSplitterOptions{Mode: TextSplitParagraphs},
+			input:   "p1 l1\np1 l2\n\n  \n p2\n",
+			want:    "p1 l1\np1 l2\n\n p2\n",
+		},
+		{
+			name:    "paragraphes Windows",
+			options: TextSplitterOptions{Mode: TextSplitParagraphs},
+			input:   "a\r\n\r\nb\r\n",
+			want:    "a\n\nb\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewTextSplitterViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			got, err := vm.Process(tt.input)
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Process = %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Les pipelines enregistrés avant l'ajout des modes ne contiennent que le délimiteur
+func TestTextSplitterLegacyConfiguration(t *testing.T) {
+	vm := NewTextSplitterViewModel()
+	if err := vm.LoadConfiguration(struct{ Delimiter string }{";"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := vm.Process("a;b")
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if want := "a\nb"; got != want {
+		t.Errorf("Process = %q, attendu %q", got, want)
+	}
+}
+
+func TestTextSplitterErrors(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		options TextSplitterOptions
+	}{
+		{"mode inconnu", context.Background(), TextSplitterOptions{Mode: "Mots"}},
+		{"expression régulière vide", context.Background(), TextSplitterOptions{Mode: TextSplitRegex}},
+		{"expression régulière invalide", context.Background(), TextSplitterOptions{Mode: TextSplitRegex, Delimiter: "("}},
+		{"taille nulle", context.Background(), TextSplitterOptions{Mode: TextSplitChars}},
+		{"découpes négatives", context.Background(), TextSplitterOptions{Delimiter: ",", MaxSplits: -1}},
+		{"contexte annulé", cancelled, TextSplitterOptions{Delimiter: ","}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewTextSplitterViewModel()
+			if err := vm.LoadConfiguration(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			_, err := vm.ProcessContext(tt.ctx, "a,b")
+			if err == nil {
+				t.Fatal("erreur attendue")
+			}
+			if tt.ctx == cancelled && !errors.Is(err, context.Canceled) {
+				t.Errorf("erreur %v, attendu %v", err, context.Canceled)
+			}
+		})
+	}
+}
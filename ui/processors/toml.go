@@ -0,0 +1,687 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Reformatage TOML et conversion TOML ⇄ JSON. Les documents sont lus avec
+// github.com/BurntSushi/toml puis rangés dans des tomlTable qui conservent l'ordre des clés.
+
+var (
+	// tomlBareKey clés TOML écrites sans guillemets
+	tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	// tomlErrorPrefix préfixe des messages de toml.ParseError ("toml: line 3 (last key "a"): ")
+	tomlErrorPrefix = regexp.MustCompile(`^toml: line \d+( \(last key "[^"]*"\))?: `)
+)
+
+// tomlTable table TOML (ou objet JSON) dont les clés gardent l'ordre du document.
+// Les valeurs sont des int64, float64, string, bool, time.Time (dates et heures TOML),
+// []interface{} ou *tomlTable.
+type tomlTable struct {
+	Keys   []string
+	Values map[string]interface{}
+}
+
+func newTOMLTable() *tomlTable {
+	return &tomlTable{Values: make(map[string]interface{})}
+}
+
+// set ajoute ou remplace une valeur; une clé remplacée garde sa position
+func (t *tomlTable) set(key string, value interface{}) {
+	if _, ok := t.Values[key]; !ok {
+		t.Keys = append(t.Keys, key)
+	}
+	t.Values[key] = value
+}
+
+// parseTOML analyse un document TOML en conservant l'ordre d'apparition des clés. Le décodage
+// lui-même n'est pas interruptible; l'annulation du contexte est vérifiée ensuite à chaque clé.
+func parseTOML(ctx context.Context, input string) (*tomlTable, error) {
+	var data map[string]interface{}
+	md, err := toml.Decode(input, &data)
+	if err != nil {
+		return nil, locateTOMLError(input, err)
+	}
+
+	// Clés enfants de chaque table (identifiée par son chemin), dans l'ordre du document.
+	// Les tables implicites ([a.b] sans [a]) sont enregistrées à leur première utilisation.
+	order := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, key := range md.Keys() {
+		if cancelled(ctx) {
+			return nil, ctx.Err()
+		}
+		for i := 1; i <= len(key); i++ {
+			path := key[:i].String()
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			parent := key[:i-1].String()
+			order[parent] = append(order[parent], key[i-1])
+		}
+	}
+	return orderedTOMLTable(data, nil, order), nil
+}
+
+// orderedTOMLTable range les valeurs décodées selon l'ordre du document; les clés absentes
+// de l'ordre (tables en ligne dans des tableaux) sont ajoutées par ordre alphabétique
+func orderedTOMLTable(data map[string]interface{}, path toml.Key, order map[string][]string) *tomlTable {
+	table := newTOMLTable()
+	for _, key := range order[path.String()] {
+		if value, ok := data[key]; ok {
+			table.set(key, orderedTOMLValue(value, append(path[:len(path):len(path)], key), order))
+		}
+	}
+	var remaining []string
+	for key := range data {
+		if _, ok := table.Values[key]; !ok {
+			remaining = append(remaining, key)
+		}
+	}
+	sort.Strings(remaining)
+	for _, key := range remaining {
+		table.set(key, orderedTOMLValue(data[key], append(path[:len(path):len(path)], key), order))
+	}
+	return table
+}
+
+func orderedTOMLValue(value interface{}, path toml.Key, order map[string][]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return orderedTOMLTable(v, path, order)
+	case []map[string]interface{}:
+		// Tableau de tables ([[path]]): les éléments partagent l'ordre des clés de path
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = orderedTOMLTable(item, path, order)
+		}
+		return items
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = orderedTOMLValue(item, path, order)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+// locateTOMLError convertit une toml.ParseError en *SyntaxError (ligne, colonne et extrait)
+func locateTOMLError(input string, err error) error {
+	var parseErr toml.ParseError
+	if !errors.As(err, &parseErr) {
+		return &SyntaxError{Format: "TOML", Err: err}
+	}
+
+	msg := parseErr.Message
+	if msg == "" {
+		msg = tomlErrorPrefix.ReplaceAllString(err.Error(), "")
+	}
+	if parseErr.LastKey != "" {
+		msg += fmt.Sprintf(" (dernière clé lue: %s)", parseErr.LastKey)
+	}
+
+	// La position en octets est plus précise que le numéro de ligne, qui peut désigner la
+	// ligne suivante quand l'erreur porte sur un retour à la ligne
+	line, column := parseErr.Position.Line, 0
+	if start := parseErr.Position.Start; (start > 0 || line <= 1) && start <= len(input) {
+		lineStart := strings.LastIndex(input[:start], "\n") + 1
+		line = strings.Count(input[:start], "\n") + 1
+		column = utf8.RuneCountInString(input[lineStart:start]) + 1
+	}
+	return newSyntaxError("TOML", input, line, column, errors.New(msg))
+}
+
+// tomlToJSON convertit une valeur TOML en arbre JSON; les dates deviennent des chaînes RFC 3339
+func tomlToJSON(ctx context.Context, value interface{}, path string) (*jsonNode, error) {
+	if cancelled(ctx) {
+		return nil, ctx.Err()
+	}
+	switch v := value.(type) {
+	case *tomlTable:
+		object := newJSONObject()
+		for _, key := range v.Keys {
+			child, err := tomlToJSON(ctx, v.Values[key], joinTOMLPath(path, key))
+			if err != nil {
+				return nil, err
+			}
+			object.Set(key, child)
+		}
+		return object, nil
+	case []interface{}:
+		array := newJSONArray()
+		for i, item := range v {
+			child, err := tomlToJSON(ctx, item, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			array.Items = append(array.Items, child)
+		}
+		return array, nil
+	case int64:
+		return newJSONNumber(strconv.FormatInt(v, 10)), nil
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, fmt.Errorf("%s: %v n'est pas représentable en JSON", path, v)
+		}
+		return newJSONNumber(formatTOMLFloat(v)), nil
+	case bool:
+		return newJSONBool(v), nil
+	case string:
+		return newJSONString(v), nil
+	case time.Time:
+		return newJSONString(formatTOMLDatetime(v)), nil
+	default:
+		return nil, fmt.Errorf("%s: type TOML non pris en charge %T", path, value)
+	}
+}
+
+// jsonToTOML convertit un arbre JSON en valeur TOML. TOML n'a pas de null et ses entiers
+// sont limités à 64 bits: ces valeurs sont refusées avec le chemin de la clé.
+func jsonToTOML(ctx context.Context, n *jsonNode, path string) (interface{}, error) {
+	if cancelled(ctx) {
+		return nil, ctx.Err()
+	}
+	switch n.Kind {
+	case jsonNull:
+		return nil, fmt.Errorf("%s: null n'est pas représentable en TOML", path)
+	case jsonBool:
+		return n.Bool, nil
+	case jsonNumber:
+		if !strings.ContainsAny(n.Raw, ".eE") {
+			i, err := strconv.ParseInt(n.Raw, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: l'entier %s dépasse les limites de TOML (64 bits)", path, n.Raw)
+			}
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(n.Raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: nombre %s hors limites", path, n.Raw)
+		}
+		return f, nil
+	case jsonString:
+		return n.Str, nil
+	case jsonArray:
+		items := make([]interface{}, len(n.Items))
+		for i, item := range n.Items {
+			value, err := jsonToTOML(ctx, item, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = value
+		}
+		return items, nil
+	default:
+		table := newTOMLTable()
+		for _, m := range n.Members {
+			value, err := jsonToTOML(ctx, m.Value, joinTOMLPath(path, m.Key))
+			if err != nil {
+				return nil, err
+			}
+			table.set(m.Key, value)
+		}
+		return table, nil
+	}
+}
+
+// joinTOMLPath chemin lisible d'une clé pour les messages d'erreur
+func joinTOMLPath(path, key string) string {
+	if path == "" {
+		return tomlKey(key)
+	}
+	return path + "." + tomlKey(key)
+}
+
+// tomlComments commentaires rattachés à une clé ou à un en-tête de table
+type tomlComments struct {
+	Leading  []string // Lignes écrites avant (lignes précédentes, intérieur d'une valeur sur plusieurs lignes)
+	Trailing string   // Commentaire en fin de ligne
+}
+
+// tomlLayout ce que le décodage TOML ne conserve pas, relevé par scanTOMLLayout
+type tomlLayout struct {
+	// Inline chemins des clés écrites « clé = valeur » (tables et tableaux de tables en ligne
+	// compris), que le reformatage ne transforme pas en sections
+	Inline map[string]bool
+	// Comments commentaires de chaque occurrence d'une clé (chemin complet) ou d'un en-tête de
+	// table (chemin précédé de "["), dans l'ordre du document. Les éléments d'un tableau de
+	// tables partagent leurs chemins, d'où une entrée par occurrence.
+	Comments map[string][]tomlComments
+	// Final commentaires qui suivent la dernière clé du document
+	Final []string
+}
+
+// tomlWriter écrit un document TOML et les commentaires relevés par scanTOMLLayout
+type tomlWriter struct {
+	ctx    context.Context
+	out    strings.Builder
+	layout *tomlLayout    // nil pour un document sans mise en forme d'origine (conversion depuis JSON)
+	seen   map[string]int // Occurrences déjà écrites de chaque chemin de layout.Comments
+}
+
+// writeTOML écrit la table racine: dans chaque table, les valeurs simples d'abord puis les
+// sous-tables ([a.b]) et les tableaux de tables ([[a.b]]), chacun dans l'ordre d'origine.
+// Chaque commentaire de layout est écrit avant la clé ou l'en-tête qui le suivait (ou en fin
+// de sa ligne). L'annulation du contexte est vérifiée à chaque table écrite.
+func writeTOML(ctx context.Context, root *tomlTable, layout *tomlLayout) (string, error) {
+	w := &tomlWriter{ctx: ctx, layout: layout, seen: make(map[string]int)}
+	if err := w.table(nil, root, false); err != nil {
+		return "", err
+	}
+	if layout != nil && len(layout.Final) > 0 {
+		if w.out.Len() > 0 {
+			w.out.WriteString("\n")
+		}
+		for _, comment := range layout.Final {
+			w.out.WriteString(comment + "\n")
+		}
+	}
+	return w.out.String(), nil
+}
+
+// inline indique si la clé était écrite « clé = valeur » dans le document d'origine
+func (w *tomlWriter) inline(path toml.Key) bool {
+	return w.layout != nil && w.layout.Inline[path.String()]
+}
+
+// comments retourne les commentaires de l'occurrence suivante de la clé ou de l'en-tête key
+func (w *tomlWriter) comments(key string) tomlComments {
+	if w.layout == nil {
+		return tomlComments{}
+	}
+	i := w.seen[key]
+	w.seen[key]++
+	if occurrences := w.layout.Comments[key]; i < len(occurrences) {
+		return occurrences[i]
+	}
+	return tomlComments{}
+}
+
+// line écrit une ligne précédée de ses commentaires et suivie de son commentaire de fin de ligne
+func (w *tomlWriter) line(text string, comments tomlComments) {
+	for _, comment := range comments.Leading {
+		w.out.WriteString(comment + "\n")
+	}
+	w.out.WriteString(text)
+	if comments.Trailing != "" {
+		w.out.WriteString(" " + comments.Trailing)
+	}
+	w.out.WriteString("\n")
+}
+
+// table écrit une table et ses sous-tables. Les clés écrites en ligne dans le document
+// d'origine (clé = { ... }) le restent au lieu d'être développées en sections.
+func (w *tomlWriter) table(path []string, table *tomlTable, arrayElement bool) error {
+	if cancelled(w.ctx) {
+		return w.ctx.Err()
+	}
+	childPath := func(key string) toml.Key {
+		return append(toml.Key(path[:len(path):len(path)]), key)
+	}
+	isSection := func(key string) bool {
+		return isTOMLSection(table.Values[key]) && !w.inline(childPath(key))
+	}
+	hasValues := false
+	for _, key := range table.Keys {
+		if !isSection(key) {
+			hasValues = true
+			break
+		}
+	}
+
+	// Une table ne contenant que des sous-tables n'a pas besoin de son propre en-tête, sauf
+	// pour garder les commentaires qui le précédaient
+	if len(path) > 0 {
+		comments := w.comments("[" + toml.Key(path).String())
+		if arrayElement || hasValues || len(table.Keys) == 0 || len(comments.Leading) > 0 || comments.Trailing != "" {
+			if w.out.Len() > 0 {
+				w.out.WriteString("\n")
+			}
+			header := make([]string, len(path))
+			for i, key := range path {
+				header[i] = tomlKey(key)
+			}
+			if arrayElement {
+				w.line("[["+strings.Join(header, ".")+"]]", comments)
+			} else {
+				w.line("["+strings.Join(header, ".")+"]", comments)
+			}
+		}
+	}
+
+	for _, key := range table.Keys {
+		if !isSection(key) {
+			w.line(tomlKey(key)+" = "+tomlInline(table.Values[key]), w.comments(childPath(key).String()))
+		}
+	}
+	for _, key := range table.Keys {
+		if !isSection(key) {
+			continue
+		}
+		switch value := table.Values[key].(type) {
+		case *tomlTable:
+			if err := w.table(childPath(key), value, false); err != nil {
+				return err
+			}
+		case []interface{}:
+			for _, item := range value {
+				if err := w.table(childPath(key), item.(*tomlTable), true); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// scanTOMLLayout relève dans un document TOML valide ce que le décodage ne conserve pas: les
+// chemins des clés écrites « clé = valeur », pour que le reformatage ne les transforme pas en
+// sections, et les commentaires. Un commentaire est rattaché à la clé ou à l'en-tête de table
+// qui le suit, ou à celui de sa ligne s'il est en fin de ligne; ceux de l'intérieur d'une valeur
+// sur plusieurs lignes sont rattachés à sa clé. Les chemins des tableaux de tables n'ont pas
+// d'indice.
+func scanTOMLLayout(input string) *tomlLayout {
+	layout := &tomlLayout{Inline: make(map[string]bool), Comments: make(map[string][]tomlComments)}
+	n := len(input)
+	var pending []string // Commentaires en attente de la clé ou de l'en-tête suivant
+
+	skipSpaces := func(i int) int {
+		for i < n && (input[i] == ' ' || input[i] == '\t') {
+			i++
+		}
+		return i
+	}
+	// readComment retourne le commentaire commençant en i et la position de la fin de sa ligne
+	readComment := func(i int) (string, int) {
+		start := i
+		for i < n && input[i] != '\n' {
+			i++
+		}
+		return strings.TrimRight(input[start:i], " \t\r"), i
+	}
+	// skipString retourne la position qui suit la chaîne commençant en i
+	skipString := func(i int) int {
+		quote := input[i]
+		if strings.HasPrefix(input[i:], strings.Repeat(string(quote), 3)) {
+			delim := strings.Repeat(string(quote), 3)
+			for j := i + 3; j < n; j++ {
+				if quote == '"' && input[j] == '\\' {
+					j++
+					continue
+				}
+				if strings.HasPrefix(input[j:], delim) {
+					// Jusqu'à deux guillemets peuvent précéder le délimiteur de fin
+					j += 3
+					for j < n && input[j] == quote {
+						j++
+					}
+					return j
+				}
+			}
+			return n
+		}
+		for j := i + 1; j < n && input[j] != '\n'; j++ {
+			if quote == '"' && input[j] == '\\' {
+				j++
+				continue
+			}
+			if input[j] == quote {
+				return j + 1
+			}
+		}
+		return n
+	}
+	parseKey := func(i int) (toml.Key, int) {
+		var key toml.Key
+		for {
+			i = skipSpaces(i)
+			if i >= n {
+				return key, i
+			}
+			start := i
+			switch input[i] {
+			case '"':
+				i = skipString(i)
+				part, err := strconv.Unquote(input[start:i])
+				if err != nil {
+					part = strings.Trim(input[start:i], `"`)
+				}
+				key = append(key, part)
+			case '\'':
+				i = skipString(i)
+				key = append(key, strings.Trim(input[start:i], "'"))
+			default:
+				for i < n && tomlBareKey.MatchString(input[i:i+1]) {
+					i++
+				}
+				if i == start {
+					return key, i
+				}
+				key = append(key, input[start:i])
+			}
+			i = skipSpaces(i)
+			if i >= n || input[i] != '.' {
+				return key, i
+			}
+			i++
+		}
+	}
+	// skipValue retourne la fin de la valeur commençant en i (tableaux sur plusieurs lignes
+	// compris), les commentaires de l'intérieur de la valeur et celui de sa dernière ligne
+	skipValue := func(i int) (end int, inner []string, trailing string) {
+		depth := 0
+		lastComment := -1 // Fin du dernier commentaire lu
+	scan:
+		for i < n {
+			switch c := input[i]; {
+			case c == '"' || c == '\'':
+				i = skipString(i)
+				continue
+			case c == '#':
+				var comment string
+				comment, i = readComment(i)
+				inner = append(inner, comment)
+				lastComment = i
+				continue
+			case c == '[' || c == '{':
+				depth++
+			case c == ']' || c == '}':
+				depth--
+			case c == '\n' && depth <= 0:
+				break scan
+			}
+			i++
+		}
+		if lastComment == i {
+			trailing, inner = inner[len(inner)-1], inner[:len(inner)-1]
+		}
+		return i, inner, trailing
+	}
+	record := func(key string, trailing string) {
+		layout.Comments[key] = append(layout.Comments[key], tomlComments{Leading: pending, Trailing: trailing})
+		pending = nil
+	}
+
+	var table toml.Key
+	for i := 0; i < n; {
+		switch input[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		case '#':
+			var comment string
+			comment, i = readComment(i)
+			pending = append(pending, comment)
+		case '[':
+			i++
+			if i < n && input[i] == '[' {
+				i++
+			}
+			table, i = parseKey(i)
+			for i < n && input[i] == ']' {
+				i++
+			}
+			trailing := ""
+			if i = skipSpaces(i); i < n && input[i] == '#' {
+				trailing, i = readComment(i)
+			}
+			record("["+table.String(), trailing)
+		default:
+			key, next := parseKey(i)
+			if len(key) == 0 {
+				i++ // Document déjà validé: ne devrait pas arriver
+				continue
+			}
+			i = skipSpaces(next)
+			if i < n && input[i] == '=' {
+				i++
+			}
+			path := append(table[:len(table):len(table)], key...).String()
+			layout.Inline[path] = true
+			end, inner, trailing := skipValue(skipSpaces(i))
+			pending = append(pending, inner...)
+			record(path, trailing)
+			i = end
+		}
+	}
+	layout.Final = pending
+	return layout
+}
+
+// isTOMLSection indique si la valeur s'écrit sous un en-tête: table, ou tableau non vide
+// ne contenant que des tables
+func isTOMLSection(value interface{}) bool {
+	switch v := value.(type) {
+	case *tomlTable:
+		return true
+	case []interface{}:
+		if len(v) == 0 {
+			return false
+		}
+		for _, item := range v {
+			if _, ok := item.(*tomlTable); !ok {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// tomlInline écrit une valeur sur une ligne (tables en ligne pour les tables dans les tableaux)
+func tomlInline(value interface{}) string {
+	switch v := value.(type) {
+	case *tomlTable:
+		if len(v.Keys) == 0 {
+			return "{}"
+		}
+		parts := make([]string, len(v.Keys))
+		for i, key := range v.Keys {
+			parts[i] = tomlKey(key) + " = " + tomlInline(v.Values[key])
+		}
+		return "{ " + strings.Join(parts, ", ") + " }"
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = tomlInline(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "nan"
+		case math.IsInf(v, 1):
+			return "inf"
+		case math.IsInf(v, -1):
+			return "-inf"
+		}
+		return formatTOMLFloat(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return formatTOMLDatetime(v)
+	default:
+		return tomlString(fmt.Sprint(v))
+	}
+}
+
+// formatTOMLFloat écrit un flottant en gardant une partie décimale (1 → 1.0) pour qu'il
+// reste un flottant après conversion
+func formatTOMLFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// formatTOMLDatetime écrit une date TOML; les dates, heures et dates-heures locales
+// (sans fuseau) sont identifiées par le fuseau que leur attribue le décodeur
+func formatTOMLDatetime(t time.Time) string {
+	switch t.Location().String() {
+	case "datetime-local":
+		return t.Format("2006-01-02T15:04:05.999999999")
+	case "date-local":
+		return t.Format("2006-01-02")
+	case "time-local":
+		return t.Format("15:04:05.999999999")
+	default:
+		return t.Format(time.RFC3339Nano)
+	}
+}
+
+// tomlKey écrit une clé, entre guillemets si elle contient d'autres caractères que
+// lettres, chiffres, - et _
+func tomlKey(key string) string {
+	if tomlBareKey.MatchString(key) {
+		return key
+	}
+	return tomlString(key)
+}
+
+// tomlString écrit une chaîne TOML de base (entre guillemets doubles)
+func tomlString(s string) string {
+	var out strings.Builder
+	out.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			out.WriteString(`\"`)
+		case '\\':
+			out.WriteString(`\\`)
+		case '\n':
+			out.WriteString(`\n`)
+		case '\r':
+			out.WriteString(`\r`)
+		case '\t':
+			out.WriteString(`\t`)
+		case '\b':
+			out.WriteString(`\b`)
+		case '\f':
+			out.WriteString(`\f`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&out, `\u%04X`, r)
+			} else {
+				out.WriteRune(r)
+			}
+		}
+	}
+	out.WriteByte('"')
+	return out.String()
+}
@@ -0,0 +1,154 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFormatTOML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "ordre des clés conservé",
+			input: "b = 1\na   =   \"x\"\n",
+			want:  "b = 1\na = \"x\"\n",
+		},
+		{
+			name:  "tables et tableaux de tables",
+			input: "titre = \"t\"\n[serveur]\nport = 8080\n[[produit]]\nnom = \"a\"\n[[produit]]\nnom = \"b\"\n",
+			want:  "titre = \"t\"\n\n[serveur]\nport = 8080\n\n[[produit]]\nnom = \"a\"\n\n[[produit]]\nnom = \"b\"\n",
+		},
+		{
+			name:  "commentaires conservés",
+			input: "# en-tête\n\n# avant a\na = 1 # après a\n\n# avant la table\n[t] # après l'en-tête\nb = [1, 2] # fin\n# fin du document\n",
+			want:  "# en-tête\n# avant a\na = 1 # après a\n\n# avant la table\n[t] # après l'en-tête\nb = [1, 2] # fin\n\n# fin du document\n",
+		},
+		{
+			name:  "commentaire dans une valeur multiligne",
+			input: "a = [\n  1, # un\n  2,\n]\n",
+			want:  "# un\na = [1, 2]\n",
+		},
+		{
+			name:  "dièse dans une chaîne",
+			input: "a = \"pas # un commentaire\"\n",
+			want:  "a = \"pas # un commentaire\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatTOML(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("formatTOML: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("formatTOML =\n%s\nattendu\n%s", got, tt.want)
+			}
+			// Le résultat est stable et reste un document TOML valide
+			again, err := formatTOML(context.Background(), got)
+			if err != nil {
+				t.Fatalf("formatTOML du résultat: %v", err)
+			}
+			if again != got {
+				t.Errorf("second formatage différent:\n%s", again)
+			}
+		})
+	}
+}
+
+func TestTOMLToJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"types scalaires", "s = \"x\"\ni = 42\nf = 1.5\nb = true\n", `{"s":"x","i":42,"f":1.5,"b":true}`},
+		{"ordre des tables", "[z]\na = 1\n[a]\nb = 2\n", `{"z":{"a":1},"a":{"b":2}}`},
+		{"table implicite", "[a.b]\nc = 1\n", `{"a":{"b":{"c":1}}}`},
+		{"tableau de tables", "[[p]]\nn = 1\n[[p]]\nn = 2\n", `{"p":[{"n":1},{"n":2}]}`},
+		{"date et heure", "d = 1979-05-27T07:32:00Z\nj = 1979-05-27\n", `{"d":"1979-05-27T07:32:00Z","j":"1979-05-27"}`},
+		{"flottant entier", "f = 3.0\n", `{"f":3.0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewTOMLViewModel()
+			if err := vm.LoadConfiguration(TOMLOptions{Mode: TOMLModeToJSON, IndentType: IndentMinified}); err != nil {
+				t.Fatal(err)
+			}
+			got, err := vm.Process(tt.input)
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if got != tt.want+"\n" {
+				t.Errorf("Process = %q, attendu %q", got, tt.want+"\n")
+			}
+		})
+	}
+}
+
+func TestJSONToTOML(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string // Extrait attendu du message d'erreur ("" : succès attendu)
+	}{
+		{"objet simple", `{"b": 1, "a": "x", "t": {"c": [1, 2]}}`, "b = 1\na = \"x\"\n\n[t]\nc = [1, 2]\n", ""},
+		{"tableau de tables", `{"p": [{"n": 1}, {"n": 2}]}`, "[[p]]\nn = 1\n\n[[p]]\nn = 2\n", ""},
+		{"clé à guillemets", `{"a b": 1}`, "\"a b\" = 1\n", ""},
+		{"null refusé", `{"a": {"b": null}}`, "", "a.b: null"},
+		{"entier hors limites", `{"x": 12345678901234567890}`, "", "x: l'entier 12345678901234567890"},
+		{"racine non objet", `[1]`, "", "doit être un objet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fromJSONToTOML(context.Background(), tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("erreur %v, attendu un message contenant %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("fromJSONToTOML: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("fromJSONToTOML =\n%s\nattendu\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		line    int
+		column  int
+		wantMsg string // Extrait attendu du message
+	}{
+		{"entier hors de l'intervalle int64", "a = 1\nx = 12345678901234567890\n", 2, 5, "out of range for int64"},
+		{"clé en double", "a = 1\na = 2\n", 2, 1, ""},
+		{"valeur manquante", "a =\n", 1, 4, ""},
+		{"chaîne non terminée", "a = \"x\n", 1, 7, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseTOML(context.Background(), tt.input)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("erreur %v (%T), attendu une *SyntaxError", err, err)
+			}
+			if syntaxErr.Line != tt.line || syntaxErr.Column != tt.column {
+				t.Errorf("ligne %d, colonne %d, attendu ligne %d, colonne %d (%v)", syntaxErr.Line, syntaxErr.Column, tt.line, tt.column, err)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("erreur %v, attendu un message contenant %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
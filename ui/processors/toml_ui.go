@@ -0,0 +1,312 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Modes du processeur TOML
+const (
+	TOMLModeValidate = "Valider"
+	TOMLModeFormat   = "Reformater"
+	TOMLModeToJSON   = "TOML → JSON"
+	TOMLModeFromJSON = "JSON → TOML"
+)
+
+// TOMLModes liste les modes acceptés par TOMLOptions.Mode
+var TOMLModes = []string{TOMLModeValidate, TOMLModeFormat, TOMLModeToJSON, TOMLModeFromJSON}
+
+// TOMLUI implémente Processor pour les documents TOML
+type TOMLUI struct {
+	viewModel *TOMLViewModel
+}
+
+func NewTOMLUI() Processor {
+	return &TOMLUI{
+		viewModel: NewTOMLViewModel(),
+	}
+}
+
+func (ui *TOMLUI) Name() string {
+	return "TOML"
+}
+
+func (ui *TOMLUI) Description() string {
+	return "Valide, reformate et convertit des documents TOML (TOML ⇄ JSON)"
+}
+
+func (ui *TOMLUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *TOMLUI) CreateConfigurationUI() fyne.CanvasObject {
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("[server]\nhost = \"localhost\"\nport = 8080")
+	input.Wrapping = fyne.TextWrapOff
+	input.SetMinRowsVisible(8)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapOff
+	output.Disable()
+
+	status := widget.NewLabel("")
+
+	indentSelect := widget.NewSelect(JSONOutputIndentTypes, nil)
+	indentSelect.SetSelected(ui.viewModel.indentType)
+
+	modeSelect := widget.NewSelect(TOMLModes, nil)
+	modeSelect.SetSelected(ui.viewModel.mode)
+
+	processBtn := widget.NewButton("Traiter", func() {
+		result, err := ui.viewModel.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			status.SetText("")
+			highlightJSONError(input, err)
+			return
+		}
+		output.SetText(result)
+		if ui.viewModel.mode == TOMLModeValidate {
+			status.SetText("Document TOML valide")
+		} else {
+			status.SetText("")
+		}
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := ui.viewModel.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	// L'indentation ne concerne que la sortie JSON
+	updateIndent := func() {
+		if ui.viewModel.mode == TOMLModeToJSON {
+			indentSelect.Enable()
+		} else {
+			indentSelect.Disable()
+		}
+	}
+	updateIndent()
+
+	modeSelect.OnChanged = func(s string) {
+		ui.viewModel.mode = s
+		updateIndent()
+		if input.Text != "" {
+			processBtn.OnTapped()
+		}
+	}
+
+	indentSelect.OnChanged = func(s string) {
+		ui.viewModel.indentType = s
+		if input.Text != "" {
+			processBtn.OnTapped()
+		}
+	}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée:"),
+		input,
+		container.NewHBox(
+			widget.NewLabel("Mode:"),
+			modeSelect,
+			widget.NewLabel("Indentation JSON:"),
+			indentSelect,
+			processBtn,
+			copyBtn,
+		),
+		container.NewHBox(
+			widget.NewLabel("Résultat:"),
+			status,
+		),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// TOMLOptions configuration du ViewModel TOML
+type TOMLOptions struct {
+	Mode       string // Une des valeurs de TOMLModes
+	IndentType string // Sortie JSON (voir JSONOutputIndentTypes)
+}
+
+// TOMLViewModel implémente ViewModel pour le processeur TOML
+type TOMLViewModel struct {
+	mode       string
+	indentType string
+	lastResult string
+}
+
+func NewTOMLViewModel() *TOMLViewModel {
+	return &TOMLViewModel{
+		mode:       TOMLModeFormat,
+		indentType: "2 espaces",
+	}
+}
+
+func (vm *TOMLViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext traite le document TOML en s'interrompant si le contexte est annulé (après le
+// décodage, qui n'est pas interruptible, puis à chaque clé et à chaque table)
+func (vm *TOMLViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		vm.lastResult = ""
+		return "", nil
+	}
+
+	var result string
+	var err error
+	switch vm.mode {
+	case TOMLModeValidate:
+		// Le document est transmis tel quel s'il est valide
+		_, err = parseTOML(ctx, input)
+		result = input
+	case TOMLModeFormat:
+		result, err = formatTOML(ctx, input)
+	case TOMLModeToJSON:
+		result, err = vm.toJSON(ctx, input)
+	case TOMLModeFromJSON:
+		result, err = fromJSONToTOML(ctx, input)
+	default:
+		err = fmt.Errorf("mode TOML inconnu: %s", vm.mode)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	vm.lastResult = result
+	return result, nil
+}
+
+// formatTOML reformate le document. Les commentaires, que le décodage ne conserve pas, sont
+// relevés par scanTOMLLayout et réécrits avec la clé ou la table à laquelle ils se rapportent.
+func formatTOML(ctx context.Context, input string) (string, error) {
+	table, err := parseTOML(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return writeTOML(ctx, table, scanTOMLLayout(input))
+}
+
+// toJSON convertit le document TOML en JSON en conservant l'ordre des clés
+func (vm *TOMLViewModel) toJSON(ctx context.Context, input string) (string, error) {
+	table, err := parseTOML(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	tree, err := tomlToJSON(ctx, table, "")
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	tree.Write(&out, NewFormatter(vm.indentType).indent(), false)
+	out.WriteString("\n")
+	return out.String(), nil
+}
+
+// fromJSONToTOML convertit un objet JSON en document TOML
+func fromJSONToTOML(ctx context.Context, input string) (string, error) {
+	tree, err := parseJSONTree(input)
+	if err != nil {
+		return "", err
+	}
+	if tree.Kind != jsonObject {
+		return "", fmt.Errorf("le document JSON doit être un objet pour être converti en TOML")
+	}
+	table, err := jsonToTOML(ctx, tree, "")
+	if err != nil {
+		return "", err
+	}
+	return writeTOML(ctx, table.(*tomlTable), nil)
+}
+
+func (vm *TOMLViewModel) GetConfiguration() interface{} {
+	return TOMLOptions{
+		Mode:       vm.mode,
+		IndentType: vm.indentType,
+	}
+}
+
+func (vm *TOMLViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(TOMLOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.mode = cfg.Mode
+	vm.indentType = cfg.IndentType
+	if vm.indentType == "" {
+		vm.indentType = "2 espaces"
+	}
+	return nil
+}
+
+func (vm *TOMLViewModel) Validate() error {
+	return vm.GetConfiguration().(TOMLOptions).Validate()
+}
+
+// Validate vérifie le mode et, s'il est utilisé, le type d'indentation
+func (o TOMLOptions) Validate() error {
+	if !slices.Contains(TOMLModes, o.Mode) {
+		return fmt.Errorf("mode TOML invalide: %s", o.Mode)
+	}
+	if o.Mode == TOMLModeToJSON {
+		return validateOutputIndent(o.IndentType)
+	}
+	return nil
+}
+
+func (vm *TOMLViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// TOMLTool identifiant de l'outil "TOML" dans les pipelines sérialisés
+const TOMLTool ToolType = "toml"
+
+func init() {
+	Register(Registration{
+		Type:        TOMLTool,
+		Name:        "TOML",
+		Description: "Valide, reformate et convertit des documents TOML (TOML ⇄ JSON)",
+		New:         NewTOMLUI,
+		Codec:       NewConfigCodec(TOMLConfig.Options, func(o TOMLOptions) TOMLConfig { return TOMLConfig(o) }),
+	})
+}
+
+// TOMLConfig configuration pour le processeur TOML
+type TOMLConfig struct {
+	Mode       string // "Valider", "Reformater", "TOML → JSON", "JSON → TOML"
+	IndentType string `json:",omitempty"` // Sortie JSON, "2 espaces" par défaut
+}
+
+func (c TOMLConfig) GetType() ToolType {
+	return TOMLTool
+}
+
+func (c TOMLConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c TOMLConfig) GetDisplayName() string {
+	return fmt.Sprintf("TOML (%s)", c.Mode)
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c TOMLConfig) Options() TOMLOptions {
+	return TOMLOptions{Mode: c.Mode, IndentType: c.IndentType}
+}
@@ -1,11 +1,215 @@
 package processors
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// JSONError erreur de validation JSON localisée dans le texte d'entrée
+type JSONError struct {
+	Offset  int64  // Position de l'erreur en octets (à partir de 0)
+	Line    int    // Ligne de l'erreur (à partir de 1)
+	Column  int    // Colonne de l'erreur en caractères (à partir de 1)
+	Snippet string // Ligne fautive suivie d'un caret sous la position de l'erreur
+	Hint    string // Suggestion pour les erreurs courantes (peut être vide)
+	Err     error  // Erreur d'origine (*json.SyntaxError, *json.UnmarshalTypeError...)
+}
+
+func (e *JSONError) Error() string {
+	return fmt.Sprintf("ligne %d, colonne %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *JSONError) Unwrap() error {
+	return e.Err
+}
+
+func (e *JSONError) Pretty() string {
+	var typeErr *json.UnmarshalTypeError
+	kind := "Erreur de syntaxe"
+	if errors.As(e.Err, &typeErr) {
+		kind = "Type incorrect"
+	}
+	text := fmt.Sprintf("%s ligne %d, colonne %d: %v\n\n%s", kind, e.Line, e.Column, e.Err, e.Snippet)
+	if e.Hint != "" {
+		text += "\n\nAstuce: " + e.Hint
+	}
+	return text
+}
+
+// SyntaxError erreur localisée dans un document d'un autre format que JSON (YAML...)
+type SyntaxError struct {
+	Format  string // Nom du format pour l'affichage ("YAML"...)
+	Line    int    // Ligne de l'erreur (à partir de 1, 0 si inconnue)
+	Column  int    // Colonne de l'erreur en caractères (à partir de 1, 0 si inconnue)
+	Snippet string // Ligne fautive, suivie d'un caret si la colonne est connue
+	Err     error
+}
+
+func (e *SyntaxError) Error() string {
+	switch {
+	case e.Line == 0:
+		return e.Err.Error()
+	case e.Column == 0:
+		return fmt.Sprintf("ligne %d: %v", e.Line, e.Err)
+	default:
+		return fmt.Sprintf("ligne %d, colonne %d: %v", e.Line, e.Column, e.Err)
+	}
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+func (e *SyntaxError) Pretty() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("Erreur %s: %v", e.Format, e.Err)
+	}
+	return fmt.Sprintf("Erreur %s %v\n\n%s", e.Format, e, e.Snippet)
+}
+
+// newSyntaxError construit une SyntaxError pour la ligne et la colonne données (à partir de 1)
+func newSyntaxError(format, input string, line, column int, err error) *SyntaxError {
+	syntaxErr := &SyntaxError{Format: format, Line: line, Column: column, Err: err}
+	lines := strings.Split(input, "\n")
+	if line < 1 || line > len(lines) {
+		syntaxErr.Line, syntaxErr.Column = 0, 0
+		return syntaxErr
+	}
+	lineText := strings.TrimRight(lines[line-1], "\r")
+	if column < 1 {
+		syntaxErr.Snippet = lineText
+		return syntaxErr
+	}
+	prefix := []rune(lineText)
+	syntaxErr.Snippet = snippetWithCaret(lineText, string(prefix[:min(column-1, len(prefix))]))
+	return syntaxErr
+}
 
 // ValidateJSON vérifie si une chaîne est un JSON valide
 func ValidateJSON(input string) error {
 	var js interface{}
-	return json.Unmarshal([]byte(input), &js)
+	if err := json.Unmarshal([]byte(input), &js); err != nil {
+		return LocateJSONError(input, err)
+	}
+	return nil
+}
+
+// LocateJSONError enrichit une erreur de encoding/json avec la ligne, la colonne et un extrait
+// du texte d'entrée. Les autres erreurs sont retournées telles quelles.
+func LocateJSONError(input string, err error) error {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		// Offset indique le nombre d'octets lus: le caractère fautif est le dernier lu
+		offset = syntaxErr.Offset
+		if offset > 0 && offset <= int64(len(input)) && !strings.Contains(syntaxErr.Error(), "unexpected end") {
+			offset--
+		}
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return err
+	}
+
+	jsonErr := newJSONError(input, offset, err)
+	jsonErr.Hint = jsonErrorHint(input, offset, err)
+	return jsonErr
+}
+
+// newJSONError construit une JSONError pointant sur l'octet offset de input
+func newJSONError(input string, offset int64, err error) *JSONError {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > int64(len(input)) {
+		offset = int64(len(input))
+	}
+
+	before := input[:offset]
+	line := strings.Count(before, "\n") + 1
+	lineStart := strings.LastIndex(before, "\n") + 1
+	lineEnd := strings.IndexByte(input[lineStart:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(input)
+	} else {
+		lineEnd += lineStart
+	}
+	lineText := strings.TrimRight(input[lineStart:lineEnd], "\r")
+	prefix := input[lineStart:offset]
+
+	return &JSONError{
+		Offset:  offset,
+		Line:    line,
+		Column:  utf8.RuneCountInString(prefix) + 1,
+		Snippet: snippetWithCaret(lineText, prefix),
+		Err:     err,
+	}
+}
+
+// snippetWithCaret retourne la ligne suivie d'un caret aligné sous la fin de prefix
+// (les tabulations du préfixe sont conservées pour garder l'alignement)
+func snippetWithCaret(lineText, prefix string) string {
+	var caret strings.Builder
+	for _, r := range prefix {
+		if r == '\t' {
+			caret.WriteRune('\t')
+		} else {
+			caret.WriteRune(' ')
+		}
+	}
+	caret.WriteRune('^')
+	return lineText + "\n" + caret.String()
+}
+
+// jsonErrorHint propose une explication pour les erreurs JSON les plus fréquentes
+func jsonErrorHint(input string, offset int64, err error) string {
+	msg := err.Error()
+	if strings.Contains(msg, "unexpected end of JSON input") {
+		return "le document se termine trop tôt: accolade, crochet ou guillemet non fermé ?"
+	}
+
+	var current byte
+	if offset < int64(len(input)) {
+		current = input[offset]
+	}
+	previous := strings.TrimRight(input[:offset], " \t\r\n")
+
+	switch {
+	case (current == '}' || current == ']') && strings.HasSuffix(previous, ","):
+		return fmt.Sprintf("virgule en trop avant '%c': les virgules finales ne sont pas autorisées en JSON", current)
+	case current == '\'':
+		return "les chaînes JSON doivent être entourées de guillemets doubles (\"), pas de guillemets simples"
+	case current == '/':
+		return "les commentaires (// ou /* */) ne sont pas autorisés en JSON"
+	case strings.Contains(msg, "looking for beginning of object key string") && isIdentifierStart(current):
+		return "les clés d'objet doivent être entourées de guillemets doubles"
+	case current < ' ' && strings.Contains(msg, "in string"):
+		return "caractère de contrôle dans une chaîne: échappez les retours à la ligne (\\n) et les tabulations (\\t)"
+	case strings.Contains(msg, "after object key:value pair") || strings.Contains(msg, "after array element"):
+		return "virgule manquante entre deux éléments ?"
+	case strings.Contains(msg, "after object key"):
+		return "deux-points (:) manquant après la clé ?"
+	case strings.Contains(msg, "looking for beginning of value") && isIdentifierStart(current):
+		return "valeur non reconnue: seuls true, false, null, les nombres et les chaînes entre guillemets doubles sont autorisés"
+	}
+	return ""
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// PrettyError est implémentée par les erreurs qui ont leur propre présentation détaillée
+// (position, extrait, liste de violations...) pour l'affichage
+type PrettyError interface {
+	error
+	Pretty() string
 }
 
 // PrettyValidationError formate les erreurs de validation pour l'affichage
@@ -14,11 +218,16 @@ func PrettyValidationError(err error) string {
 		return ""
 	}
 
+	var prettyErr PrettyError
+	if errors.As(err, &prettyErr) {
+		return prettyErr.Pretty()
+	}
+
 	switch e := err.(type) {
 	case *json.SyntaxError:
-		return "Erreur de syntaxe à la position " + string(e.Offset) + ": " + e.Error()
+		return "Erreur de syntaxe à la position " + strconv.FormatInt(e.Offset, 10) + ": " + e.Error()
 	case *json.UnmarshalTypeError:
-		return "Type incorrect à la position " + string(e.Offset) +
+		return "Type incorrect à la position " + strconv.FormatInt(e.Offset, 10) +
 			". Attendu: " + e.Type.String() + ", Reçu: " + e.Value
 	default:
 		return "Erreur de validation: " + err.Error()
@@ -0,0 +1,111 @@
+package processors
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateJSONLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		line     int
+		column   int
+		wantHint string // Extrait attendu de l'astuce ("" : aucune astuce)
+	}{
+		{"littéral incomplet", `{"a": tru,}`, 1, 10, ""},
+		{"virgule finale", "{\n  \"a\": 1,\n}", 3, 1, "virgule en trop avant '}'"},
+		{"virgule finale de tableau", `[1, 2, ]`, 1, 8, "virgule en trop avant ']'"},
+		{"guillemets simples", `{'a': 1}`, 1, 2, "guillemets doubles"},
+		{"clé sans guillemets", `{a: 1}`, 1, 2, "clés d'objet"},
+		{"commentaire", "// config\n{}", 1, 1, "commentaires"},
+		{"virgule manquante", `[1 2]`, 1, 4, "virgule manquante"},
+		{"deux-points manquant", `{"a" 1}`, 1, 6, "deux-points"},
+		{"retour à la ligne dans une chaîne", "[\"a\nb\"]", 1, 4, "caractère de contrôle"},
+		{"fin prématurée", `{"a": [1`, 1, 9, "se termine trop tôt"},
+		{"colonne en caractères", `{"é": x}`, 1, 7, "valeur non reconnue"},
+		{"fins de ligne CRLF", "{\r\n  \"a\": ,\r\n}", 2, 8, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJSON(tt.input)
+			var jsonErr *JSONError
+			if !errors.As(err, &jsonErr) {
+				t.Fatalf("erreur %v (%T), attendu une *JSONError", err, err)
+			}
+			if jsonErr.Line != tt.line || jsonErr.Column != tt.column {
+				t.Errorf("position ligne %d, colonne %d, attendu ligne %d, colonne %d", jsonErr.Line, jsonErr.Column, tt.line, tt.column)
+			}
+			if tt.wantHint == "" && jsonErr.Hint != "" {
+				t.Errorf("astuce %q, attendu aucune", jsonErr.Hint)
+			}
+			if !strings.Contains(jsonErr.Hint, tt.wantHint) {
+				t.Errorf("astuce %q, attendu une astuce contenant %q", jsonErr.Hint, tt.wantHint)
+			}
+			if strings.Contains(jsonErr.Snippet, "\r") {
+				t.Errorf("extrait %q avec un retour chariot", jsonErr.Snippet)
+			}
+		})
+	}
+}
+
+func TestJSONErrorSnippet(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"caret sous le caractère fautif", `{"a": tru,}`, "{\"a\": tru,}\n         ^"},
+		{"tabulations conservées", "{\n\t\"a\": ,\n}", "\t\"a\": ,\n\t     ^"},
+		{"caractères multi-octets", `["é", x]`, "[\"é\", x]\n      ^"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jsonErr *JSONError
+			if err := ValidateJSON(tt.input); !errors.As(err, &jsonErr) {
+				t.Fatalf("erreur %v, attendu une *JSONError", err)
+			}
+			if jsonErr.Snippet != tt.want {
+				t.Errorf("extrait\n%s\nattendu\n%s", jsonErr.Snippet, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocateJSONErrorPassthrough(t *testing.T) {
+	other := errors.New("autre erreur")
+	if err := LocateJSONError("{}", other); err != other {
+		t.Errorf("LocateJSONError = %v, attendu l'erreur d'origine", err)
+	}
+	if err := ValidateJSON(`{"a": [1, 2]}`); err != nil {
+		t.Errorf("ValidateJSON d'un document valide: %v", err)
+	}
+}
+
+func TestErrorTokenSpan(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		column int
+		want   string // Texte sélectionné
+	}{
+		{"caractère seul", `{"a": tru,}`, 9, ","},
+		{"mot", `{"a": tru,}`, 6, "tru"},
+		{"nombre", `[1, -2.5e3 x]`, 4, "-2.5e3"},
+		{"chaîne", `{'clé': 1}`, 1, "'clé'"},
+		{"chaîne avec échappement", `["a\"b" 1]`, 1, `"a\"b"`},
+		{"chaîne non fermée", `["abc`, 1, `"abc`},
+		{"fin de ligne", `{"a": 1`, 7, `{"a": 1`},
+		{"colonne négative", `x y`, -1, "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := []rune(tt.line)
+			start, end := errorTokenSpan(line, tt.column)
+			if got := string(line[start:end]); got != tt.want {
+				t.Errorf("errorTokenSpan(%q, %d) = %q, attendu %q", tt.line, tt.column, got, tt.want)
+			}
+		})
+	}
+}
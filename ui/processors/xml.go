@@ -0,0 +1,324 @@
+package processors
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Formatage XML. Le document est lu avec encoding/xml (RawToken, pour conserver les préfixes
+// d'espaces de noms tels qu'écrits) dans un arbre xmlNode, puis réécrit avec l'indentation
+// demandée. Le texte est recopié tel qu'écrit dans l'entrée (entités, sections CDATA).
+
+// xmlNodeKind type d'un nœud XML
+type xmlNodeKind int
+
+const (
+	xmlElement xmlNodeKind = iota
+	xmlText
+	xmlComment
+	xmlProcInst
+	xmlDirective
+)
+
+// xmlNode nœud de l'arbre XML
+type xmlNode struct {
+	Kind     xmlNodeKind
+	Name     string     // Éléments: nom avec préfixe ("soap:Envelope")
+	Attrs    []xml.Attr // Éléments: attributs dans l'ordre d'origine
+	Raw      string     // Texte tel qu'écrit, contenu d'un commentaire, instruction ou directive
+	Children []*xmlNode
+}
+
+// xmlPosition position d'un jeton dans l'entrée (à partir de 1)
+type xmlPosition struct {
+	Line, Column int
+}
+
+// parseXML vérifie que le document est bien formé et construit son arbre. Les nœuds de
+// premier niveau (prologue, commentaires, élément racine) sont retournés dans l'ordre.
+// L'annulation du contexte est vérifiée à chaque jeton lu.
+func parseXML(ctx context.Context, input string) ([]*xmlNode, error) {
+	dec := xml.NewDecoder(strings.NewReader(input))
+	// Le texte est déjà en UTF-8: l'encodage déclaré dans le prologue est ignoré
+	dec.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) {
+		return r, nil
+	}
+
+	fail := func(pos xmlPosition, format string, args ...interface{}) error {
+		return newSyntaxError("XML", input, pos.Line, pos.Column, fmt.Errorf(format, args...))
+	}
+
+	var top []*xmlNode
+	var stack []*xmlNode
+	var openedAt []xmlPosition
+	hasRoot := false
+	for {
+		if cancelled(ctx) {
+			return nil, ctx.Err()
+		}
+		line, column := dec.InputPos()
+		pos := xmlPosition{line, column}
+		start := dec.InputOffset()
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, locateXMLError(input, dec, err)
+		}
+		raw := input[start:dec.InputOffset()]
+
+		var node *xmlNode
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 {
+				if hasRoot {
+					return nil, fail(pos, "plusieurs éléments racine: <%s> après l'élément racine", xmlName(t.Name))
+				}
+				hasRoot = true
+			}
+			node = &xmlNode{Kind: xmlElement, Name: xmlName(t.Name), Attrs: t.Attr}
+		case xml.EndElement:
+			name := xmlName(t.Name)
+			if len(stack) == 0 {
+				return nil, fail(pos, "balise fermante </%s> sans balise ouvrante", name)
+			}
+			if open := stack[len(stack)-1]; open.Name != name {
+				return nil, fail(pos, "balise fermante </%s> inattendue: </%s> attendue (ouverte ligne %d)",
+					name, open.Name, openedAt[len(openedAt)-1].Line)
+			}
+			stack, openedAt = stack[:len(stack)-1], openedAt[:len(openedAt)-1]
+			continue
+		case xml.CharData:
+			if len(stack) == 0 {
+				if trimmed := strings.TrimLeft(raw, " \t\r\n"); trimmed != "" {
+					// Désigner le premier caractère du texte plutôt que les espaces qui le précèdent
+					blank := raw[:len(raw)-len(trimmed)]
+					if newlines := strings.Count(blank, "\n"); newlines > 0 {
+						pos = xmlPosition{pos.Line + newlines, len(blank) - strings.LastIndex(blank, "\n")}
+					} else {
+						pos.Column += len(blank)
+					}
+					return nil, fail(pos, "texte en dehors de l'élément racine")
+				}
+				continue
+			}
+			node = &xmlNode{Kind: xmlText, Raw: raw}
+		case xml.Comment:
+			node = &xmlNode{Kind: xmlComment, Raw: string(t)}
+		case xml.ProcInst:
+			node = &xmlNode{Kind: xmlProcInst, Raw: raw}
+		case xml.Directive:
+			node = &xmlNode{Kind: xmlDirective, Raw: raw}
+		}
+
+		if len(stack) == 0 {
+			top = append(top, node)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, node)
+		}
+		if node.Kind == xmlElement {
+			stack = append(stack, node)
+			openedAt = append(openedAt, pos)
+		}
+	}
+
+	if len(stack) > 0 {
+		open := stack[len(stack)-1]
+		return nil, fail(openedAt[len(openedAt)-1], "élément <%s> non fermé", open.Name)
+	}
+	if !hasRoot {
+		return nil, &SyntaxError{Format: "XML", Err: errors.New("aucun élément racine")}
+	}
+	return top, nil
+}
+
+// locateXMLError convertit une *xml.SyntaxError en *SyntaxError; la colonne n'est indiquée
+// que si la position courante du décodeur est sur la ligne de l'erreur
+func locateXMLError(input string, dec *xml.Decoder, err error) error {
+	var syntaxErr *xml.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return &SyntaxError{Format: "XML", Err: err}
+	}
+	column := 0
+	if line, col := dec.InputPos(); line == syntaxErr.Line {
+		column = col
+	}
+	return newSyntaxError("XML", input, syntaxErr.Line, column, errors.New(syntaxErr.Msg))
+}
+
+// xmlName nom qualifié tel qu'écrit dans le document
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// xmlAttrEscaper échappe les valeurs d'attributs (entre guillemets doubles)
+var xmlAttrEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	`"`, "&quot;",
+	"\t", "&#x9;",
+	"\n", "&#xA;",
+	"\r", "&#xD;",
+)
+
+// xmlWriter réécrit un arbre XML. Un indent vide produit un document minifié.
+type xmlWriter struct {
+	ctx           context.Context
+	out           strings.Builder
+	indent        string
+	stripComments bool
+	err           error // Annulation constatée: le reste de l'arbre n'est pas écrit
+}
+
+// writeXML réécrit le document: chaque élément ne contenant que des éléments est indenté,
+// le contenu mixte (texte et éléments) et xml:space="preserve" sont recopiés sans changement.
+// L'annulation du contexte est vérifiée à chaque élément écrit.
+func writeXML(ctx context.Context, nodes []*xmlNode, indent string, stripComments bool) (string, error) {
+	w := &xmlWriter{ctx: ctx, indent: indent, stripComments: stripComments}
+	for _, n := range nodes {
+		if n.Kind == xmlComment && stripComments {
+			continue
+		}
+		if w.out.Len() > 0 && indent != "" {
+			w.out.WriteString("\n")
+		}
+		w.node(n, 0)
+	}
+	if w.err != nil {
+		return "", w.err
+	}
+	if indent != "" {
+		w.out.WriteString("\n")
+	}
+	return w.out.String(), nil
+}
+
+// node écrit un nœud indenté à la profondeur depth
+func (w *xmlWriter) node(n *xmlNode, depth int) {
+	if w.err != nil {
+		return
+	}
+	if cancelled(w.ctx) {
+		w.err = w.ctx.Err()
+		return
+	}
+	if w.indent != "" {
+		w.out.WriteString(strings.Repeat(w.indent, depth))
+	}
+	if n.Kind != xmlElement {
+		w.inline(n)
+		return
+	}
+
+	children := w.children(n)
+	mixed := preservesSpace(n) || hasText(children)
+	if !mixed {
+		children = withoutText(children) // Espaces entre éléments
+	}
+
+	w.startTag(n)
+	if len(children) == 0 {
+		w.out.WriteString("/>")
+		return
+	}
+	w.out.WriteString(">")
+	if mixed {
+		for _, child := range children {
+			w.inline(child)
+		}
+	} else {
+		for _, child := range children {
+			if w.indent != "" {
+				w.out.WriteString("\n")
+			}
+			w.node(child, depth+1)
+		}
+		if w.indent != "" {
+			w.out.WriteString("\n" + strings.Repeat(w.indent, depth))
+		}
+	}
+	w.out.WriteString("</" + n.Name + ">")
+}
+
+// inline écrit un nœud sans ajouter d'espaces (contenu mixte)
+func (w *xmlWriter) inline(n *xmlNode) {
+	switch n.Kind {
+	case xmlText, xmlProcInst, xmlDirective:
+		w.out.WriteString(n.Raw)
+	case xmlComment:
+		w.out.WriteString("<!--" + n.Raw + "-->")
+	default:
+		w.startTag(n)
+		if len(n.Children) == 0 {
+			w.out.WriteString("/>")
+			return
+		}
+		w.out.WriteString(">")
+		for _, child := range n.Children {
+			if child.Kind == xmlComment && w.stripComments {
+				continue
+			}
+			w.inline(child)
+		}
+		w.out.WriteString("</" + n.Name + ">")
+	}
+}
+
+func (w *xmlWriter) startTag(n *xmlNode) {
+	w.out.WriteString("<" + n.Name)
+	for _, attr := range n.Attrs {
+		fmt.Fprintf(&w.out, ` %s="%s"`, xmlName(attr.Name), xmlAttrEscaper.Replace(attr.Value))
+	}
+}
+
+// children retourne les enfants à écrire (sans les commentaires s'ils sont supprimés)
+func (w *xmlWriter) children(n *xmlNode) []*xmlNode {
+	if !w.stripComments {
+		return n.Children
+	}
+	var children []*xmlNode
+	for _, child := range n.Children {
+		if child.Kind != xmlComment {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// hasText indique si des nœuds texte non blancs sont présents (contenu mixte)
+func hasText(nodes []*xmlNode) bool {
+	for _, n := range nodes {
+		if n.Kind == xmlText && strings.TrimSpace(n.Raw) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutText(nodes []*xmlNode) []*xmlNode {
+	var result []*xmlNode
+	for _, n := range nodes {
+		if n.Kind != xmlText {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func preservesSpace(n *xmlNode) bool {
+	for _, attr := range n.Attrs {
+		if attr.Name.Space == "xml" && attr.Name.Local == "space" {
+			return attr.Value == "preserve"
+		}
+	}
+	return false
+}
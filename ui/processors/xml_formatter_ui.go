@@ -0,0 +1,220 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// XMLFormatterUI implémente Processor pour le formateur XML
+type XMLFormatterUI struct {
+	viewModel *XMLFormatterViewModel
+}
+
+func NewXMLFormatterUI() Processor {
+	return &XMLFormatterUI{
+		viewModel: NewXMLFormatterViewModel(),
+	}
+}
+
+func (ui *XMLFormatterUI) Name() string {
+	return "Formateur XML"
+}
+
+func (ui *XMLFormatterUI) Description() string {
+	return "Vérifie qu'un document XML est bien formé et le ré-indente ou le minifie"
+}
+
+func (ui *XMLFormatterUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *XMLFormatterUI) CreateConfigurationUI() fyne.CanvasObject {
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("Entrez votre XML ici...")
+	input.Wrapping = fyne.TextWrapOff
+	input.SetMinRowsVisible(8)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapOff
+	output.Disable()
+
+	indentSelect := widget.NewSelect(JSONOutputIndentTypes, nil)
+	indentSelect.SetSelected(ui.viewModel.indentType)
+
+	stripCheck := widget.NewCheck("Supprimer les commentaires", nil)
+	stripCheck.SetChecked(ui.viewModel.stripComments)
+
+	formatBtn := widget.NewButton("Formater", func() {
+		result, err := ui.viewModel.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			highlightJSONError(input, err)
+			return
+		}
+		output.SetText(result)
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := ui.viewModel.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	indentSelect.OnChanged = func(s string) {
+		ui.viewModel.indentType = s
+		if input.Text != "" {
+			formatBtn.OnTapped()
+		}
+	}
+
+	stripCheck.OnChanged = func(checked bool) {
+		ui.viewModel.stripComments = checked
+		if input.Text != "" {
+			formatBtn.OnTapped()
+		}
+	}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée XML:"),
+		input,
+		container.NewHBox(
+			formatBtn,
+			widget.NewLabel("Indentation:"),
+			indentSelect,
+			stripCheck,
+		),
+		container.NewHBox(
+			widget.NewLabel("Résultat formaté:"),
+			copyBtn,
+		),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// XMLFormatterOptions configuration du ViewModel du formateur XML
+type XMLFormatterOptions struct {
+	IndentType    string // Une des valeurs de JSONOutputIndentTypes ("Minifié" compris)
+	StripComments bool
+}
+
+// XMLFormatterViewModel implémente ViewModel pour le formateur XML
+type XMLFormatterViewModel struct {
+	indentType    string
+	stripComments bool
+	lastResult    string
+}
+
+func NewXMLFormatterViewModel() *XMLFormatterViewModel {
+	return &XMLFormatterViewModel{
+		indentType: "2 espaces",
+	}
+}
+
+func (vm *XMLFormatterViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext formate le document XML en s'interrompant si le contexte est annulé
+func (vm *XMLFormatterViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		vm.lastResult = ""
+		return "", nil
+	}
+
+	nodes, err := parseXML(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := writeXML(ctx, nodes, NewFormatter(vm.indentType).indent(), vm.stripComments)
+	if err != nil {
+		return "", err
+	}
+	vm.lastResult = result
+	return result, nil
+}
+
+func (vm *XMLFormatterViewModel) GetConfiguration() interface{} {
+	return XMLFormatterOptions{
+		IndentType:    vm.indentType,
+		StripComments: vm.stripComments,
+	}
+}
+
+func (vm *XMLFormatterViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(XMLFormatterOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.indentType = cfg.IndentType
+	if vm.indentType == "" {
+		vm.indentType = "2 espaces"
+	}
+	vm.stripComments = cfg.StripComments
+	return nil
+}
+
+func (vm *XMLFormatterViewModel) Validate() error {
+	return vm.GetConfiguration().(XMLFormatterOptions).Validate()
+}
+
+// Validate vérifie le type d'indentation (valeur vide acceptée)
+func (o XMLFormatterOptions) Validate() error {
+	return validateOutputIndent(o.IndentType)
+}
+
+func (vm *XMLFormatterViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// XMLFormatterTool identifiant de l'outil "XML Formatter" dans les pipelines sérialisés
+const XMLFormatterTool ToolType = "xml_formatter"
+
+func init() {
+	Register(Registration{
+		Type:        XMLFormatterTool,
+		Name:        "XML Formatter",
+		Description: "Vérifie qu'un document XML est bien formé et le ré-indente ou le minifie",
+		New:         NewXMLFormatterUI,
+		Codec:       NewConfigCodec(XMLFormatterConfig.Options, func(o XMLFormatterOptions) XMLFormatterConfig { return XMLFormatterConfig(o) }),
+	})
+}
+
+// XMLFormatterConfig configuration pour le formateur XML
+type XMLFormatterConfig struct {
+	IndentType    string // "2 espaces", "4 espaces", "Tabulations" ou "Minifié"
+	StripComments bool   `json:",omitempty"`
+}
+
+func (c XMLFormatterConfig) GetType() ToolType {
+	return XMLFormatterTool
+}
+
+func (c XMLFormatterConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c XMLFormatterConfig) GetDisplayName() string {
+	if c.StripComments {
+		return fmt.Sprintf("XML Formatter (Indentation: %s, sans commentaires)", c.IndentType)
+	}
+	return fmt.Sprintf("XML Formatter (Indentation: %s)", c.IndentType)
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c XMLFormatterConfig) Options() XMLFormatterOptions {
+	return XMLFormatterOptions{IndentType: c.IndentType, StripComments: c.StripComments}
+}
@@ -0,0 +1,133 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFormatXML(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		indent        string
+		stripComments bool
+		want          string
+	}{
+		{
+			name:   "indentation",
+			input:  `<?xml version="1.0"?><a><b x="1"/><c><d>texte</d></c></a>`,
+			indent: "  ",
+			want:   "<?xml version=\"1.0\"?>\n<a>\n  <b x=\"1\"/>\n  <c>\n    <d>texte</d>\n  </c>\n</a>\n",
+		},
+		{
+			name:   "minifié",
+			input:  "<a>\n  <b/>\n  <!-- note -->\n</a>",
+			indent: "",
+			want:   "<a><b/><!-- note --></a>",
+		},
+		{
+			name:          "commentaires supprimés",
+			input:         "<!-- début --><a><!-- x --><b>t<!-- y --></b></a>",
+			indent:        "  ",
+			stripComments: true,
+			want:          "<a>\n  <b>t</b>\n</a>\n",
+		},
+		{
+			name:   "contenu mixte conservé",
+			input:  "<p>Un <b>mot</b> en gras</p>",
+			indent: "  ",
+			want:   "<p>Un <b>mot</b> en gras</p>\n",
+		},
+		{
+			name:   "xml:space preserve",
+			input:  "<a><pre xml:space=\"preserve\">\n  <b/>\n</pre></a>",
+			indent: "  ",
+			want:   "<a>\n  <pre xml:space=\"preserve\">\n  <b/>\n</pre>\n</a>\n",
+		},
+		{
+			name:   "entités et CDATA recopiées",
+			input:  "<a>&lt;&amp;<![CDATA[<x>]]></a>",
+			indent: "  ",
+			want:   "<a>&lt;&amp;<![CDATA[<x>]]></a>\n",
+		},
+		{
+			name:   "préfixes d'espaces de noms et ordre des attributs",
+			input:  `<soap:Envelope xmlns:soap="urn:s" b="2" a="1"><soap:Body/></soap:Envelope>`,
+			indent: "\t",
+			want:   "<soap:Envelope xmlns:soap=\"urn:s\" b=\"2\" a=\"1\">\n\t<soap:Body/>\n</soap:Envelope>\n",
+		},
+		{
+			name:   "attribut à échapper",
+			input:  `<a t="x &amp; &quot;y&quot; &lt;"/>`,
+			indent: "  ",
+			want:   "<a t=\"x &amp; &quot;y&quot; &lt;\"/>\n",
+		},
+		{
+			name:   "doctype",
+			input:  "<!DOCTYPE a><a/>",
+			indent: "  ",
+			want:   "<!DOCTYPE a>\n<a/>\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := parseXML(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("parseXML: %v", err)
+			}
+			got, err := writeXML(context.Background(), nodes, tt.indent, tt.stripComments)
+			if err != nil {
+				t.Fatalf("writeXML: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("writeXML =\n%q\nattendu\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseXMLErrors(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		input  string
+		line   int // Ligne attendue de la *SyntaxError (-1 : autre erreur)
+		column int
+	}{
+		{"balise fermante inattendue", context.Background(), "<a>\n  <b>\n  </c>\n</a>", 3, 3},
+		{"élément non fermé", context.Background(), "<a>\n  <b>\n</a>", 3, 1},
+		{"élément racine non fermé", context.Background(), "<a>\n  <b/>", 1, 1},
+		{"plusieurs éléments racine", context.Background(), "<a/>\n<b/>", 2, 1},
+		{"texte hors de l'élément racine", context.Background(), "<a/>\ntexte", 2, 1},
+		{"texte après des espaces", context.Background(), "<a/>  texte", 1, 7},
+		{"balise fermante seule", context.Background(), "</a>", 1, 1},
+		{"aucun élément racine", context.Background(), "<!-- vide -->", 0, 0},
+		{"attribut sans guillemets", context.Background(), "<a x=1/>", 1, 0},
+		{"contexte annulé", cancelled, "<a/>", -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseXML(tt.ctx, tt.input)
+			if err == nil {
+				t.Fatal("erreur attendue")
+			}
+			if tt.line < 0 {
+				if !errors.Is(err, context.Canceled) {
+					t.Errorf("erreur %v, attendu %v", err, context.Canceled)
+				}
+				return
+			}
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("erreur %v (%T), attendu une *SyntaxError", err, err)
+			}
+			if syntaxErr.Line != tt.line || (tt.column > 0 && syntaxErr.Column != tt.column) {
+				t.Errorf("ligne %d, colonne %d, attendu ligne %d, colonne %d (%v)", syntaxErr.Line, syntaxErr.Column, tt.line, tt.column, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Conversion YAML ⇄ JSON. Les documents YAML sont lus sous forme de yaml.Node pour
+// conserver l'ordre des clés, puis convertis en arbre JSON (jsonNode) et inversement.
+
+// maxYAMLValues limite le nombre de valeurs produites par l'expansion des alias
+// (protection contre les documents « billion laughs »)
+const maxYAMLValues = 1 << 20
+
+var (
+	// yamlLineError extrait la ligne des erreurs de gopkg.in/yaml.v3 ("yaml: line 3: ...")
+	yamlLineError = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+	// jsonNumberLiteral nombres dont l'écriture YAML est aussi un nombre JSON valide
+	jsonNumberLiteral = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+	// yaml11Literal chaînes lues comme booléens ou nombres sexagésimaux par les analyseurs
+	// YAML 1.1 (kubectl, PyYAML...): elles sont écrites entre guillemets
+	yaml11Literal = regexp.MustCompile(`^(y|Y|yes|Yes|YES|n|N|no|No|NO|on|On|ON|off|Off|OFF|[-+]?[0-9][0-9_]*(:[0-5]?[0-9])+(\.[0-9_]*)?)$`)
+)
+
+// parseYAMLDocuments analyse un flux YAML (documents séparés par ---) et convertit chaque
+// document en arbre JSON en conservant l'ordre des clés. L'annulation du contexte est vérifiée
+// entre les documents et à chaque valeur convertie.
+func parseYAMLDocuments(ctx context.Context, input string) ([]*jsonNode, error) {
+	dec := yaml.NewDecoder(strings.NewReader(input))
+	conv := &yamlConverter{ctx: ctx, input: input}
+
+	var documents []*jsonNode
+	for {
+		if cancelled(ctx) {
+			return nil, ctx.Err()
+		}
+		var doc yaml.Node
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, locateYAMLError(input, err)
+		}
+		node, err := conv.convert(&doc)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, node)
+	}
+	return documents, nil
+}
+
+// locateYAMLError convertit une erreur de gopkg.in/yaml.v3 en *SyntaxError avec un extrait
+// de la ligne fautive
+func locateYAMLError(input string, err error) error {
+	if m := yamlLineError.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return newSyntaxError("YAML", input, line, 0, errors.New(m[2]))
+	}
+	return &SyntaxError{Format: "YAML", Err: errors.New(strings.TrimPrefix(err.Error(), "yaml: "))}
+}
+
+// yamlConverter convertit les yaml.Node d'un flux en jsonNode
+type yamlConverter struct {
+	ctx   context.Context
+	input string
+	count int // Valeurs produites, alias développés compris
+}
+
+// fail retourne une erreur localisée sur le nœud YAML
+func (c *yamlConverter) fail(n *yaml.Node, format string, args ...interface{}) error {
+	return newSyntaxError("YAML", c.input, n.Line, n.Column, fmt.Errorf(format, args...))
+}
+
+func (c *yamlConverter) convert(n *yaml.Node) (*jsonNode, error) {
+	if cancelled(c.ctx) {
+		return nil, c.ctx.Err()
+	}
+	c.count++
+	if c.count > maxYAMLValues {
+		return nil, c.fail(n, "document trop volumineux après expansion des alias (plus de %d valeurs)", maxYAMLValues)
+	}
+
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return newJSONNull(), nil
+		}
+		return c.convert(n.Content[0])
+	case yaml.AliasNode:
+		return c.convert(n.Alias)
+	case yaml.SequenceNode:
+		array := newJSONArray()
+		for _, item := range n.Content {
+			value, err := c.convert(item)
+			if err != nil {
+				return nil, err
+			}
+			array.Items = append(array.Items, value)
+		}
+		return array, nil
+	case yaml.MappingNode:
+		object := newJSONObject()
+		if err := c.mapping(object, n); err != nil {
+			return nil, err
+		}
+		return object, nil
+	default:
+		return c.scalar(n)
+	}
+}
+
+// mapping ajoute les paires du nœud à object. Les clés de fusion (<<: *ancre) n'ajoutent que
+// les clés absentes du nœud: les clés explicites sont toujours prioritaires.
+func (c *yamlConverter) mapping(object *jsonNode, n *yaml.Node) error {
+	explicit := make(map[string]int) // Clé → ligne de sa définition
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		keyNode := n.Content[i]
+		if keyNode.ShortTag() == "!!merge" {
+			continue
+		}
+		key, err := c.key(keyNode)
+		if err != nil {
+			return err
+		}
+		if line, ok := explicit[key]; ok {
+			return c.fail(keyNode, "clé %q déjà définie ligne %d", key, line)
+		}
+		explicit[key] = keyNode.Line
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		keyNode, valueNode := n.Content[i], n.Content[i+1]
+		if keyNode.ShortTag() == "!!merge" {
+			if err := c.merge(object, valueNode, explicit); err != nil {
+				return err
+			}
+			continue
+		}
+		key, _ := c.key(keyNode)
+		value, err := c.convert(valueNode)
+		if err != nil {
+			return err
+		}
+		object.Set(key, value)
+	}
+	return nil
+}
+
+// merge applique une clé de fusion: une table, un alias vers une table ou une liste de tables
+// (la première de la liste est prioritaire)
+func (c *yamlConverter) merge(object *jsonNode, n *yaml.Node, explicit map[string]int) error {
+	source := n
+	if source.Kind == yaml.AliasNode {
+		source = source.Alias
+	}
+	switch source.Kind {
+	case yaml.MappingNode:
+		merged, err := c.convert(source)
+		if err != nil {
+			return err
+		}
+		for _, m := range merged.Members {
+			if _, ok := explicit[m.Key]; ok {
+				continue
+			}
+			if _, ok := object.Get(m.Key); !ok {
+				object.Set(m.Key, m.Value)
+			}
+		}
+		return nil
+	case yaml.SequenceNode:
+		for _, item := range source.Content {
+			if item.Kind == yaml.SequenceNode {
+				return c.fail(item, "une clé de fusion (<<) attend une table ou une liste de tables")
+			}
+			if err := c.merge(object, item, explicit); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return c.fail(n, "une clé de fusion (<<) attend une table ou une liste de tables")
+	}
+}
+
+// key retourne la clé JSON d'une clé YAML: seules les clés scalaires sont convertibles
+func (c *yamlConverter) key(n *yaml.Node) (string, error) {
+	resolved := n
+	if resolved.Kind == yaml.AliasNode {
+		resolved = resolved.Alias
+	}
+	if resolved.Kind != yaml.ScalarNode {
+		return "", c.fail(n, "clé complexe (table ou liste) non convertible en JSON")
+	}
+	if resolved.ShortTag() == "!!null" {
+		return "null", nil
+	}
+	return resolved.Value, nil
+}
+
+// scalar convertit un scalaire selon son type YAML résolu. Les nombres déjà valides en JSON
+// sont repris tels quels pour conserver leur précision; les dates, données binaires et tags
+// personnalisés deviennent des chaînes.
+func (c *yamlConverter) scalar(n *yaml.Node) (*jsonNode, error) {
+	switch n.ShortTag() {
+	case "!!null":
+		return newJSONNull(), nil
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return nil, c.fail(n, "booléen invalide %q", n.Value)
+		}
+		return newJSONBool(b), nil
+	case "!!int":
+		if jsonNumberLiteral.MatchString(n.Value) {
+			return newJSONNumber(n.Value), nil
+		}
+		var v interface{}
+		if err := n.Decode(&v); err != nil {
+			return nil, c.fail(n, "entier invalide %q", n.Value)
+		}
+		switch v.(type) {
+		case int, int64, uint64:
+			return newJSONNumber(fmt.Sprint(v)), nil
+		}
+		return nil, c.fail(n, "entier invalide %q", n.Value)
+	case "!!float":
+		if jsonNumberLiteral.MatchString(n.Value) {
+			return newJSONNumber(n.Value), nil
+		}
+		var f float64
+		if err := n.Decode(&f); err != nil {
+			return nil, c.fail(n, "nombre invalide %q", n.Value)
+		}
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, c.fail(n, "%s n'est pas représentable en JSON", n.Value)
+		}
+		return newJSONNumber(strconv.FormatFloat(f, 'g', -1, 64)), nil
+	default:
+		return newJSONString(n.Value), nil
+	}
+}
+
+// yamlNode convertit un arbre JSON en yaml.Node. En cas de clés en double, seule la dernière
+// occurrence est conservée (une clé ne peut apparaître qu'une fois dans une table YAML).
+func yamlNode(n *jsonNode) *yaml.Node {
+	switch n.Kind {
+	case jsonNull:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	case jsonBool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(n.Bool)}
+	case jsonNumber:
+		tag := "!!int"
+		if strings.ContainsAny(n.Raw, ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: n.Raw}
+	case jsonString:
+		node := yamlString(n.Str)
+		if node.Style == 0 && strings.Contains(n.Str, "\n") {
+			// Bloc littéral (|) pour les textes multilignes; l'encodeur revient aux guillemets
+			// si le texte ne s'y prête pas
+			node.Style = yaml.LiteralStyle
+		}
+		return node
+	case jsonArray:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range n.Items {
+			node.Content = append(node.Content, yamlNode(item))
+		}
+		return node
+	default:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, m := range n.Members {
+			if current, _ := n.Get(m.Key); current != m.Value {
+				continue
+			}
+			node.Content = append(node.Content, yamlString(m.Key), yamlNode(m.Value))
+		}
+		return node
+	}
+}
+
+// yamlString retourne le scalaire YAML d'une chaîne, entre guillemets si un analyseur
+// YAML 1.1 la lirait comme un booléen ou un nombre
+func yamlString(s string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+	if yaml11Literal.MatchString(s) {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+	return node
+}
+
+// writeYAMLDocuments écrit les documents dans un flux YAML, séparés par ---, en vérifiant
+// l'annulation du contexte entre les documents
+func writeYAMLDocuments(ctx context.Context, documents []*jsonNode, indent int) (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(indent)
+	for _, doc := range documents {
+		if cancelled(ctx) {
+			return "", ctx.Err()
+		}
+		if err := enc.Encode(yamlNode(doc)); err != nil {
+			return "", err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
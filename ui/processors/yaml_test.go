@@ -0,0 +1,169 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseYAMLDocuments(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string // Documents en JSON compact
+	}{
+		{"ordre des clés conservé", "b: 1\na: 2\n", []string{`{"b":1,"a":2}`}},
+		{"scalaires typés", "s: texte\nn: ~\nt: true\ni: 42\nf: 1.50\n", []string{`{"s":"texte","n":null,"t":true,"i":42,"f":1.50}`}},
+		{"grand entier exact", "id: 12345678901234567890\n", []string{`{"id":12345678901234567890}`}},
+		{"entiers hexadécimal et octal", "h: 0x1F\no: 0o17\n", []string{`{"h":31,"o":15}`}},
+		{"flottant YAML", "f: .5\ne: 1e3\n", []string{`{"f":0.5,"e":1e3}`}},
+		{"chaînes entre guillemets", "a: \"42\"\nb: 'true'\n", []string{`{"a":"42","b":"true"}`}},
+		{"date conservée en chaîne", "d: 2024-01-02\n", []string{`{"d":"2024-01-02"}`}},
+		{"listes et tables imbriquées", "l:\n  - a\n  - {x: 1}\n", []string{`{"l":["a",{"x":1}]}`}},
+		{"alias", "base: &b {x: 1}\ncopie: *b\n", []string{`{"base":{"x":1},"copie":{"x":1}}`}},
+		{"clé de fusion", "base: &b {x: 1, y: 2}\nobj:\n  <<: *b\n  y: 3\n", []string{`{"base":{"x":1,"y":2},"obj":{"x":1,"y":3}}`}},
+		{"fusion de plusieurs tables", "a: &a {x: 1}\nb: &b {x: 2, z: 2}\nc:\n  <<: [*a, *b]\n", []string{`{"a":{"x":1},"b":{"x":2,"z":2},"c":{"x":1,"z":2}}`}},
+		{"clé nulle", "~: 1\n", []string{`{"null":1}`}},
+		{"plusieurs documents", "a: 1\n---\n- 2\n---\n", []string{`{"a":1}`, `[2]`, `null`}},
+		{"commentaires seuls", "# rien\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documents, err := parseYAMLDocuments(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("parseYAMLDocuments: %v", err)
+			}
+			var got []string
+			for _, doc := range documents {
+				got = append(got, jsonLiteral(doc))
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("documents %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  int // Ligne attendue de la *SyntaxError (0 : inconnue)
+	}{
+		{"indentation incohérente", "a:\n  b: 1\n c: 2\n", 2},
+		{"clé en double", "a: 1\nb: 2\na: 3\n", 3},
+		{"clé complexe", "? [1, 2]\n: x\n", 1},
+		{"infini non représentable", "x: .inf\n", 1},
+		{"fusion d'une valeur scalaire", "a:\n  <<: 1\n", 2},
+		{"alias inconnu", "a: *absent\n", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseYAMLDocuments(context.Background(), tt.input)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("erreur %v (%T), attendu une *SyntaxError", err, err)
+			}
+			if syntaxErr.Line != tt.line {
+				t.Errorf("ligne %d, attendu %d (%v)", syntaxErr.Line, tt.line, err)
+			}
+		})
+	}
+}
+
+func TestParseYAMLAliasExpansion(t *testing.T) {
+	// « Billion laughs »: chaque niveau multiplie par 10 le nombre de valeurs
+	var doc strings.Builder
+	doc.WriteString("a0: &a0 [x, x, x, x, x, x, x, x, x, x]\n")
+	for i := 1; i <= 7; i++ {
+		prev := "*a" + string(rune('0'+i-1))
+		doc.WriteString("a" + string(rune('0'+i)) + ": &a" + string(rune('0'+i)) + " [")
+		doc.WriteString(strings.TrimSuffix(strings.Repeat(prev+", ", 10), ", "))
+		doc.WriteString("]\n")
+	}
+	if _, err := parseYAMLDocuments(context.Background(), doc.String()); err == nil {
+		t.Error("expansion des alias au-delà de maxYAMLValues: erreur attendue")
+	}
+}
+
+func TestYAMLToJSONDocuments(t *testing.T) {
+	tests := []struct {
+		name      string
+		documents string
+		input     string
+		want      string
+	}{
+		{"document unique", YAMLDocumentsArray, "a: 1\n", "{\"a\":1}\n"},
+		{"flux en tableau", YAMLDocumentsArray, "a: 1\n---\nb: 2\n", "[{\"a\":1},{\"b\":2}]\n"},
+		{"flux en NDJSON", YAMLDocumentsNDJSON, "a: 1\n---\nb: 2\n", "{\"a\":1}\n{\"b\":2}\n"},
+		{"entrée vide", YAMLDocumentsArray, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewYAMLToJSONViewModel()
+			if err := vm.LoadConfiguration(YAMLToJSONOptions{IndentType: IndentMinified, Documents: tt.documents}); err != nil {
+				t.Fatal(err)
+			}
+			got, err := vm.Process(tt.input)
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Process = %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONToYAML(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		splitArray bool
+		want       string
+	}{
+		{"ordre des clés conservé", `{"b": 1, "a": [true, null]}`, false, "b: 1\na:\n  - true\n  - null\n"},
+		{"nombres tels qu'écrits", `{"id": 12345678901234567890, "f": 1.50}`, false, "id: 12345678901234567890\nf: 1.50\n"},
+		{"chaînes ambiguës en YAML 1.1", `{"a": "yes", "b": "off", "c": "1:30", "d": "oui"}`, false, "a: \"yes\"\nb: \"off\"\nc: \"1:30\"\nd: oui\n"},
+		{"chaînes ambiguës en YAML 1.2", `["true", "42", "null"]`, false, "- \"true\"\n- \"42\"\n- \"null\"\n"},
+		{"texte multiligne", `{"t": "a\nb\n"}`, false, "t: |\n  a\n  b\n"},
+		{"tableau en flux", `[{"a": 1}, 2]`, true, "a: 1\n---\n2\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewJSONToYAMLViewModel()
+			if err := vm.LoadConfiguration(JSONToYAMLOptions{IndentType: "2 espaces", SplitArray: tt.splitArray}); err != nil {
+				t.Fatal(err)
+			}
+			got, err := vm.Process(tt.input)
+			if err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Process =\n%s\nattendu\n%s", got, tt.want)
+			}
+
+			// Relu en YAML, le résultat redonne le document JSON
+			documents, err := parseYAMLDocuments(context.Background(), got)
+			if err != nil {
+				t.Fatalf("relecture du YAML produit: %v", err)
+			}
+			original, _ := parseJSONTree(tt.input)
+			if !tt.splitArray && (len(documents) != 1 || !jsonEqual(documents[0], original)) {
+				t.Errorf("YAML relu %v, attendu %s", documents, jsonLiteral(original))
+			}
+		})
+	}
+}
+
+// Une clé ne peut apparaître qu'une fois dans une table YAML: seule la dernière occurrence est écrite
+func TestJSONToYAMLDuplicateKeys(t *testing.T) {
+	got, err := NewJSONToYAMLViewModel().Process(`{"a": 1, "b": 2, "a": 3}`)
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if want := "b: 2\na: 3\n"; got != want {
+		t.Errorf("Process = %q, attendu %q", got, want)
+	}
+}
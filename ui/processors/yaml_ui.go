@@ -0,0 +1,478 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Sortie JSON d'un flux YAML contenant plusieurs documents
+const (
+	YAMLDocumentsArray  = "Tableau JSON"
+	YAMLDocumentsNDJSON = "Un document par ligne (NDJSON)"
+)
+
+// YAMLDocumentsModes liste les valeurs acceptées pour YAMLToJSONOptions.Documents
+var YAMLDocumentsModes = []string{YAMLDocumentsArray, YAMLDocumentsNDJSON}
+
+// YAMLIndentTypes liste les indentations proposées pour la sortie YAML
+var YAMLIndentTypes = []string{"2 espaces", "4 espaces"}
+
+// YAMLToJSONUI implémente Processor pour la conversion YAML → JSON
+type YAMLToJSONUI struct {
+	viewModel *YAMLToJSONViewModel
+}
+
+func NewYAMLToJSONUI() Processor {
+	return &YAMLToJSONUI{
+		viewModel: NewYAMLToJSONViewModel(),
+	}
+}
+
+func (ui *YAMLToJSONUI) Name() string {
+	return "YAML → JSON"
+}
+
+func (ui *YAMLToJSONUI) Description() string {
+	return "Convertit un ou plusieurs documents YAML en JSON en conservant l'ordre des clés"
+}
+
+func (ui *YAMLToJSONUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *YAMLToJSONUI) CreateConfigurationUI() fyne.CanvasObject {
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("apiVersion: v1\nkind: ConfigMap\n---\n...")
+	input.Wrapping = fyne.TextWrapOff
+	input.SetMinRowsVisible(8)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapOff
+	output.Disable()
+
+	status := widget.NewLabel("")
+
+	indentSelect := widget.NewSelect(JSONOutputIndentTypes, nil)
+	indentSelect.SetSelected(ui.viewModel.indentType)
+
+	documentsSelect := widget.NewSelect(YAMLDocumentsModes, nil)
+	documentsSelect.SetSelected(ui.viewModel.documents)
+
+	convertBtn := widget.NewButton("Convertir", func() {
+		result, err := ui.viewModel.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			status.SetText("")
+			highlightJSONError(input, err)
+			return
+		}
+		output.SetText(result)
+		status.SetText(fmt.Sprintf("%d document(s)", ui.viewModel.lastCount))
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := ui.viewModel.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	// Le mode NDJSON écrit chaque document sur une seule ligne
+	updateIndent := func() {
+		if ui.viewModel.documents == YAMLDocumentsNDJSON {
+			indentSelect.Disable()
+		} else {
+			indentSelect.Enable()
+		}
+	}
+	updateIndent()
+
+	indentSelect.OnChanged = func(s string) {
+		ui.viewModel.indentType = s
+		if input.Text != "" {
+			convertBtn.OnTapped()
+		}
+	}
+
+	documentsSelect.OnChanged = func(s string) {
+		ui.viewModel.documents = s
+		updateIndent()
+		if input.Text != "" {
+			convertBtn.OnTapped()
+		}
+	}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée YAML:"),
+		input,
+		container.NewHBox(
+			widget.NewLabel("Indentation:"),
+			indentSelect,
+			widget.NewLabel("Plusieurs documents:"),
+			documentsSelect,
+			convertBtn,
+			copyBtn,
+		),
+		container.NewHBox(
+			widget.NewLabel("Résultat JSON:"),
+			status,
+		),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// YAMLToJSONOptions configuration du ViewModel de conversion YAML → JSON
+type YAMLToJSONOptions struct {
+	IndentType string // Une des valeurs de JSONOutputIndentTypes
+	Documents  string // Une des valeurs de YAMLDocumentsModes, utilisée pour les flux multi-documents
+}
+
+// YAMLToJSONViewModel implémente ViewModel pour la conversion YAML → JSON.
+// Un document unique est converti tel quel; un flux de plusieurs documents devient un
+// tableau JSON ou une ligne NDJSON par document.
+type YAMLToJSONViewModel struct {
+	indentType string
+	documents  string
+	lastResult string
+	lastCount  int
+}
+
+func NewYAMLToJSONViewModel() *YAMLToJSONViewModel {
+	return &YAMLToJSONViewModel{
+		indentType: "2 espaces",
+		documents:  YAMLDocumentsArray,
+	}
+}
+
+func (vm *YAMLToJSONViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext convertit le flux YAML en s'interrompant si le contexte est annulé
+func (vm *YAMLToJSONViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	documents, err := parseYAMLDocuments(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	switch {
+	case len(documents) == 0:
+		// Entrée vide ou uniquement des commentaires
+	case vm.documents == YAMLDocumentsNDJSON:
+		for _, doc := range documents {
+			doc.Write(&out, "", false)
+			out.WriteString("\n")
+		}
+	case len(documents) == 1:
+		documents[0].Write(&out, NewFormatter(vm.indentType).indent(), false)
+		out.WriteString("\n")
+	default:
+		newJSONArray(documents...).Write(&out, NewFormatter(vm.indentType).indent(), false)
+		out.WriteString("\n")
+	}
+
+	vm.lastResult, vm.lastCount = out.String(), len(documents)
+	return vm.lastResult, nil
+}
+
+func (vm *YAMLToJSONViewModel) GetConfiguration() interface{} {
+	return YAMLToJSONOptions{
+		IndentType: vm.indentType,
+		Documents:  vm.documents,
+	}
+}
+
+func (vm *YAMLToJSONViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(YAMLToJSONOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.indentType = cfg.IndentType
+	if vm.indentType == "" {
+		vm.indentType = "2 espaces"
+	}
+	vm.documents = cfg.Documents
+	if vm.documents == "" {
+		vm.documents = YAMLDocumentsArray
+	}
+	return nil
+}
+
+func (vm *YAMLToJSONViewModel) Validate() error {
+	return vm.GetConfiguration().(YAMLToJSONOptions).Validate()
+}
+
+// Validate vérifie l'indentation et le mode multi-documents (valeurs vides acceptées)
+func (o YAMLToJSONOptions) Validate() error {
+	if o.Documents != "" && !slices.Contains(YAMLDocumentsModes, o.Documents) {
+		return fmt.Errorf("mode multi-documents invalide: %s", o.Documents)
+	}
+	return validateOutputIndent(o.IndentType)
+}
+
+func (vm *YAMLToJSONViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// JSONToYAMLUI implémente Processor pour la conversion JSON → YAML
+type JSONToYAMLUI struct {
+	viewModel *JSONToYAMLViewModel
+}
+
+func NewJSONToYAMLUI() Processor {
+	return &JSONToYAMLUI{
+		viewModel: NewJSONToYAMLViewModel(),
+	}
+}
+
+func (ui *JSONToYAMLUI) Name() string {
+	return "JSON → YAML"
+}
+
+func (ui *JSONToYAMLUI) Description() string {
+	return "Convertit un document JSON en YAML en conservant l'ordre des clés"
+}
+
+func (ui *JSONToYAMLUI) ViewModel() ViewModel {
+	return ui.viewModel
+}
+
+func (ui *JSONToYAMLUI) CreateConfigurationUI() fyne.CanvasObject {
+	input := widget.NewMultiLineEntry()
+	input.SetPlaceHolder("Entrez votre document JSON ici...")
+	input.Wrapping = fyne.TextWrapOff
+	input.SetMinRowsVisible(8)
+
+	output := widget.NewMultiLineEntry()
+	output.Wrapping = fyne.TextWrapOff
+	output.Disable()
+
+	indentSelect := widget.NewSelect(YAMLIndentTypes, nil)
+	indentSelect.SetSelected(ui.viewModel.indentType)
+
+	splitCheck := widget.NewCheck("Tableau → un document YAML par élément", nil)
+	splitCheck.SetChecked(ui.viewModel.splitArray)
+
+	convertBtn := widget.NewButton("Convertir", func() {
+		result, err := ui.viewModel.Process(input.Text)
+		if err != nil {
+			output.SetText(PrettyValidationError(err))
+			highlightJSONError(input, err)
+			return
+		}
+		output.SetText(result)
+	})
+
+	copyBtn := widget.NewButton("Copier", func() {
+		if result, _ := ui.viewModel.GetLastResult(); result != "" {
+			clipboard := fyne.CurrentApp().Driver().AllWindows()[0].Clipboard()
+			clipboard.SetContent(result)
+		}
+	})
+
+	indentSelect.OnChanged = func(s string) {
+		ui.viewModel.indentType = s
+		if input.Text != "" {
+			convertBtn.OnTapped()
+		}
+	}
+
+	splitCheck.OnChanged = func(checked bool) {
+		ui.viewModel.splitArray = checked
+		if input.Text != "" {
+			convertBtn.OnTapped()
+		}
+	}
+
+	topSection := container.NewVBox(
+		widget.NewLabel("Entrée JSON:"),
+		input,
+		container.NewHBox(
+			widget.NewLabel("Indentation:"),
+			indentSelect,
+			splitCheck,
+			convertBtn,
+			copyBtn,
+		),
+		widget.NewLabel("Résultat YAML:"),
+	)
+
+	return container.NewBorder(
+		topSection,
+		nil,
+		nil,
+		nil,
+		container.NewScroll(output),
+	)
+}
+
+// JSONToYAMLOptions configuration du ViewModel de conversion JSON → YAML
+type JSONToYAMLOptions struct {
+	IndentType string // Une des valeurs de YAMLIndentTypes
+	SplitArray bool   // Un tableau racine produit un flux YAML (un document par élément)
+}
+
+// JSONToYAMLViewModel implémente ViewModel pour la conversion JSON → YAML
+type JSONToYAMLViewModel struct {
+	indentType string
+	splitArray bool
+	lastResult string
+}
+
+func NewJSONToYAMLViewModel() *JSONToYAMLViewModel {
+	return &JSONToYAMLViewModel{
+		indentType: "2 espaces",
+	}
+}
+
+func (vm *JSONToYAMLViewModel) Process(input string) (string, error) {
+	return vm.ProcessContext(context.Background(), input)
+}
+
+// ProcessContext convertit le document JSON en s'interrompant si le contexte est annulé
+// (entre l'analyse du JSON et l'écriture de chaque document YAML)
+func (vm *JSONToYAMLViewModel) ProcessContext(ctx context.Context, input string) (string, error) {
+	if strings.TrimSpace(input) == "" {
+		vm.lastResult = ""
+		return "", nil
+	}
+
+	tree, err := parseJSONTree(input)
+	if err != nil {
+		return "", err
+	}
+	documents := []*jsonNode{tree}
+	if vm.splitArray && tree.Kind == jsonArray {
+		documents = tree.Items
+	}
+
+	result, err := writeYAMLDocuments(ctx, documents, NewFormatter(vm.indentType).IndentSize)
+	if err != nil {
+		return "", err
+	}
+
+	vm.lastResult = result
+	return result, nil
+}
+
+func (vm *JSONToYAMLViewModel) GetConfiguration() interface{} {
+	return JSONToYAMLOptions{
+		IndentType: vm.indentType,
+		SplitArray: vm.splitArray,
+	}
+}
+
+func (vm *JSONToYAMLViewModel) LoadConfiguration(config interface{}) error {
+	cfg, ok := config.(JSONToYAMLOptions)
+	if !ok {
+		return fmt.Errorf("configuration invalide")
+	}
+	vm.indentType = cfg.IndentType
+	if vm.indentType == "" {
+		vm.indentType = "2 espaces"
+	}
+	vm.splitArray = cfg.SplitArray
+	return nil
+}
+
+func (vm *JSONToYAMLViewModel) Validate() error {
+	return vm.GetConfiguration().(JSONToYAMLOptions).Validate()
+}
+
+// Validate vérifie l'indentation (valeur vide acceptée)
+func (o JSONToYAMLOptions) Validate() error {
+	if o.IndentType != "" && !slices.Contains(YAMLIndentTypes, o.IndentType) {
+		return fmt.Errorf("type d'indentation invalide: %s", o.IndentType)
+	}
+	return nil
+}
+
+func (vm *JSONToYAMLViewModel) GetLastResult() (string, error) {
+	return vm.lastResult, nil
+}
+
+// YAMLToJSONTool identifiant de l'outil "YAML → JSON" dans les pipelines sérialisés
+const YAMLToJSONTool ToolType = "yaml_to_json"
+
+func init() {
+	Register(Registration{
+		Type:        YAMLToJSONTool,
+		Name:        "YAML → JSON",
+		Description: "Convertit un ou plusieurs documents YAML en JSON",
+		New:         NewYAMLToJSONUI,
+		Codec:       NewConfigCodec(YAMLToJSONConfig.Options, func(o YAMLToJSONOptions) YAMLToJSONConfig { return YAMLToJSONConfig(o) }),
+	})
+}
+
+// YAMLToJSONConfig configuration pour la conversion YAML → JSON
+type YAMLToJSONConfig struct {
+	IndentType string `json:",omitempty"` // "2 espaces" par défaut
+	Documents  string `json:",omitempty"` // Flux multi-documents: "Tableau JSON" (défaut) ou NDJSON
+}
+
+func (c YAMLToJSONConfig) GetType() ToolType {
+	return YAMLToJSONTool
+}
+
+func (c YAMLToJSONConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c YAMLToJSONConfig) GetDisplayName() string {
+	return "YAML → JSON"
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c YAMLToJSONConfig) Options() YAMLToJSONOptions {
+	return YAMLToJSONOptions{IndentType: c.IndentType, Documents: c.Documents}
+}
+
+// JSONToYAMLTool identifiant de l'outil "JSON → YAML" dans les pipelines sérialisés
+const JSONToYAMLTool ToolType = "json_to_yaml"
+
+func init() {
+	Register(Registration{
+		Type:        JSONToYAMLTool,
+		Name:        "JSON → YAML",
+		Description: "Convertit un document JSON en YAML",
+		New:         NewJSONToYAMLUI,
+		Codec:       NewConfigCodec(JSONToYAMLConfig.Options, func(o JSONToYAMLOptions) JSONToYAMLConfig { return JSONToYAMLConfig(o) }),
+	})
+}
+
+// JSONToYAMLConfig configuration pour la conversion JSON → YAML
+type JSONToYAMLConfig struct {
+	IndentType string `json:",omitempty"` // "2 espaces" par défaut
+	SplitArray bool   `json:",omitempty"` // Un document YAML par élément du tableau racine
+}
+
+func (c JSONToYAMLConfig) GetType() ToolType {
+	return JSONToYAMLTool
+}
+
+func (c JSONToYAMLConfig) Validate() error {
+	return c.Options().Validate()
+}
+
+func (c JSONToYAMLConfig) GetDisplayName() string {
+	return "JSON → YAML"
+}
+
+// Options convertit la configuration pour le ViewModel
+func (c JSONToYAMLConfig) Options() JSONToYAMLOptions {
+	return JSONToYAMLOptions{IndentType: c.IndentType, SplitArray: c.SplitArray}
+}
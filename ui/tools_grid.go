@@ -14,35 +14,30 @@ type Tool struct {
 	MakeUI      func() fyne.CanvasObject
 }
 
+// registeredTools construit la liste des outils à partir du registre des processeurs
+func registeredTools() []Tool {
+	var tools []Tool
+	for _, reg := range processors.Registered() {
+		r := reg // capture
+		tools = append(tools, Tool{
+			Name:        r.Name,
+			Description: r.Description,
+			MakeUI: func() fyne.CanvasObject {
+				return r.New().CreateConfigurationUI()
+			},
+		})
+	}
+	return tools
+}
+
 func MakeToolsGrid(onToolSelect func(fyne.CanvasObject)) fyne.CanvasObject {
-	tools := []Tool{
+	tools := append([]Tool{
 		{
 			Name:        "Pipeline Builder",
 			Description: "Enchaîne plusieurs outils de traitement",
 			MakeUI:      MakePipelineBuilderUI,
 		},
-		{
-			Name:        "JSON Formatter",
-			Description: "Formate et valide du JSON",
-			MakeUI: func() fyne.CanvasObject {
-				return processors.NewJSONFormatterUI().CreateConfigurationUI()
-			},
-		},
-		{
-			Name:        "Text Splitter",
-			Description: "Divise du texte selon un délimiteur",
-			MakeUI: func() fyne.CanvasObject {
-				return processors.NewTextSplitterUI().CreateConfigurationUI()
-			},
-		},
-		{
-			Name:        "Text Joiner",
-			Description: "Joint du texte avec un délimiteur",
-			MakeUI: func() fyne.CanvasObject {
-				return processors.NewTextJoinerUI().CreateConfigurationUI()
-			},
-		},
-	}
+	}, registeredTools()...)
 
 	// Créer une grille qui s'adapte à l'espace disponible
 	// Utiliser 2 colonnes pour que les cartes soient bien disposées
@@ -78,29 +73,7 @@ func MakeToolsGrid(onToolSelect func(fyne.CanvasObject)) fyne.CanvasObject {
 
 // MakeProcessorsGrid affiche uniquement les processeurs (sans "Pipeline Builder") dans une grille
 func MakeProcessorsGrid(onToolSelect func(fyne.CanvasObject)) fyne.CanvasObject {
-	tools := []Tool{
-		{
-			Name:        "JSON Formatter",
-			Description: "Formate et valide du JSON",
-			MakeUI: func() fyne.CanvasObject {
-				return processors.NewJSONFormatterUI().CreateConfigurationUI()
-			},
-		},
-		{
-			Name:        "Text Splitter",
-			Description: "Divise du texte selon un délimiteur",
-			MakeUI: func() fyne.CanvasObject {
-				return processors.NewTextSplitterUI().CreateConfigurationUI()
-			},
-		},
-		{
-			Name:        "Text Joiner",
-			Description: "Joint du texte avec un délimiteur",
-			MakeUI: func() fyne.CanvasObject {
-				return processors.NewTextJoinerUI().CreateConfigurationUI()
-			},
-		},
-	}
+	tools := registeredTools()
 
 	// Ajouter les processeurs personnalisés à la grille
 	for _, custom := range GlobalCustomProcessorManager.GetProcessors() {
@@ -109,7 +82,7 @@ func MakeProcessorsGrid(onToolSelect func(fyne.CanvasObject)) fyne.CanvasObject
 			Name:        "Custom: " + c.Name,
 			Description: "Processeur personnalisé",
 			MakeUI: func() fyne.CanvasObject {
-				return processors.NewCustomProcessor(c.Name, c.Script).CreateConfigurationUI()
+				return processors.NewCustomProcessorWithOptions(c.Config().Options()).CreateConfigurationUI()
 			},
 		})
 	}